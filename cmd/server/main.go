@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/ko44d/go-clean-hexapp/config"
 	"github.com/ko44d/go-clean-hexapp/internal/container"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
 	"github.com/ko44d/go-clean-hexapp/internal/router"
 )
 
@@ -21,7 +23,10 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	r := router.New(c.Handler)
+	defer c.Close()
+
+	timeouts := middleware.Timeouts{Default: cfg.HTTP.RequestTimeout, Routes: cfg.HTTP.RouteTimeouts}
+	r := router.New(timeouts, c.RateLimit, c.Handler, c.ProjectHandler, c.LabelHandler, c.CommentHandler, c.AuditHandler)
 
 	addr := fmt.Sprintf(":%d", cfg.HTTP.Port)
 	log.Printf("server starting at %s", addr)
@@ -35,6 +40,10 @@ func main() {
 	}
 
 	if err := server.ListenAndServe(); err != nil {
-		log.Fatalf("server failed: %v", err)
+		// log.Fatal would skip the deferred Close, so close first and
+		// exit non-zero by hand.
+		log.Printf("server failed: %v", err)
+		c.Close()
+		os.Exit(1)
 	}
 }
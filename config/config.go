@@ -1,64 +1,258 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// DBConfig locates the Postgres database, either through URL (DATABASE_URL)
+// or through the discrete POSTGRES_* variables, and tunes its pools. Zero
+// pool settings keep the pgx defaults.
 type DBConfig struct {
+	URL      string
 	Host     string
 	Port     int
 	User     string
 	Password string
 	Name     string
 	SSLMode  string
+	// ReplicaDSN optionally points task reads at a read replica, which is
+	// pinged every ReplicaCheckInterval and skipped while it does not answer.
+	ReplicaDSN           string
+	ReplicaCheckInterval time.Duration
+
+	MaxConns          int
+	MinConns          int
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	StatementTimeout  time.Duration
+	ConnectTimeout    time.Duration
+	ApplicationName   string
+	// StartupTimeout is how long startup waits for the database to accept
+	// connections, so the app can start alongside it.
+	StartupTimeout time.Duration
+
+	// RetryAttempts bounds the attempts at a task operation that fails for
+	// transient reasons. BreakerThreshold operations in a row that cannot
+	// reach the database stop calls to it for BreakerCooldown.
+	RetryAttempts    int
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
+// HTTPConfig bounds each request by RequestTimeout, or by the timeout of its
+// route in RouteTimeouts, keyed by method and route pattern. A timeout of 0
+// leaves its requests unbounded.
 type HTTPConfig struct {
-	Port int
+	Port           int
+	RequestTimeout time.Duration
+	RouteTimeouts  map[string]time.Duration
+}
+
+type AuthzConfig struct {
+	PolicyFile     string
+	ReloadInterval time.Duration
+}
+
+type ReminderConfig struct {
+	Interval   time.Duration
+	Window     time.Duration
+	Notifier   string
+	SMTPAddr   string
+	SMTPFrom   string
+	SMTPTo     []string
+	WebhookURL string
+}
+
+// TrashConfig controls how long deleted tasks can be restored before the
+// purger removes them for good.
+type TrashConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+// StorageConfig selects where data is kept: "postgres", "sqlite" for a single
+// database file at SQLitePath, or "memory" for local development without a
+// database. Nothing kept in memory survives a restart.
+type StorageConfig struct {
+	Backend    string
+	SQLitePath string
+}
+
+// TaskStoreConfig selects how the postgres backend persists tasks: "postgres"
+// keeps one row per task, "events" appends their changes to an event store
+// and projects them onto that row.
+type TaskStoreConfig struct {
+	Kind             string
+	SnapshotInterval int
+}
+
+// TaskCacheConfig sizes the cache in front of the task repository; a Size of
+// 0 disables it. With Notify, replicas sharing a postgres database invalidate
+// each other's caches through LISTEN/NOTIFY instead of waiting for TTL.
+type TaskCacheConfig struct {
+	Size   int
+	TTL    time.Duration
+	Notify bool
+}
+
+// RateLimitConfig limits how many requests each client makes: Default
+// applies to every route without a limit of its own in Routes, keyed by
+// method and route pattern. Store is "memory", which keeps at most
+// MemorySize buckets per replica, or "postgres", which shares them between
+// replicas. APIKeys holds the SHA-256 digests, in hex, of the API keys
+// clients may be told apart by.
+type RateLimitConfig struct {
+	Store      string
+	Default    Rate
+	Routes     map[string]Rate
+	MemorySize int
+	APIKeys    []string
+}
+
+// Rate allows Requests per Period; a zero Rate is unlimited.
+type Rate struct {
+	Requests int
+	Period   time.Duration
 }
 
 type Config struct {
-	DB   DBConfig
-	HTTP HTTPConfig
+	Storage   StorageConfig
+	DB        DBConfig
+	HTTP      HTTPConfig
+	Authz     AuthzConfig
+	Reminder  ReminderConfig
+	Trash     TrashConfig
+	TaskStore TaskStoreConfig
+	TaskCache TaskCacheConfig
+	RateLimit RateLimitConfig
 }
 
 func Load() (*Config, error) {
 	cfg := &Config{}
+
+	cfg.Storage.Backend = lookupEnv("STORAGE_BACKEND", "postgres")
+	if cfg.Storage.Backend == "postgres" {
+		if err := loadDB(&cfg.DB); err != nil {
+			return nil, err
+		}
+	}
+	cfg.Storage.SQLitePath = lookupEnv("SQLITE_PATH", "data/tasks.db")
+
+	cfg.HTTP.Port = lookupEnvInt("PORT", 8080)
+	requestTimeout, err := lookupEnvTimeout("REQUEST_TIMEOUT", 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("REQUEST_TIMEOUT: %w", err)
+	}
+	cfg.HTTP.RequestTimeout = requestTimeout
+	routeTimeouts, err := lookupEnvDurations("ROUTE_TIMEOUTS")
+	if err != nil {
+		return nil, fmt.Errorf("ROUTE_TIMEOUTS: %w", err)
+	}
+	cfg.HTTP.RouteTimeouts = routeTimeouts
+
+	cfg.Authz.PolicyFile = lookupEnv("AUTHZ_POLICY_FILE", "config/policy.yaml")
+	cfg.Authz.ReloadInterval = lookupEnvDuration("AUTHZ_RELOAD_INTERVAL", 5*time.Second)
+
+	cfg.Reminder.Interval = lookupEnvDuration("REMINDER_INTERVAL", time.Minute)
+	cfg.Reminder.Window = lookupEnvDuration("REMINDER_WINDOW", 24*time.Hour)
+	cfg.Reminder.Notifier = lookupEnv("REMINDER_NOTIFIER", "log")
+	cfg.Reminder.SMTPAddr = lookupEnv("SMTP_ADDR", "localhost:1025")
+	cfg.Reminder.SMTPFrom = lookupEnv("SMTP_FROM", "reminders@localhost")
+	cfg.Reminder.SMTPTo = lookupEnvList("SMTP_TO")
+	cfg.Reminder.WebhookURL = lookupEnv("REMINDER_WEBHOOK_URL", "")
+
+	cfg.Trash.Retention = lookupEnvDuration("TRASH_RETENTION", 30*24*time.Hour)
+	cfg.Trash.PurgeInterval = lookupEnvDuration("TRASH_PURGE_INTERVAL", time.Hour)
+
+	cfg.TaskStore.Kind = lookupEnv("TASK_STORE", "postgres")
+	cfg.TaskStore.SnapshotInterval = lookupEnvInt("TASK_SNAPSHOT_INTERVAL", 50)
+
+	cfg.TaskCache.Size = lookupEnvInt("TASK_CACHE_SIZE", 0)
+	cfg.TaskCache.TTL = lookupEnvDuration("TASK_CACHE_TTL", 30*time.Second)
+	cfg.TaskCache.Notify = lookupEnvBool("TASK_CACHE_NOTIFY", false)
+
+	cfg.RateLimit.Store = lookupEnv("RATE_LIMIT_STORE", "memory")
+	if cfg.RateLimit.Store != "memory" && cfg.RateLimit.Store != "postgres" {
+		return nil, fmt.Errorf("RATE_LIMIT_STORE must be memory or postgres, got %q", cfg.RateLimit.Store)
+	}
+	if cfg.RateLimit.Default, err = parseRate(lookupEnv("RATE_LIMIT", "")); err != nil {
+		return nil, fmt.Errorf("RATE_LIMIT: %w", err)
+	}
+	if cfg.RateLimit.Routes, err = lookupEnvRates("RATE_LIMITS"); err != nil {
+		return nil, fmt.Errorf("RATE_LIMITS: %w", err)
+	}
+	cfg.RateLimit.MemorySize = lookupEnvInt("RATE_LIMIT_MEMORY_SIZE", 10000)
+	if cfg.RateLimit.APIKeys, err = lookupEnvDigests("RATE_LIMIT_API_KEYS"); err != nil {
+		return nil, fmt.Errorf("RATE_LIMIT_API_KEYS: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func loadDB(db *DBConfig) error {
+	db.ReplicaDSN = lookupEnv("POSTGRES_REPLICA_DSN", "")
+	db.ReplicaCheckInterval = lookupEnvDuration("POSTGRES_REPLICA_CHECK_INTERVAL", 5*time.Second)
+	db.MaxConns = lookupEnvInt("POSTGRES_MAX_CONNS", 0)
+	db.MinConns = lookupEnvInt("POSTGRES_MIN_CONNS", 0)
+	db.MaxConnLifetime = lookupEnvDuration("POSTGRES_MAX_CONN_LIFETIME", 0)
+	db.MaxConnIdleTime = lookupEnvDuration("POSTGRES_MAX_CONN_IDLE_TIME", 0)
+	db.HealthCheckPeriod = lookupEnvDuration("POSTGRES_HEALTH_CHECK_PERIOD", 0)
+	db.StatementTimeout = lookupEnvDuration("POSTGRES_STATEMENT_TIMEOUT", 0)
+	db.ConnectTimeout = lookupEnvDuration("POSTGRES_CONNECT_TIMEOUT", 5*time.Second)
+	db.ApplicationName = lookupEnv("POSTGRES_APPLICATION_NAME", "go-clean-hexapp")
+	db.StartupTimeout = lookupEnvDuration("POSTGRES_STARTUP_TIMEOUT", time.Minute)
+	db.RetryAttempts = lookupEnvInt("POSTGRES_RETRY_ATTEMPTS", 3)
+	db.BreakerThreshold = lookupEnvInt("POSTGRES_BREAKER_THRESHOLD", 5)
+	db.BreakerCooldown = lookupEnvDuration("POSTGRES_BREAKER_COOLDOWN", 10*time.Second)
+	if db.MinConns > db.MaxConns && db.MaxConns > 0 {
+		return fmt.Errorf("POSTGRES_MIN_CONNS (%d) exceeds POSTGRES_MAX_CONNS (%d)", db.MinConns, db.MaxConns)
+	}
+
+	db.URL = lookupEnv("DATABASE_URL", "")
+	if db.URL != "" {
+		return nil
+	}
+
 	var err error
 
-	cfg.DB.Host, err = lookupRequiredEnv("POSTGRES_HOST")
+	db.Host, err = lookupRequiredEnv("POSTGRES_HOST")
 	if err != nil {
-		return nil, fmt.Errorf("POSTGRES_HOST: %w", err)
+		return fmt.Errorf("POSTGRES_HOST: %w", err)
 	}
-	cfg.DB.Port, err = lookupRequiredEnvInt("POSTGRES_PORT")
+	db.Port, err = lookupRequiredEnvInt("POSTGRES_PORT")
 	if err != nil {
-		return nil, fmt.Errorf("POSTGRES_PORT: %w", err)
+		return fmt.Errorf("POSTGRES_PORT: %w", err)
 	}
-	cfg.DB.User, err = lookupRequiredEnv("POSTGRES_USER")
+	db.User, err = lookupRequiredEnv("POSTGRES_USER")
 	if err != nil {
-		return nil, fmt.Errorf("POSTGRES_USER: %w", err)
+		return fmt.Errorf("POSTGRES_USER: %w", err)
 	}
-	cfg.DB.Password, err = lookupRequiredEnv("POSTGRES_PASSWORD")
+	db.Password, err = lookupRequiredEnv("POSTGRES_PASSWORD")
 	if err != nil {
-		return nil, fmt.Errorf("POSTGRES_PASSWORD: %w", err)
+		return fmt.Errorf("POSTGRES_PASSWORD: %w", err)
 	}
-	cfg.DB.Name, err = lookupRequiredEnv("POSTGRES_DB")
+	db.Name, err = lookupRequiredEnv("POSTGRES_DB")
 	if err != nil {
-		return nil, fmt.Errorf("POSTGRES_DB: %w", err)
+		return fmt.Errorf("POSTGRES_DB: %w", err)
 	}
-	cfg.DB.SSLMode, err = lookupRequiredEnv("POSTGRES_SSLMODE")
+	db.SSLMode, err = lookupRequiredEnv("POSTGRES_SSLMODE")
 	if err != nil {
-		return nil, fmt.Errorf("POSTGRES_SSLMODE: %w", err)
+		return fmt.Errorf("POSTGRES_SSLMODE: %w", err)
 	}
-
-	cfg.HTTP.Port = lookupEnvInt("PORT", 8080)
-
-	return cfg, nil
+	return nil
 }
 
 func (c Config) GetDSN() string {
+	if c.DB.URL != "" {
+		return c.DB.URL
+	}
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		c.DB.Host, c.DB.Port, c.DB.User, c.DB.Password, c.DB.Name, c.DB.SSLMode,
@@ -90,6 +284,124 @@ func lookupEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+func lookupEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v, ok := os.LookupEnv(key); ok {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+func lookupEnvBool(key string, fallback bool) bool {
+	if v, ok := os.LookupEnv(key); ok {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// lookupEnvDurations parses comma-separated name=duration pairs, such as
+// "GET /tasks=2s, POST /tasks/:id/comments=5s".
+func lookupEnvDurations(key string) (map[string]time.Duration, error) {
+	durations := map[string]time.Duration{}
+	for _, pair := range lookupEnvList(key) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not name=duration", pair)
+		}
+		d, err := parseTimeout(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", pair, err)
+		}
+		durations[strings.TrimSpace(name)] = d
+	}
+	return durations, nil
+}
+
+// lookupEnvTimeout parses a timeout as lookupEnvDurations does, returning
+// fallback when key is unset or empty.
+func lookupEnvTimeout(key string, fallback time.Duration) (time.Duration, error) {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return fallback, nil
+	}
+	return parseTimeout(value)
+}
+
+// parseTimeout parses a non-negative duration; 0 leaves requests unbounded.
+func parseTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not a valid duration", strings.TrimSpace(value))
+	}
+	return d, nil
+}
+
+// lookupEnvRates parses comma-separated name=rate pairs, such as
+// "POST /tasks=10/1m, GET /tasks=100/1m".
+func lookupEnvRates(key string) (map[string]Rate, error) {
+	rates := map[string]Rate{}
+	for _, pair := range lookupEnvList(key) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not name=rate", pair)
+		}
+		rate, err := parseRate(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", pair, err)
+		}
+		rates[strings.TrimSpace(name)] = rate
+	}
+	return rates, nil
+}
+
+// lookupEnvDigests parses comma-separated SHA-256 digests in hex.
+func lookupEnvDigests(key string) ([]string, error) {
+	digests := []string{}
+	for _, value := range lookupEnvList(key) {
+		digest, err := hex.DecodeString(value)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("%q is not a SHA-256 digest in hex", value)
+		}
+		digests = append(digests, hex.EncodeToString(digest))
+	}
+	return digests, nil
+}
+
+// parseRate parses "requests/period", such as "100/1m". An empty value is
+// the zero Rate.
+func parseRate(value string) (Rate, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Rate{}, nil
+	}
+	requests, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return Rate{}, fmt.Errorf("%q is not requests/period", value)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n < 0 {
+		return Rate{}, fmt.Errorf("%q has an invalid number of requests", value)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return Rate{}, fmt.Errorf("%q has an invalid period", value)
+	}
+	return Rate{Requests: n, Period: d}, nil
+}
+
+func lookupEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 func lookupRequiredEnvInt(key string) (int, error) {
 	value, err := lookupRequiredEnv(key)
 	if err != nil {
@@ -1,30 +1,309 @@
 package container
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log"
+	"time"
+
+	"github.com/gin-gonic/gin"
 
 	"github.com/ko44d/go-clean-hexapp/config"
+	auditdomain "github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	commentdomain "github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	labeldomain "github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	projectdomain "github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
 	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/db"
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/notifier"
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/policy"
 	"github.com/ko44d/go-clean-hexapp/internal/interface/handler"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
 	"github.com/ko44d/go-clean-hexapp/internal/interface/repository"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/label"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/project"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
 	"github.com/ko44d/go-clean-hexapp/internal/usecase/task"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/trash"
 )
 
+// reminderLockKey identifies the advisory lock that elects the replica
+// running the reminder scheduler.
+const reminderLockKey int64 = 0x7265_6d69_6e64
+
+// taskChangeChannel is notified by migrations/016_task_change_notifications.sql
+// with the workspace of every task change.
+const taskChangeChannel = "task_changes"
+
 type Container struct {
-	Handler *handler.TaskHandler
+	Handler        *handler.TaskHandler
+	ProjectHandler *handler.ProjectHandler
+	LabelHandler   *handler.LabelHandler
+	CommentHandler *handler.CommentHandler
+	AuditHandler   *handler.AuditHandler
+	// RateLimit is the middleware limiting requests per client.
+	RateLimit gin.HandlerFunc
+
+	dbPools *db.Pools
+	sqlDB   *sql.DB
+	cache   *repository.CachedRepository
+	stop    context.CancelFunc
+}
+
+// storage holds the adapters of one storage backend.
+type storage struct {
+	tasks        domain.Repository
+	projects     projectdomain.Repository
+	dependencies domain.DependencyRepository
+	labels       labeldomain.Repository
+	comments     commentdomain.Repository
+	audit        auditdomain.Repository
+	reminders    reminder.Store
+	trash        trash.Store
+	tx           transaction.Transactor
+	lock         reminder.Locker
+	// dbPools is nil unless the backend is postgres, sqlDB unless it is
+	// sqlite.
+	dbPools *db.Pools
+	sqlDB   *sql.DB
 }
 
 func New(cfg *config.Config) (*Container, error) {
-	dbPool, err := db.New(cfg.GetDSN())
+	store, err := newStorage(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect database: %w", err)
+		return nil, err
+	}
+	closeStore := func() {
+		if store.dbPools != nil {
+			store.dbPools.Close()
+		}
+		if store.sqlDB != nil {
+			_ = store.sqlDB.Close()
+		}
+	}
+
+	authorizer, err := policy.NewFileAuthorizer(cfg.Authz.PolicyFile)
+	if err != nil {
+		closeStore()
+		return nil, fmt.Errorf("failed to load authorization policy: %w", err)
 	}
 
-	repo := repository.New(dbPool)
-	usecase := task.New(repo)
+	reminderNotifier, err := newNotifier(cfg.Reminder)
+	if err != nil {
+		closeStore()
+		return nil, fmt.Errorf("failed to configure reminders: %w", err)
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	if store.dbPools != nil {
+		go store.dbPools.WatchReplica(ctx, cfg.DB.ReplicaCheckInterval)
+	}
+	var cache *repository.CachedRepository
+	if cfg.TaskCache.Size > 0 {
+		cache = repository.NewCachedRepository(store.tasks, cfg.TaskCache.Size, cfg.TaskCache.TTL)
+		store.tasks = cache
+		store.tx = cache.Transactor(store.tx)
+		store.labels = cache.Labels(store.labels)
+		store.comments = cache.Comments(store.comments)
+		store.projects = cache.Projects(store.projects)
+		store.trash = cache.Trash(store.trash)
+		if cfg.TaskCache.Notify && store.dbPools != nil {
+			go db.Listen(ctx, store.dbPools.Primary, taskChangeChannel, cache.InvalidateWorkspace, cache.InvalidateAll)
+		}
+	}
+
+	limits := rateLimits(cfg.RateLimit)
+	var rateLimitStore ratelimit.Store
+	switch cfg.RateLimit.Store {
+	case "postgres":
+		if store.dbPools == nil {
+			stop()
+			closeStore()
+			return nil, fmt.Errorf("rate limit store postgres needs the postgres storage backend")
+		}
+		postgresStore := repository.NewPostgresRateLimitStore(store.dbPools.Primary)
+		go postgresStore.Run(ctx, time.Minute, longestPeriod(limits))
+		rateLimitStore = postgresStore
+	default:
+		rateLimitStore = repository.NewMemoryRateLimitStore(cfg.RateLimit.MemorySize, longestPeriod(limits))
+	}
+
+	scheduler := reminder.NewScheduler(store.tasks, store.reminders, reminderNotifier, store.lock, cfg.Reminder.Window)
+
+	go authorizer.Watch(ctx, cfg.Authz.ReloadInterval)
+	go scheduler.Run(ctx, cfg.Reminder.Interval)
+	go trash.NewPurger(store.trash, cfg.Trash.Retention).Run(ctx, cfg.Trash.PurgeInterval)
+
+	usecase := task.New(store.tasks, store.projects, store.dependencies, store.audit, store.tx, authorizer)
 	h := handler.New(usecase)
+	projectHandler := handler.NewProjectHandler(project.New(store.projects, authorizer))
+	labelHandler := handler.NewLabelHandler(label.New(store.labels, store.tasks, authorizer))
+	commentHandler := handler.NewCommentHandler(comment.New(store.comments, store.tasks, authorizer))
+	auditHandler := handler.NewAuditHandler(audit.New(store.audit, authorizer))
 
 	return &Container{
-		Handler: h,
+		Handler:        h,
+		ProjectHandler: projectHandler,
+		LabelHandler:   labelHandler,
+		CommentHandler: commentHandler,
+		AuditHandler:   auditHandler,
+		RateLimit:      middleware.RateLimit(rateLimitStore, limits),
+		dbPools:        store.dbPools,
+		sqlDB:          store.sqlDB,
+		cache:          cache,
+		stop:           stop,
 	}, nil
 }
+
+func newStorage(cfg *config.Config) (*storage, error) {
+	switch cfg.Storage.Backend {
+	case "postgres":
+		dbPools, err := db.New(cfg.GetDSN(), cfg.DB.ReplicaDSN, dbOptions(cfg.DB))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect database: %w", err)
+		}
+		// Every adapter shares one retry policy and circuit breaker.
+		policy := repository.NewResilience(cfg.DB.RetryAttempts, cfg.DB.BreakerThreshold, cfg.DB.BreakerCooldown)
+		primary := repository.NewResilientDB(dbPools.Primary, policy)
+		tasks, err := newTaskRepository(cfg.TaskStore, primary, dbPools)
+		if err != nil {
+			dbPools.Close()
+			return nil, fmt.Errorf("failed to configure task store: %w", err)
+		}
+		return &storage{
+			tasks:        tasks,
+			projects:     repository.NewProjectRepository(primary),
+			dependencies: repository.NewDependencyRepository(primary),
+			labels:       repository.NewLabelRepository(primary),
+			comments:     repository.NewCommentRepository(primary),
+			audit:        repository.NewAuditRepository(primary),
+			reminders:    repository.NewReminderRepository(primary),
+			trash:        repository.NewTrashRepository(primary),
+			tx:           repository.NewTransactor(primary),
+			lock:         db.NewAdvisoryLock(dbPools.Primary, reminderLockKey),
+			dbPools:      dbPools,
+		}, nil
+	case "sqlite":
+		sqlDB, err := db.NewSQLite(cfg.Storage.SQLitePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+		}
+		return &storage{
+			tasks:        repository.NewSQLiteRepository(sqlDB),
+			projects:     repository.NewSQLiteProjectRepository(sqlDB),
+			dependencies: repository.NewSQLiteDependencyRepository(sqlDB),
+			labels:       repository.NewSQLiteLabelRepository(sqlDB),
+			comments:     repository.NewSQLiteCommentRepository(sqlDB),
+			audit:        repository.NewSQLiteAuditRepository(sqlDB),
+			reminders:    repository.NewSQLiteReminderRepository(sqlDB),
+			trash:        repository.NewSQLiteTrashRepository(sqlDB),
+			tx:           repository.NewSQLiteTransactor(sqlDB),
+			// One process owns the database file, so an in-process lock
+			// is enough to keep a single scheduler running.
+			lock:  repository.NewMemoryLock(),
+			sqlDB: sqlDB,
+		}, nil
+	case "memory":
+		memory := repository.NewMemoryStore()
+		return &storage{
+			tasks:        repository.NewMemoryRepository(memory),
+			projects:     repository.NewMemoryProjectRepository(memory),
+			dependencies: repository.NewMemoryDependencyRepository(memory),
+			labels:       repository.NewMemoryLabelRepository(memory),
+			comments:     repository.NewMemoryCommentRepository(memory),
+			audit:        repository.NewMemoryAuditRepository(memory),
+			reminders:    repository.NewMemoryReminderRepository(memory),
+			trash:        repository.NewMemoryTrashRepository(memory),
+			tx:           repository.NewMemoryTransactor(),
+			lock:         repository.NewMemoryLock(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+// Close stops background workers and releases the database pool or file.
+func (c *Container) Close() {
+	c.stop()
+	if c.cache != nil {
+		stats := c.cache.Stats()
+		log.Printf("task cache: %d hits, %d misses, %d evictions", stats.Hits, stats.Misses, stats.Evictions)
+	}
+	if c.dbPools != nil {
+		c.dbPools.Close()
+	}
+	if c.sqlDB != nil {
+		_ = c.sqlDB.Close()
+	}
+}
+
+func dbOptions(cfg config.DBConfig) db.Options {
+	return db.Options{
+		MaxConns:          int32(cfg.MaxConns),
+		MinConns:          int32(cfg.MinConns),
+		MaxConnLifetime:   cfg.MaxConnLifetime,
+		MaxConnIdleTime:   cfg.MaxConnIdleTime,
+		HealthCheckPeriod: cfg.HealthCheckPeriod,
+		StatementTimeout:  cfg.StatementTimeout,
+		ConnectTimeout:    cfg.ConnectTimeout,
+		ApplicationName:   cfg.ApplicationName,
+		StartupTimeout:    cfg.StartupTimeout,
+	}
+}
+
+func rateLimits(cfg config.RateLimitConfig) middleware.RateLimits {
+	limits := middleware.RateLimits{
+		Default: ratelimit.Limit{Burst: cfg.Default.Requests, Period: cfg.Default.Period},
+		Routes:  map[string]ratelimit.Limit{},
+		APIKeys: map[string]bool{},
+	}
+	for _, digest := range cfg.APIKeys {
+		limits.APIKeys[digest] = true
+	}
+	for route, rate := range cfg.Routes {
+		limits.Routes[route] = ratelimit.Limit{Burst: rate.Requests, Period: rate.Period}
+	}
+	return limits
+}
+
+// longestPeriod is how long buckets are kept once idle: by then they have
+// refilled whatever their limit.
+func longestPeriod(limits middleware.RateLimits) time.Duration {
+	longest := limits.Default.Period
+	for _, limit := range limits.Routes {
+		longest = max(longest, limit.Period)
+	}
+	return max(longest, time.Minute)
+}
+
+func newTaskRepository(cfg config.TaskStoreConfig, primary repository.DB, dbPools *db.Pools) (domain.Repository, error) {
+	switch cfg.Kind {
+	case "postgres":
+		if dbPools.Replica != nil {
+			return repository.NewWithReplica(primary, dbPools.Replica, dbPools.ReplicaHealthy), nil
+		}
+		return repository.New(primary), nil
+	case "events":
+		return repository.NewEventRepository(primary, cfg.SnapshotInterval), nil
+	default:
+		return nil, fmt.Errorf("unknown task store %q", cfg.Kind)
+	}
+}
+
+func newNotifier(cfg config.ReminderConfig) (reminder.Notifier, error) {
+	switch cfg.Notifier {
+	case "log":
+		return notifier.NewLogNotifier(), nil
+	case "smtp":
+		return notifier.NewSMTPNotifier(cfg.SMTPAddr, cfg.SMTPFrom, cfg.SMTPTo)
+	case "webhook":
+		return notifier.NewWebhookNotifier(cfg.WebhookURL, nil)
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Notifier)
+	}
+}
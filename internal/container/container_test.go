@@ -0,0 +1,95 @@
+package container
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/config"
+	commentdomain "github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	labeldomain "github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task/tasktest"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+)
+
+func TestContainer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Container Suite")
+}
+
+// postgresDSNEnv names the variable holding the DSN of a migrated Postgres
+// database; the postgres specs are skipped when it is unset.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+var _ = Describe("newStorage", func() {
+	// openStorage builds the storage newStorage configures for backend and
+	// taskStore, closing it when the spec ends.
+	openStorage := func(backend string, taskStore string) *storage {
+		cfg := &config.Config{
+			Storage:   config.StorageConfig{Backend: backend, SQLitePath: filepath.Join(GinkgoT().TempDir(), "tasks.db")},
+			TaskStore: config.TaskStoreConfig{Kind: taskStore, SnapshotInterval: 3},
+		}
+		if backend == "postgres" {
+			cfg.DB.URL = os.Getenv(postgresDSNEnv)
+			if cfg.DB.URL == "" {
+				Skip(postgresDSNEnv + " is not set")
+			}
+		}
+		store, err := newStorage(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			if store.dbPools != nil {
+				store.dbPools.Close()
+			}
+			if store.sqlDB != nil {
+				_ = store.sqlDB.Close()
+			}
+		})
+		return store
+	}
+
+	for _, backend := range []struct{ name, storage, taskStore string }{
+		{"memory", "memory", ""},
+		{"sqlite", "sqlite", ""},
+		{"postgres", "postgres", "postgres"},
+		{"postgres with TASK_STORE=events", "postgres", "events"},
+	} {
+		Describe(backend.name, func() {
+			tasktest.DescribeRepository(func() domain.Repository {
+				return openStorage(backend.storage, backend.taskStore).tasks
+			})
+
+			It("attaches labels and comments to the tasks it stores", func() {
+				store := openStorage(backend.storage, backend.taskStore)
+				ctx := workspace.WithID(context.Background(), uuid.NewString())
+				now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+				task, err := domain.New(uuid.NewString(), "Write report", now, now)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.tasks.Create(ctx, task)).To(Succeed())
+				bug, err := labeldomain.New(uuid.NewString(), "bug", "", now, now)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.labels.Create(ctx, bug)).To(Succeed())
+				Expect(store.labels.Attach(ctx, task.ID, bug.ID)).To(Succeed())
+				note, err := commentdomain.New(uuid.NewString(), task.ID, "user-1", "Looks good", now)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(store.comments.Create(ctx, note)).To(Succeed())
+
+				found, err := store.tasks.FindByID(ctx, task.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found.Labels).To(Equal([]string{"bug"}))
+				Expect(found.CommentCount).To(Equal(1))
+				labelled, err := store.tasks.FindAll(ctx, domain.ListFilter{Labels: []string{"bug"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(labelled).To(HaveLen(1))
+			})
+		})
+	}
+})
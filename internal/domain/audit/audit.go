@@ -0,0 +1,124 @@
+package audit
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+type Action string
+
+const (
+	ActionCreate   Action = "create"
+	ActionUpdate   Action = "update"
+	ActionComplete Action = "complete"
+	ActionDelete   Action = "delete"
+	ActionRestore  Action = "restore"
+)
+
+// Change holds the value of a field before and after an action. A nil value
+// means the field was unset.
+type Change struct {
+	Before any
+	After  any
+}
+
+// Record is an append-only entry of the audit log describing one action on a
+// task.
+type Record struct {
+	ID          string
+	WorkspaceID string
+	TaskID      string
+	// Actor is the ID of the caller, empty for anonymous callers.
+	Actor     string
+	Action    Action
+	Changes   map[string]Change
+	RequestID string
+	CreatedAt time.Time
+}
+
+// NewTaskRecord records action on a task, keeping only the fields that differ
+// between before and after. before is nil for created tasks and after is nil
+// for deleted ones.
+func NewTaskRecord(id string, action Action, actor string, requestID string, before, after *task.Task, now time.Time) *Record {
+	record := &Record{
+		ID:        id,
+		Actor:     actor,
+		Action:    action,
+		Changes:   Diff(Snapshot(before), Snapshot(after)),
+		RequestID: requestID,
+		CreatedAt: now,
+	}
+	for _, t := range []*task.Task{after, before} {
+		if t != nil {
+			record.WorkspaceID, record.TaskID = t.WorkspaceID, t.ID
+			break
+		}
+	}
+	return record
+}
+
+// NewDependencyRecord records that d was added to the dependencies of its
+// task, or removed from them, as an update of the task's blocked_by field.
+func NewDependencyRecord(id string, workspaceID string, actor string, requestID string, d task.Dependency, added bool, now time.Time) *Record {
+	change := Change{Before: d.BlockerID}
+	if added {
+		change = Change{After: d.BlockerID}
+	}
+	return &Record{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		TaskID:      d.TaskID,
+		Actor:       actor,
+		Action:      ActionUpdate,
+		Changes:     map[string]Change{"blocked_by": change},
+		RequestID:   requestID,
+		CreatedAt:   now,
+	}
+}
+
+// Snapshot captures the audited fields of t. Unset fields are left out, and a
+// nil task has no fields at all.
+func Snapshot(t *task.Task) map[string]any {
+	fields := map[string]any{}
+	if t == nil {
+		return fields
+	}
+	fields["title"] = t.Title
+	fields["status"] = string(t.Status)
+	fields["priority"] = string(t.Priority)
+	if t.ProjectID != nil {
+		fields["project_id"] = *t.ProjectID
+	}
+	if t.ParentID != nil {
+		fields["parent_id"] = *t.ParentID
+	}
+	if t.DueAt != nil {
+		fields["due_at"] = t.DueAt.UTC().Format(time.RFC3339)
+	}
+	if t.Recurrence != nil {
+		fields["recurrence"] = t.Recurrence.String()
+	}
+	if len(t.Assignees) > 0 {
+		fields["assignees"] = slices.Clone(t.Assignees)
+	}
+	return fields
+}
+
+// Diff returns the fields whose value differs between before and after.
+func Diff(before, after map[string]any) map[string]Change {
+	changes := map[string]Change{}
+	for field, value := range before {
+		if !reflect.DeepEqual(value, after[field]) {
+			changes[field] = Change{Before: value, After: after[field]}
+		}
+	}
+	for field, value := range after {
+		if _, ok := before[field]; !ok {
+			changes[field] = Change{After: value}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,94 @@
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+func TestAudit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Domain Suite")
+}
+
+var _ = Describe("Audit Domain", func() {
+	var (
+		now    time.Time
+		before *task.Task
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+		before = &task.Task{
+			ID:          "task-1",
+			WorkspaceID: "workspace-1",
+			Title:       "Write report",
+			Status:      task.StatusTodo,
+			Priority:    task.PriorityMedium,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	})
+
+	Describe("NewTaskRecord", func() {
+		It("should keep only the changed fields", func() {
+			after := before.Clone()
+			dueAt := now.Add(24 * time.Hour)
+			Expect(after.SetDueAt(&dueAt, now)).To(Succeed())
+			Expect(after.Rename("Write the report", now)).To(Succeed())
+
+			record := audit.NewTaskRecord("record-1", audit.ActionUpdate, "user-1", "request-1", before, after, now)
+
+			Expect(record.TaskID).To(Equal("task-1"))
+			Expect(record.WorkspaceID).To(Equal("workspace-1"))
+			Expect(record.Changes).To(Equal(map[string]audit.Change{
+				"title":  {Before: "Write report", After: "Write the report"},
+				"due_at": {After: "2025-10-02T12:00:00Z"},
+			}))
+		})
+
+		It("should list every field of a created task", func() {
+			record := audit.NewTaskRecord("record-1", audit.ActionCreate, "user-1", "", nil, before, now)
+
+			Expect(record.TaskID).To(Equal("task-1"))
+			Expect(record.Changes).To(HaveLen(3))
+			Expect(record.Changes["status"]).To(Equal(audit.Change{After: "todo"}))
+		})
+
+		It("should keep the task of a deleted task", func() {
+			record := audit.NewTaskRecord("record-1", audit.ActionDelete, "user-1", "", before, nil, now)
+
+			Expect(record.TaskID).To(Equal("task-1"))
+			Expect(record.Changes["title"]).To(Equal(audit.Change{Before: "Write report"}))
+		})
+
+		It("should compare assignees by value", func() {
+			before.Assignees = []string{"user-1"}
+			after := before.Clone()
+
+			record := audit.NewTaskRecord("record-1", audit.ActionUpdate, "user-1", "", before, after, now)
+
+			Expect(record.Changes).To(BeEmpty())
+		})
+	})
+
+	Describe("NewFilter", func() {
+		It("should default the limit", func() {
+			filter, err := audit.NewFilter("user-1", now, 0)
+
+			Expect(err).To(BeNil())
+			Expect(filter.Limit).To(Equal(audit.DefaultLimit))
+		})
+
+		It("should reject limits above the maximum", func() {
+			_, err := audit.NewFilter("", time.Time{}, audit.MaxLimit+1)
+
+			Expect(err).To(Equal(audit.ErrInvalidLimit))
+		})
+	})
+})
@@ -0,0 +1,16 @@
+package audit
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID tags ctx with the ID of the request being served so that the
+// records it causes can be traced back to it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
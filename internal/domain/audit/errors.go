@@ -0,0 +1,5 @@
+package audit
+
+import "errors"
+
+var ErrInvalidLimit = errors.New("invalid limit")
@@ -0,0 +1,27 @@
+package audit
+
+import "time"
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 500
+)
+
+// Filter selects up to Limit records, oldest first. Empty fields match every
+// record.
+type Filter struct {
+	Actor string
+	Since time.Time
+	Limit int
+}
+
+// NewFilter validates a requested filter. A zero limit selects DefaultLimit.
+func NewFilter(actor string, since time.Time, limit int) (Filter, error) {
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 0 || limit > MaxLimit {
+		return Filter{}, ErrInvalidLimit
+	}
+	return Filter{Actor: actor, Since: since, Limit: limit}, nil
+}
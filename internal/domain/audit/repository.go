@@ -0,0 +1,15 @@
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+package audit
+
+import "context"
+
+// Repository stores the audit log. Records can be appended but never changed
+// or removed.
+type Repository interface {
+	Append(ctx context.Context, record *Record) error
+	// FindByTask returns the records of a task, oldest first. Records outlive
+	// the task they describe.
+	FindByTask(ctx context.Context, taskID string) ([]*Record, error)
+	Find(ctx context.Context, filter Filter) ([]*Record, error)
+}
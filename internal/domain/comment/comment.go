@@ -0,0 +1,66 @@
+package comment
+
+import (
+	"strings"
+	"time"
+)
+
+// Comment is a note on a task. Body is Markdown and is stored as written;
+// rendering is left to clients.
+type Comment struct {
+	ID          string
+	WorkspaceID string
+	TaskID      string
+	AuthorID    string
+	Body        string
+	CreatedAt   time.Time
+	// EditedAt is nil until the author first edits the comment.
+	EditedAt *time.Time
+}
+
+func New(id string, taskID string, authorID string, body string, createdAt time.Time) (*Comment, error) {
+	if err := validateBody(body); err != nil {
+		return nil, err
+	}
+	return &Comment{
+		ID:        id,
+		TaskID:    taskID,
+		AuthorID:  authorID,
+		Body:      body,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// Edit replaces the body on behalf of authorID, who must be the author.
+func (c *Comment) Edit(authorID string, body string, now time.Time) error {
+	if err := c.CheckAuthor(authorID); err != nil {
+		return err
+	}
+	if err := validateBody(body); err != nil {
+		return err
+	}
+	c.Body = body
+	c.EditedAt = &now
+	return nil
+}
+
+// CheckAuthor returns ErrNotAuthor unless authorID wrote the comment.
+func (c *Comment) CheckAuthor(authorID string) error {
+	if authorID == "" || authorID != c.AuthorID {
+		return ErrNotAuthor
+	}
+	return nil
+}
+
+func validateBody(body string) error {
+	if body == "" {
+		return ErrInvalidBody
+	}
+	if strings.TrimSpace(body) == "" {
+		return ErrBodyBlank
+	}
+	if len(body) > 10000 {
+		return ErrBodyTooLong
+	}
+	return nil
+}
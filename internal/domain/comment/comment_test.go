@@ -0,0 +1,91 @@
+package comment_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+)
+
+func TestComment(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Comment Domain Suite")
+}
+
+var _ = Describe("Comment Domain", func() {
+	var createdAt time.Time
+
+	BeforeEach(func() {
+		createdAt = time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	})
+
+	Describe("New", func() {
+		It("should keep the Markdown body as written", func() {
+			c, err := comment.New("comment-1", "task-1", "user-1", "**Done** in `main`", createdAt)
+
+			Expect(err).To(BeNil())
+			Expect(c.Body).To(Equal("**Done** in `main`"))
+			Expect(c.EditedAt).To(BeNil())
+		})
+
+		It("should reject empty, blank and oversized bodies", func() {
+			_, err := comment.New("comment-1", "task-1", "user-1", "", createdAt)
+			Expect(err).To(MatchError(comment.ErrInvalidBody))
+
+			_, err = comment.New("comment-1", "task-1", "user-1", " \n", createdAt)
+			Expect(err).To(MatchError(comment.ErrBodyBlank))
+
+			_, err = comment.New("comment-1", "task-1", "user-1", strings.Repeat("a", 10001), createdAt)
+			Expect(err).To(MatchError(comment.ErrBodyTooLong))
+		})
+	})
+
+	Describe("Edit", func() {
+		It("should record the edit time", func() {
+			c, _ := comment.New("comment-1", "task-1", "user-1", "first", createdAt)
+			editedAt := createdAt.Add(time.Minute)
+
+			Expect(c.Edit("user-1", "second", editedAt)).To(Succeed())
+			Expect(c.Body).To(Equal("second"))
+			Expect(c.EditedAt).To(HaveValue(Equal(editedAt)))
+		})
+
+		It("should only let the author edit", func() {
+			c, _ := comment.New("comment-1", "task-1", "user-1", "first", createdAt)
+
+			Expect(c.Edit("user-2", "second", createdAt)).To(MatchError(comment.ErrNotAuthor))
+			Expect(c.Body).To(Equal("first"))
+		})
+	})
+
+	Describe("NewPage", func() {
+		It("should default the page size", func() {
+			page, err := comment.NewPage(0, "")
+
+			Expect(err).To(BeNil())
+			Expect(page.Limit).To(Equal(comment.DefaultPageSize))
+			Expect(page.After).To(BeNil())
+		})
+
+		It("should round-trip cursors", func() {
+			cursor := comment.Cursor{CreatedAt: createdAt.Add(123 * time.Microsecond), ID: "comment-1"}
+
+			page, err := comment.NewPage(10, cursor.String())
+
+			Expect(err).To(BeNil())
+			Expect(page.After).To(HaveValue(Equal(cursor)))
+		})
+
+		It("should reject out-of-range sizes and malformed cursors", func() {
+			_, err := comment.NewPage(comment.MaxPageSize+1, "")
+			Expect(err).To(MatchError(comment.ErrInvalidPageSize))
+
+			_, err = comment.NewPage(10, "not a cursor")
+			Expect(err).To(MatchError(comment.ErrInvalidCursor))
+		})
+	})
+})
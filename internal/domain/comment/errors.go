@@ -0,0 +1,14 @@
+package comment
+
+import "errors"
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrInvalidBody     = errors.New("body must not be empty")
+	ErrBodyBlank       = errors.New("body must not be blank")
+	ErrBodyTooLong     = errors.New("body must not exceed 10000 characters")
+	ErrNotAuthor       = errors.New("only the author may change a comment")
+
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrInvalidPageSize = errors.New("invalid page size")
+)
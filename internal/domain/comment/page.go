@@ -0,0 +1,69 @@
+package comment
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Cursor marks the last comment of a page. Comments are ordered by creation
+// time and then ID, so a cursor identifies a position even when comments are
+// added or deleted between requests.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// String encodes the cursor as an opaque, URL-safe token.
+func (c Cursor) String() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{CreatedAt: t, ID: id}, nil
+}
+
+// Page selects up to Limit comments following After, or from the first
+// comment when After is nil.
+type Page struct {
+	After *Cursor
+	Limit int
+}
+
+// NewPage validates a requested page. A zero limit selects DefaultPageSize
+// and an empty cursor the first page.
+func NewPage(limit int, cursor string) (Page, error) {
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	if limit < 0 || limit > MaxPageSize {
+		return Page{}, ErrInvalidPageSize
+	}
+	page := Page{Limit: limit}
+	if cursor != "" {
+		after, err := ParseCursor(cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		page.After = &after
+	}
+	return page, nil
+}
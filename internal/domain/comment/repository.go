@@ -0,0 +1,16 @@
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+package comment
+
+import (
+	"context"
+)
+
+type Repository interface {
+	// FindByTask returns up to page.Limit comments of the task, oldest first.
+	FindByTask(ctx context.Context, taskID string, page Page) ([]*Comment, error)
+	FindByID(ctx context.Context, id string) (*Comment, error)
+	Create(ctx context.Context, comment *Comment) error
+	Update(ctx context.Context, comment *Comment) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,13 @@
+package label
+
+import "errors"
+
+var (
+	ErrLabelNotFound    = errors.New("label not found")
+	ErrLabelExists      = errors.New("label name already in use")
+	ErrLabelNotAttached = errors.New("label not attached to task")
+	ErrInvalidName      = errors.New("name must not be empty")
+	ErrNameBlank        = errors.New("name must not be blank")
+	ErrNameTooLong      = errors.New("name must not exceed 50 characters")
+	ErrInvalidColor     = errors.New("color must be a hex code such as #1f883d")
+)
@@ -0,0 +1,69 @@
+package label
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultColor is used when a label is created without a colour.
+const DefaultColor = "#6e7781"
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// Label tags tasks of one workspace. Names are unique within the workspace.
+type Label struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Color       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func New(id string, name string, color string, createdAt time.Time, updatedAt time.Time) (*Label, error) {
+	color, err := normalize(name, color)
+	if err != nil {
+		return nil, err
+	}
+	return &Label{
+		ID:        id,
+		Name:      name,
+		Color:     color,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+func (l *Label) Edit(name string, color string, now time.Time) error {
+	color, err := normalize(name, color)
+	if err != nil {
+		return err
+	}
+	l.Name = name
+	l.Color = color
+	l.UpdatedAt = now
+	return nil
+}
+
+// normalize validates name and color and returns the colour to store:
+// lower-case, or DefaultColor when empty.
+func normalize(name string, color string) (string, error) {
+	if name == "" {
+		return "", ErrInvalidName
+	}
+	if strings.TrimSpace(name) == "" {
+		return "", ErrNameBlank
+	}
+	if len(name) > 50 {
+		return "", ErrNameTooLong
+	}
+	if color == "" {
+		return DefaultColor, nil
+	}
+	color = strings.ToLower(color)
+	if !colorPattern.MatchString(color) {
+		return "", ErrInvalidColor
+	}
+	return color, nil
+}
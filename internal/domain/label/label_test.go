@@ -0,0 +1,72 @@
+package label_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+)
+
+func TestLabel(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Label Domain Suite")
+}
+
+var _ = Describe("Label Domain", func() {
+	var createdAt time.Time
+
+	BeforeEach(func() {
+		createdAt = time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	})
+
+	Describe("New", func() {
+		It("should normalise the colour to lower case", func() {
+			l, err := label.New("label-1", "bug", "#D73A4A", createdAt, createdAt)
+
+			Expect(err).To(BeNil())
+			Expect(l.Name).To(Equal("bug"))
+			Expect(l.Color).To(Equal("#d73a4a"))
+		})
+
+		It("should fall back to the default colour", func() {
+			l, err := label.New("label-1", "bug", "", createdAt, createdAt)
+
+			Expect(err).To(BeNil())
+			Expect(l.Color).To(Equal(label.DefaultColor))
+		})
+
+		It("should reject invalid names", func() {
+			_, err := label.New("label-1", "", "", createdAt, createdAt)
+			Expect(err).To(MatchError(label.ErrInvalidName))
+
+			_, err = label.New("label-1", " ", "", createdAt, createdAt)
+			Expect(err).To(MatchError(label.ErrNameBlank))
+
+			_, err = label.New("label-1", strings.Repeat("a", 51), "", createdAt, createdAt)
+			Expect(err).To(MatchError(label.ErrNameTooLong))
+		})
+
+		It("should reject colours that are not six-digit hex codes", func() {
+			for _, color := range []string{"red", "#fff", "d73a4a", "#d73a4g"} {
+				_, err := label.New("label-1", "bug", color, createdAt, createdAt)
+				Expect(err).To(MatchError(label.ErrInvalidColor), color)
+			}
+		})
+	})
+
+	Describe("Edit", func() {
+		It("should leave the label untouched when invalid", func() {
+			l, _ := label.New("label-1", "bug", "#d73a4a", createdAt, createdAt)
+
+			err := l.Edit("bug", "blue", createdAt.Add(time.Minute))
+
+			Expect(err).To(MatchError(label.ErrInvalidColor))
+			Expect(l.Color).To(Equal("#d73a4a"))
+			Expect(l.UpdatedAt).To(Equal(createdAt))
+		})
+	})
+})
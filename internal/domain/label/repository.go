@@ -0,0 +1,22 @@
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+package label
+
+import (
+	"context"
+)
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]*Label, error)
+	FindByID(ctx context.Context, id string) (*Label, error)
+	// Create and Update return ErrLabelExists when another label of the
+	// workspace has the same name.
+	Create(ctx context.Context, label *Label) error
+	Update(ctx context.Context, label *Label) error
+	// Delete removes the label and detaches it from every task.
+	Delete(ctx context.Context, id string) error
+	// Attach adds the label to the task; attaching it twice is a no-op.
+	Attach(ctx context.Context, taskID string, labelID string) error
+	// Detach removes the label from the task or returns ErrLabelNotAttached.
+	Detach(ctx context.Context, taskID string, labelID string) error
+}
@@ -0,0 +1,12 @@
+package project
+
+import "errors"
+
+var (
+	ErrProjectNotFound    = errors.New("project not found")
+	ErrInvalidName        = errors.New("name must not be empty")
+	ErrNameBlank          = errors.New("name must not be blank")
+	ErrNameTooLong        = errors.New("name must not exceed 100 characters")
+	ErrDescriptionTooLong = errors.New("description must not exceed 2000 characters")
+	ErrProjectArchived    = errors.New("project is archived")
+)
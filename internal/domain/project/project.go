@@ -0,0 +1,79 @@
+package project
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+type Project struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Description string
+	Archived    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func New(id string, name string, description string, createdAt time.Time, updatedAt time.Time) (*Project, error) {
+	if err := validate(name, description); err != nil {
+		return nil, err
+	}
+
+	return &Project{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}, nil
+}
+
+func (p *Project) Edit(name string, description string, now time.Time) error {
+	if err := validate(name, description); err != nil {
+		return err
+	}
+	p.Name = name
+	p.Description = description
+	p.UpdatedAt = now
+	return nil
+}
+
+func (p *Project) Archive(now time.Time) {
+	p.Archived = true
+	p.UpdatedAt = now
+}
+
+func (p *Project) Unarchive(now time.Time) {
+	p.Archived = false
+	p.UpdatedAt = now
+}
+
+// AddTask files t under the project. Archived projects are read-only and do
+// not accept new tasks.
+func (p *Project) AddTask(t *task.Task) error {
+	if p.Archived {
+		return ErrProjectArchived
+	}
+	id := p.ID
+	t.ProjectID = &id
+	return nil
+}
+
+func validate(name string, description string) error {
+	if name == "" {
+		return ErrInvalidName
+	}
+	if strings.TrimSpace(name) == "" {
+		return ErrNameBlank
+	}
+	if len(name) > 100 {
+		return ErrNameTooLong
+	}
+	if len(description) > 2000 {
+		return ErrDescriptionTooLong
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package project_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+func TestProject(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Project Domain Suite")
+}
+
+var _ = Describe("Project Domain", func() {
+	var createdAt time.Time
+
+	BeforeEach(func() {
+		createdAt = time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	})
+
+	Describe("New", func() {
+		Context("when name is valid", func() {
+			It("should create an active project", func() {
+				p, err := project.New("project-1", "Ops", "Weekly checklists", createdAt, createdAt)
+
+				Expect(err).To(BeNil())
+				Expect(p.ID).To(Equal("project-1"))
+				Expect(p.Name).To(Equal("Ops"))
+				Expect(p.Description).To(Equal("Weekly checklists"))
+				Expect(p.Archived).To(BeFalse())
+				Expect(p.CreatedAt).To(Equal(createdAt))
+			})
+		})
+
+		Context("when name is invalid", func() {
+			It("should return ErrInvalidName for an empty name", func() {
+				p, err := project.New("project-1", "", "", createdAt, createdAt)
+
+				Expect(err).To(MatchError(project.ErrInvalidName))
+				Expect(p).To(BeNil())
+			})
+
+			It("should return ErrNameBlank for a whitespace name", func() {
+				_, err := project.New("project-1", "  ", "", createdAt, createdAt)
+
+				Expect(err).To(MatchError(project.ErrNameBlank))
+			})
+
+			It("should return ErrNameTooLong for a 101 character name", func() {
+				_, err := project.New("project-1", strings.Repeat("a", 101), "", createdAt, createdAt)
+
+				Expect(err).To(MatchError(project.ErrNameTooLong))
+			})
+		})
+
+		Context("when description is too long", func() {
+			It("should return ErrDescriptionTooLong", func() {
+				_, err := project.New("project-1", "Ops", strings.Repeat("a", 2001), createdAt, createdAt)
+
+				Expect(err).To(MatchError(project.ErrDescriptionTooLong))
+			})
+		})
+	})
+
+	Describe("Edit", func() {
+		It("should update name, description and timestamp", func() {
+			p, _ := project.New("project-1", "Ops", "", createdAt, createdAt)
+			editedAt := createdAt.Add(time.Minute)
+
+			err := p.Edit("Operations", "Runbooks", editedAt)
+
+			Expect(err).To(BeNil())
+			Expect(p.Name).To(Equal("Operations"))
+			Expect(p.Description).To(Equal("Runbooks"))
+			Expect(p.UpdatedAt).To(Equal(editedAt))
+		})
+
+		It("should leave the project untouched when invalid", func() {
+			p, _ := project.New("project-1", "Ops", "", createdAt, createdAt)
+
+			err := p.Edit("", "Runbooks", createdAt.Add(time.Minute))
+
+			Expect(err).To(MatchError(project.ErrInvalidName))
+			Expect(p.Name).To(Equal("Ops"))
+			Expect(p.UpdatedAt).To(Equal(createdAt))
+		})
+	})
+
+	Describe("Archive", func() {
+		It("should toggle the archived flag", func() {
+			p, _ := project.New("project-1", "Ops", "", createdAt, createdAt)
+
+			p.Archive(createdAt.Add(time.Minute))
+			Expect(p.Archived).To(BeTrue())
+
+			p.Unarchive(createdAt.Add(2 * time.Minute))
+			Expect(p.Archived).To(BeFalse())
+			Expect(p.UpdatedAt).To(Equal(createdAt.Add(2 * time.Minute)))
+		})
+	})
+
+	Describe("AddTask", func() {
+		var t *task.Task
+
+		BeforeEach(func() {
+			t, _ = task.New("task-1", "Test Task", createdAt, createdAt)
+		})
+
+		Context("when the project is active", func() {
+			It("should file the task under the project", func() {
+				p, _ := project.New("project-1", "Ops", "", createdAt, createdAt)
+
+				err := p.AddTask(t)
+
+				Expect(err).To(BeNil())
+				Expect(t.ProjectID).NotTo(BeNil())
+				Expect(*t.ProjectID).To(Equal("project-1"))
+			})
+		})
+
+		Context("when the project is archived", func() {
+			It("should return ErrProjectArchived", func() {
+				p, _ := project.New("project-1", "Ops", "", createdAt, createdAt)
+				p.Archive(createdAt)
+
+				err := p.AddTask(t)
+
+				Expect(err).To(MatchError(project.ErrProjectArchived))
+				Expect(t.ProjectID).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,15 @@
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
+package project
+
+import (
+	"context"
+)
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]*Project, error)
+	FindByID(ctx context.Context, id string) (*Project, error)
+	Create(ctx context.Context, project *Project) error
+	Update(ctx context.Context, project *Project) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,43 @@
+package task
+
+import (
+	"slices"
+	"strings"
+)
+
+// MaxAssignees is how many users may be assigned to one task.
+const MaxAssignees = 10
+
+// Assign adds userID to the task's assignees. Assigning someone twice is a
+// no-op. Finished tasks keep the assignees they were finished with until they
+// are reopened. Like Repository.AddAssignee, it leaves UpdatedAt alone.
+func (t *Task) Assign(userID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return ErrInvalidAssignee
+	}
+	if !t.IsOpen() {
+		return ErrTaskFinished
+	}
+	if slices.Contains(t.Assignees, userID) {
+		return nil
+	}
+	if len(t.Assignees) >= MaxAssignees {
+		return ErrTooManyAssignees
+	}
+	t.Assignees = append(t.Assignees, userID)
+	slices.Sort(t.Assignees)
+	return nil
+}
+
+// Unassign removes userID from the task's assignees.
+func (t *Task) Unassign(userID string) error {
+	if !t.IsOpen() {
+		return ErrTaskFinished
+	}
+	i := slices.Index(t.Assignees, userID)
+	if i < 0 {
+		return ErrAssigneeNotFound
+	}
+	t.Assignees = slices.Delete(t.Assignees, i, i+1)
+	return nil
+}
@@ -0,0 +1,87 @@
+package task_test
+
+import (
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+var _ = Describe("Assignees", func() {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var t *task.Task
+
+	BeforeEach(func() {
+		t = &task.Task{ID: "task-1", Status: task.StatusTodo}
+	})
+
+	Describe("Assign", func() {
+		It("should keep assignees sorted and ignore repeats", func() {
+			Expect(t.Assign("bob")).To(Succeed())
+			Expect(t.Assign("alice")).To(Succeed())
+			Expect(t.Assign("bob")).To(Succeed())
+
+			Expect(t.Assignees).To(Equal([]string{"alice", "bob"}))
+			Expect(t.UpdatedAt).To(BeZero())
+		})
+
+		It("should reject an empty user", func() {
+			Expect(t.Assign(" ")).To(MatchError(task.ErrInvalidAssignee))
+		})
+
+		It("should cap the number of assignees", func() {
+			for i := range task.MaxAssignees {
+				Expect(t.Assign("user-" + strconv.Itoa(i))).To(Succeed())
+			}
+
+			Expect(t.Assign("one-too-many")).To(MatchError(task.ErrTooManyAssignees))
+			Expect(t.Assignees).To(HaveLen(task.MaxAssignees))
+		})
+
+		It("should refuse to reassign a finished task until it is reopened", func() {
+			Expect(t.Complete(now)).To(Succeed())
+			Expect(t.Assign("alice")).To(MatchError(task.ErrTaskFinished))
+
+			Expect(t.TransitionTo(task.DefaultWorkflow, task.StatusTodo, now)).To(Succeed())
+			Expect(t.Assign("alice")).To(Succeed())
+		})
+	})
+
+	Describe("Unassign", func() {
+		It("should remove the user", func() {
+			t.Assignees = []string{"alice", "bob"}
+
+			Expect(t.Unassign("alice")).To(Succeed())
+			Expect(t.Assignees).To(Equal([]string{"bob"}))
+		})
+
+		It("should report users that are not assigned", func() {
+			Expect(t.Unassign("alice")).To(MatchError(task.ErrAssigneeNotFound))
+		})
+
+		It("should refuse on a finished task", func() {
+			t.Assignees = []string{"alice"}
+			t.Status = task.StatusCancelled
+
+			Expect(t.Unassign("alice")).To(MatchError(task.ErrTaskFinished))
+		})
+	})
+
+	Describe("NextOccurrence", func() {
+		It("should carry the assignees over", func() {
+			due := now.Add(time.Hour)
+			recurrence, _ := task.ParseRecurrence("FREQ=DAILY")
+			t.DueAt = &due
+			t.Recurrence = recurrence
+			t.Assignees = []string{"alice"}
+
+			next := t.NextOccurrence("task-2", now)
+
+			Expect(next.Assignees).To(Equal([]string{"alice"}))
+		})
+	})
+})
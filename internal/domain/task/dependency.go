@@ -0,0 +1,95 @@
+package task
+
+// Dependency states that TaskID cannot be completed before BlockerID.
+type Dependency struct {
+	TaskID    string
+	BlockerID string
+}
+
+// DependencyGraph is a set of blocked-by edges, typically the part of the
+// workspace graph reachable from one task.
+type DependencyGraph struct {
+	edges    []Dependency
+	blockers map[string][]string
+}
+
+func NewDependencyGraph(edges []Dependency) *DependencyGraph {
+	g := &DependencyGraph{blockers: map[string][]string{}}
+	for _, d := range edges {
+		g.add(d)
+	}
+	return g
+}
+
+func (g *DependencyGraph) Edges() []Dependency {
+	return g.edges
+}
+
+// TaskIDs lists root followed by every other task of the graph, in the order
+// they first appear in the edges.
+func (g *DependencyGraph) TaskIDs(root string) []string {
+	seen := map[string]bool{root: true}
+	ids := []string{root}
+	for _, d := range g.edges {
+		for _, id := range []string{d.TaskID, d.BlockerID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// Add records d unless it would make a task wait on itself, directly or
+// through other tasks. The graph must include the dependents of d.TaskID, as
+// DependencyRepository.FindGraph returns them, for the check to be complete.
+func (g *DependencyGraph) Add(d Dependency) error {
+	if d.TaskID == d.BlockerID || g.waitsOn(d.BlockerID, d.TaskID) {
+		return ErrDependencyCycle
+	}
+	g.add(d)
+	return nil
+}
+
+func (g *DependencyGraph) add(d Dependency) {
+	g.edges = append(g.edges, d)
+	g.blockers[d.TaskID] = append(g.blockers[d.TaskID], d.BlockerID)
+}
+
+// waitsOn reports whether from is blocked by to, directly or transitively.
+func (g *DependencyGraph) waitsOn(from, to string) bool {
+	visited := map[string]bool{}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, blocker := range g.blockers[id] {
+			if blocker == to {
+				return true
+			}
+			if !visited[blocker] {
+				visited[blocker] = true
+				queue = append(queue, blocker)
+			}
+		}
+	}
+	return false
+}
+
+// CheckBlockers returns ErrOpenBlockers when one of tasks waits on an open
+// blocker that is not among tasks itself. openBlockers are the dependencies
+// of tasks whose blocker is still open, as returned by
+// DependencyRepository.FindOpenBlockers.
+func CheckBlockers(tasks []*Task, openBlockers []Dependency) error {
+	completing := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		completing[t.ID] = true
+	}
+	for _, d := range openBlockers {
+		if completing[d.TaskID] && !completing[d.BlockerID] {
+			return ErrOpenBlockers
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package task_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+var _ = Describe("Dependencies", func() {
+	Describe("DependencyGraph.Add", func() {
+		It("should accept an edge that keeps the graph acyclic", func() {
+			graph := task.NewDependencyGraph([]task.Dependency{{TaskID: "b", BlockerID: "c"}})
+
+			Expect(graph.Add(task.Dependency{TaskID: "a", BlockerID: "b"})).To(Succeed())
+			Expect(graph.Edges()).To(HaveLen(2))
+		})
+
+		It("should reject a task blocking itself", func() {
+			graph := task.NewDependencyGraph(nil)
+
+			Expect(graph.Add(task.Dependency{TaskID: "a", BlockerID: "a"})).To(MatchError(task.ErrDependencyCycle))
+		})
+
+		It("should reject a transitive cycle", func() {
+			graph := task.NewDependencyGraph([]task.Dependency{
+				{TaskID: "b", BlockerID: "a"},
+				{TaskID: "c", BlockerID: "b"},
+			})
+
+			Expect(graph.Add(task.Dependency{TaskID: "a", BlockerID: "c"})).To(MatchError(task.ErrDependencyCycle))
+			Expect(graph.Edges()).To(HaveLen(2))
+		})
+	})
+
+	Describe("DependencyGraph.TaskIDs", func() {
+		It("should list the root first and every task once", func() {
+			graph := task.NewDependencyGraph([]task.Dependency{
+				{TaskID: "b", BlockerID: "a"},
+				{TaskID: "a", BlockerID: "c"},
+			})
+
+			Expect(graph.TaskIDs("a")).To(Equal([]string{"a", "b", "c"}))
+		})
+	})
+
+	Describe("CheckBlockers", func() {
+		tasks := []*task.Task{{ID: "a"}, {ID: "b"}}
+
+		It("should refuse when a blocker stays open", func() {
+			err := task.CheckBlockers(tasks, []task.Dependency{{TaskID: "a", BlockerID: "x"}})
+
+			Expect(err).To(MatchError(task.ErrOpenBlockers))
+		})
+
+		It("should allow blockers completed in the same operation", func() {
+			err := task.CheckBlockers(tasks, []task.Dependency{{TaskID: "a", BlockerID: "b"}})
+
+			Expect(err).To(Succeed())
+		})
+	})
+})
@@ -3,8 +3,36 @@ package task
 import "errors"
 
 var (
-	ErrTaskNotFound = errors.New("task not found")
-	ErrInvalidTitle = errors.New("title must not be empty")
-	ErrTitleBlank   = errors.New("title must not be blank")
-	ErrTitleTooLong = errors.New("title must not exceed 200 characters")
+	ErrTaskNotFound    = errors.New("task not found")
+	ErrVersionConflict = errors.New("task was changed concurrently")
+	ErrInvalidTitle    = errors.New("title must not be empty")
+	ErrTitleBlank      = errors.New("title must not be blank")
+	ErrTitleTooLong    = errors.New("title must not exceed 200 characters")
+
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidTransition = errors.New("invalid status transition")
+
+	ErrInvalidPriority   = errors.New("invalid priority")
+	ErrDueBeforeCreation = errors.New("due date must not be before creation")
+	ErrInvalidSort       = errors.New("invalid sort order")
+	ErrInvalidLabelMatch = errors.New("invalid label match")
+
+	ErrInvalidRecurrence        = errors.New("invalid recurrence rule")
+	ErrRecurrenceWithoutDueDate = errors.New("recurring task must have a due date")
+
+	ErrParentNotFound   = errors.New("parent task not found")
+	ErrHierarchyCycle   = errors.New("task cannot be nested below itself or its subtasks")
+	ErrHierarchyTooDeep = errors.New("subtasks are nested too deeply")
+	ErrOpenSubtasks     = errors.New("task has open subtasks")
+	ErrParentDeleted    = errors.New("parent task is deleted")
+
+	ErrBlockerNotFound    = errors.New("blocker task not found")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrDependencyCycle    = errors.New("dependency would make a task wait on itself")
+	ErrOpenBlockers       = errors.New("task is blocked by open tasks")
+
+	ErrInvalidAssignee  = errors.New("assignee must not be empty")
+	ErrAssigneeNotFound = errors.New("user is not assigned to the task")
+	ErrTooManyAssignees = errors.New("task has too many assignees")
+	ErrTaskFinished     = errors.New("finished task must be reopened before reassigning")
 )
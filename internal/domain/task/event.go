@@ -0,0 +1,148 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTaskCreated       EventType = "TaskCreated"
+	EventTaskRenamed       EventType = "TaskRenamed"
+	EventTaskCompleted     EventType = "TaskCompleted"
+	EventTaskStatusChanged EventType = "TaskStatusChanged"
+	EventTaskRescheduled   EventType = "TaskRescheduled"
+	EventTaskMoved         EventType = "TaskMoved"
+	EventTaskAssigned      EventType = "TaskAssigned"
+	EventTaskUnassigned    EventType = "TaskUnassigned"
+	EventTaskDeleted       EventType = "TaskDeleted"
+	EventTaskRestored      EventType = "TaskRestored"
+)
+
+// Event records one change to a task. The events of a task form its stream,
+// numbered by Version from 1; replaying a stream in order rebuilds the task.
+type Event struct {
+	TaskID     string
+	Version    int
+	Type       EventType
+	OccurredAt time.Time
+	// State carries the fields the event sets:
+	//   TaskCreated: the whole task
+	//   TaskRenamed: Title
+	//   TaskCompleted, TaskStatusChanged: Status
+	//   TaskRescheduled: Priority, DueAt, Recurrence, SeriesID, Occurrence
+	//   TaskMoved: ProjectID, ParentID
+	//   TaskDeleted: DeletedAt
+	// The other events carry no state.
+	State *Task
+	// UserID is the user of TaskAssigned and TaskUnassigned.
+	UserID string
+}
+
+// Apply changes t as e describes. TaskCreated is not applied to an existing
+// task; use Replay to start a stream. Assignment and trash events leave
+// UpdatedAt alone, as Repository.AddAssignee and Repository.Delete do.
+func (t *Task) Apply(e Event) error {
+	switch e.Type {
+	case EventTaskAssigned:
+		if !slices.Contains(t.Assignees, e.UserID) {
+			t.Assignees = append(t.Assignees, e.UserID)
+			slices.Sort(t.Assignees)
+		}
+		return nil
+	case EventTaskUnassigned:
+		t.Assignees = slices.DeleteFunc(t.Assignees, func(id string) bool { return id == e.UserID })
+		return nil
+	case EventTaskDeleted:
+		t.DeletedAt = e.State.DeletedAt
+		return nil
+	case EventTaskRestored:
+		t.DeletedAt = nil
+		return nil
+	case EventTaskRenamed:
+		t.Title = e.State.Title
+	case EventTaskCompleted, EventTaskStatusChanged:
+		t.Status = e.State.Status
+	case EventTaskRescheduled:
+		t.Priority = e.State.Priority
+		t.DueAt = e.State.DueAt
+		t.Recurrence = e.State.Recurrence
+		t.SeriesID = e.State.SeriesID
+		t.Occurrence = e.State.Occurrence
+	case EventTaskMoved:
+		t.ProjectID = e.State.ProjectID
+		t.ParentID = e.State.ParentID
+	default:
+		return fmt.Errorf("cannot apply %s to task %q", e.Type, t.ID)
+	}
+	t.UpdatedAt = e.OccurredAt
+	return nil
+}
+
+// Replay rebuilds a task from events, starting from snapshot, or from the
+// TaskCreated event when snapshot is nil. snapshot is not modified.
+func Replay(snapshot *Task, events []Event) (*Task, error) {
+	var t *Task
+	if snapshot != nil {
+		t = snapshot.Clone()
+	}
+	for _, e := range events {
+		if t == nil {
+			if e.Type != EventTaskCreated {
+				return nil, fmt.Errorf("stream of task %q starts with %s", e.TaskID, e.Type)
+			}
+			t = e.State.Clone()
+			continue
+		}
+		if err := t.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+	return t, nil
+}
+
+// Changes returns the events that turn before into after. Only the fields
+// persisted by Repository.Update are compared; assignees and deletion have
+// events of their own.
+func Changes(before, after *Task) []Event {
+	var events []Event
+	add := func(eventType EventType) {
+		events = append(events, Event{TaskID: after.ID, Type: eventType, OccurredAt: after.UpdatedAt, State: after})
+	}
+	if before.Title != after.Title {
+		add(EventTaskRenamed)
+	}
+	if before.Status != after.Status {
+		if after.Status == StatusComplete {
+			add(EventTaskCompleted)
+		} else {
+			add(EventTaskStatusChanged)
+		}
+	}
+	if before.Priority != after.Priority || !equalTime(before.DueAt, after.DueAt) ||
+		!equalRecurrence(before.Recurrence, after.Recurrence) ||
+		!equalString(before.SeriesID, after.SeriesID) || before.Occurrence != after.Occurrence {
+		add(EventTaskRescheduled)
+	}
+	if !equalString(before.ProjectID, after.ProjectID) || !equalString(before.ParentID, after.ParentID) {
+		add(EventTaskMoved)
+	}
+	return events
+}
+
+func equalString(a, b *string) bool {
+	return (a == nil) == (b == nil) && (a == nil || *a == *b)
+}
+
+func equalTime(a, b *time.Time) bool {
+	return (a == nil) == (b == nil) && (a == nil || a.Equal(*b))
+}
+
+func equalRecurrence(a, b *Recurrence) bool {
+	return (a == nil) == (b == nil) && (a == nil || a.String() == b.String())
+}
@@ -0,0 +1,124 @@
+package task_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+var _ = Describe("Events", func() {
+	created := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := created.Add(time.Hour)
+
+	var initial *task.Task
+
+	BeforeEach(func() {
+		initial = &task.Task{ID: "task-1", Title: "Write report", Status: task.StatusTodo,
+			Priority: task.PriorityMedium, CreatedAt: created, UpdatedAt: created}
+	})
+
+	createdEvent := func() task.Event {
+		return task.Event{TaskID: "task-1", Version: 1, Type: task.EventTaskCreated, OccurredAt: created, State: initial}
+	}
+
+	Describe("Replay", func() {
+		It("should rebuild a task from its stream", func() {
+			t, err := task.Replay(nil, []task.Event{
+				createdEvent(),
+				{TaskID: "task-1", Version: 2, Type: task.EventTaskRenamed, OccurredAt: later, State: &task.Task{Title: "Write summary"}},
+				{TaskID: "task-1", Version: 3, Type: task.EventTaskAssigned, OccurredAt: later, UserID: "bob"},
+				{TaskID: "task-1", Version: 4, Type: task.EventTaskAssigned, OccurredAt: later, UserID: "alice"},
+				{TaskID: "task-1", Version: 5, Type: task.EventTaskUnassigned, OccurredAt: later, UserID: "bob"},
+			})
+
+			Expect(err).To(BeNil())
+			Expect(t.Title).To(Equal("Write summary"))
+			Expect(t.Assignees).To(Equal([]string{"alice"}))
+			Expect(t.UpdatedAt).To(Equal(later))
+		})
+
+		It("should continue from a snapshot without changing it", func() {
+			t, err := task.Replay(initial, []task.Event{
+				{TaskID: "task-1", Version: 7, Type: task.EventTaskCompleted, OccurredAt: later, State: &task.Task{Status: task.StatusComplete}},
+			})
+
+			Expect(err).To(BeNil())
+			Expect(t.Status).To(Equal(task.StatusComplete))
+			Expect(initial.Status).To(Equal(task.StatusTodo))
+		})
+
+		It("should leave UpdatedAt alone when a task is deleted and restored", func() {
+			t, err := task.Replay(nil, []task.Event{
+				createdEvent(),
+				{TaskID: "task-1", Version: 2, Type: task.EventTaskDeleted, OccurredAt: later, State: &task.Task{DeletedAt: &later}},
+			})
+			Expect(err).To(BeNil())
+			Expect(t.DeletedAt).To(HaveValue(Equal(later)))
+
+			t, err = task.Replay(t, []task.Event{{TaskID: "task-1", Version: 3, Type: task.EventTaskRestored, OccurredAt: later}})
+			Expect(err).To(BeNil())
+			Expect(t.DeletedAt).To(BeNil())
+			Expect(t.UpdatedAt).To(Equal(created))
+		})
+
+		It("should reject a stream that does not start with TaskCreated", func() {
+			_, err := task.Replay(nil, []task.Event{
+				{TaskID: "task-1", Version: 1, Type: task.EventTaskRenamed, State: &task.Task{Title: "Write summary"}},
+			})
+
+			Expect(err).To(MatchError(ContainSubstring("starts with TaskRenamed")))
+		})
+
+		It("should report an empty stream as not found", func() {
+			_, err := task.Replay(nil, nil)
+
+			Expect(err).To(MatchError(task.ErrTaskNotFound))
+		})
+	})
+
+	Describe("Changes", func() {
+		It("should return no events when nothing persisted changed", func() {
+			after := initial.Clone()
+			after.Assignees = []string{"alice"}
+
+			Expect(task.Changes(initial, after)).To(BeEmpty())
+		})
+
+		It("should return one event per changed aspect that replays to the new state", func() {
+			dueAt := created.Add(48 * time.Hour)
+			parentID := "parent-1"
+			after := initial.Clone()
+			Expect(after.Rename("Write summary", later)).To(Succeed())
+			Expect(after.Complete(later)).To(Succeed())
+			after.DueAt = &dueAt
+			after.ParentID = &parentID
+
+			events := task.Changes(initial, after)
+
+			types := make([]task.EventType, len(events))
+			for i, e := range events {
+				types[i] = e.Type
+			}
+			Expect(types).To(Equal([]task.EventType{
+				task.EventTaskRenamed, task.EventTaskCompleted, task.EventTaskRescheduled, task.EventTaskMoved,
+			}))
+
+			replayed, err := task.Replay(initial, events)
+			Expect(err).To(BeNil())
+			Expect(replayed).To(Equal(after))
+		})
+
+		It("should tell reopening from completing", func() {
+			after := initial.Clone()
+			after.Status = task.StatusInProgress
+
+			events := task.Changes(initial, after)
+
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Type).To(Equal(task.EventTaskStatusChanged))
+		})
+	})
+})
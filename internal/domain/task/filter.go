@@ -0,0 +1,61 @@
+package task
+
+import (
+	"fmt"
+	"time"
+)
+
+type SortOrder string
+
+const (
+	SortByCreatedAt SortOrder = "created_at"
+	// SortByDueAt orders by due date, earliest first, with undated tasks last.
+	SortByDueAt SortOrder = "due_at"
+)
+
+func ParseSortOrder(s string) (SortOrder, error) {
+	switch order := SortOrder(s); order {
+	case SortByCreatedAt, SortByDueAt:
+		return order, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidSort, s)
+}
+
+// LabelMatch decides whether ListFilter.Labels keeps tasks carrying any or
+// all of the labels.
+type LabelMatch string
+
+const (
+	LabelMatchAny LabelMatch = "any"
+	LabelMatchAll LabelMatch = "all"
+)
+
+func ParseLabelMatch(s string) (LabelMatch, error) {
+	switch match := LabelMatch(s); match {
+	case LabelMatchAny, LabelMatchAll:
+		return match, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidLabelMatch, s)
+}
+
+// ListFilter narrows the tasks returned by Repository.FindAll. Zero values
+// mean "no constraint".
+type ListFilter struct {
+	IDs       []string
+	ProjectID string
+	Priority  Priority
+	// OverdueAt, when set, keeps only open tasks whose due date is before it.
+	OverdueAt time.Time
+	// Assignee keeps tasks assigned to this user ID.
+	Assignee string
+	// SeriesID keeps the occurrences of a recurring series.
+	SeriesID string
+	// MinOccurrence keeps occurrences numbered at least MinOccurrence.
+	MinOccurrence int
+	// Labels keeps tasks carrying the named labels, combined as LabelMatch
+	// says; LabelMatch defaults to LabelMatchAny.
+	Labels     []string
+	LabelMatch LabelMatch
+	// SortBy defaults to SortByCreatedAt.
+	SortBy SortOrder
+}
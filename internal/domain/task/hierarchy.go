@@ -0,0 +1,131 @@
+package task
+
+import "time"
+
+// MaxDepth is the deepest level a subtask may be nested at. Top-level tasks
+// are at depth 0.
+const MaxDepth = 4
+
+// Progress counts the direct subtasks of a task. Cancelled subtasks are not
+// counted.
+type Progress struct {
+	Total     int
+	Completed int
+}
+
+// SetParent moves the task below lineage[0], or makes it a top-level task
+// when lineage is empty. lineage lists the new parent followed by its
+// ancestors up to a top-level task, and subtreeHeight is how many levels of
+// subtasks the task itself has. Together they reject cycles and trees deeper
+// than MaxDepth.
+func (t *Task) SetParent(lineage []*Task, subtreeHeight int, now time.Time) error {
+	if len(lineage) == 0 {
+		t.ParentID = nil
+		t.UpdatedAt = now
+		return nil
+	}
+	for _, ancestor := range lineage {
+		if ancestor.ID == t.ID {
+			return ErrHierarchyCycle
+		}
+	}
+	if len(lineage)+subtreeHeight > MaxDepth {
+		return ErrHierarchyTooDeep
+	}
+	parentID := lineage[0].ID
+	t.ParentID = &parentID
+	t.UpdatedAt = now
+	return nil
+}
+
+// Tree is a task with all of its descendants.
+type Tree struct {
+	Task     *Task
+	Subtasks []*Tree
+}
+
+// BuildTree arranges tasks, as returned by Repository.FindTree, below the
+// task with rootID. Siblings keep the order they have in tasks.
+func BuildTree(rootID string, tasks []*Task) (*Tree, error) {
+	nodes := make(map[string]*Tree, len(tasks))
+	for _, t := range tasks {
+		nodes[t.ID] = &Tree{Task: t}
+	}
+	root, ok := nodes[rootID]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	for _, t := range tasks {
+		if t.ID == rootID || t.ParentID == nil {
+			continue
+		}
+		if parent, ok := nodes[*t.ParentID]; ok {
+			parent.Subtasks = append(parent.Subtasks, nodes[t.ID])
+		}
+	}
+	return root, nil
+}
+
+// Height is the number of subtask levels below the root; a task without
+// subtasks has height 0.
+func (tr *Tree) Height() int {
+	height := 0
+	for _, sub := range tr.Subtasks {
+		height = max(height, sub.Height()+1)
+	}
+	return height
+}
+
+// Complete completes the root task. Open subtasks prevent this unless cascade
+// is set, in which case they are completed first. It returns every task that
+// changed, deepest first; a root that is complete already is left as it is
+// and not returned.
+func (tr *Tree) Complete(cascade bool, now time.Time) ([]*Task, error) {
+	changed, err := tr.completeSubtasks(cascade, now)
+	if err != nil {
+		return nil, err
+	}
+	if tr.Task.Status == StatusComplete {
+		return changed, nil
+	}
+	if err := tr.Task.Complete(now); err != nil {
+		return nil, err
+	}
+	return append(changed, tr.Task), nil
+}
+
+func (tr *Tree) completeSubtasks(cascade bool, now time.Time) ([]*Task, error) {
+	var changed []*Task
+	for _, sub := range tr.Subtasks {
+		if !sub.hasOpen() {
+			continue
+		}
+		if !cascade {
+			return nil, ErrOpenSubtasks
+		}
+		// Finished subtasks stay as they are; only their open descendants
+		// are completed.
+		complete := sub.completeSubtasks
+		if sub.Task.IsOpen() {
+			complete = sub.Complete
+		}
+		subChanged, err := complete(true, now)
+		if err != nil {
+			return nil, err
+		}
+		changed = append(changed, subChanged...)
+	}
+	return changed, nil
+}
+
+func (tr *Tree) hasOpen() bool {
+	if tr.Task.IsOpen() {
+		return true
+	}
+	for _, sub := range tr.Subtasks {
+		if sub.hasOpen() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package task_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+var _ = Describe("Hierarchy", func() {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newTask := func(id string, parent *task.Task) *task.Task {
+		t := &task.Task{ID: id, Status: task.StatusTodo}
+		if parent != nil {
+			parentID := parent.ID
+			t.ParentID = &parentID
+		}
+		return t
+	}
+
+	Describe("SetParent", func() {
+		It("should nest the task below the first task of the lineage", func() {
+			root := newTask("root", nil)
+			child := newTask("child", nil)
+
+			Expect(child.SetParent([]*task.Task{root}, 0, now)).To(Succeed())
+			Expect(child.ParentID).To(HaveValue(Equal("root")))
+		})
+
+		It("should make the task top-level without a lineage", func() {
+			child := newTask("child", newTask("root", nil))
+
+			Expect(child.SetParent(nil, 0, now)).To(Succeed())
+			Expect(child.ParentID).To(BeNil())
+		})
+
+		It("should reject nesting a task below itself or a descendant", func() {
+			root := newTask("root", nil)
+			child := newTask("child", root)
+
+			Expect(root.SetParent([]*task.Task{root}, 1, now)).To(MatchError(task.ErrHierarchyCycle))
+			Expect(root.SetParent([]*task.Task{child, root}, 1, now)).To(MatchError(task.ErrHierarchyCycle))
+		})
+
+		It("should reject trees deeper than MaxDepth", func() {
+			lineage := []*task.Task{}
+			for i := 0; i < task.MaxDepth; i++ {
+				lineage = append(lineage, newTask("ancestor", nil))
+			}
+			leaf := newTask("leaf", nil)
+
+			Expect(leaf.SetParent(lineage, 0, now)).To(Succeed())
+			Expect(leaf.SetParent(lineage[1:], 1, now)).To(Succeed())
+			Expect(leaf.SetParent(lineage, 1, now)).To(MatchError(task.ErrHierarchyTooDeep))
+		})
+	})
+
+	Describe("BuildTree", func() {
+		It("should nest descendants and measure the height", func() {
+			root := newTask("root", nil)
+			child := newTask("child", root)
+			grandchild := newTask("grandchild", child)
+
+			tree, err := task.BuildTree("root", []*task.Task{root, child, grandchild})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tree.Task).To(Equal(root))
+			Expect(tree.Subtasks).To(HaveLen(1))
+			Expect(tree.Subtasks[0].Subtasks[0].Task).To(Equal(grandchild))
+			Expect(tree.Height()).To(Equal(2))
+		})
+
+		It("should report a missing root", func() {
+			_, err := task.BuildTree("root", nil)
+
+			Expect(err).To(MatchError(task.ErrTaskNotFound))
+		})
+	})
+
+	Describe("Tree.Complete", func() {
+		It("should ignore finished subtasks", func() {
+			root := newTask("root", nil)
+			done := newTask("done", root)
+			done.Status = task.StatusCancelled
+			tree, _ := task.BuildTree("root", []*task.Task{root, done})
+
+			changed, err := tree.Complete(false, now)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(ConsistOf(root))
+			Expect(root.Status).To(Equal(task.StatusComplete))
+		})
+
+		It("should only complete open descendants of finished subtasks", func() {
+			root := newTask("root", nil)
+			cancelled := newTask("cancelled", root)
+			cancelled.Status = task.StatusCancelled
+			open := newTask("open", cancelled)
+			tree, _ := task.BuildTree("root", []*task.Task{root, cancelled, open})
+
+			changed, err := tree.Complete(true, now)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(Equal([]*task.Task{open, root}))
+			Expect(cancelled.Status).To(Equal(task.StatusCancelled))
+		})
+
+		It("should leave a complete root out of the changes", func() {
+			root := newTask("root", nil)
+			root.Status = task.StatusComplete
+			root.UpdatedAt = now.Add(-time.Hour)
+			tree, _ := task.BuildTree("root", []*task.Task{root})
+
+			changed, err := tree.Complete(false, now)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeEmpty())
+			Expect(root.UpdatedAt).To(Equal(now.Add(-time.Hour)))
+		})
+	})
+})
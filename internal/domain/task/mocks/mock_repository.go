@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	task "github.com/ko44d/go-clean-hexapp/internal/domain/task"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,20 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddAssignee mocks base method.
+func (m *MockRepository) AddAssignee(ctx context.Context, taskID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAssignee", ctx, taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAssignee indicates an expected call of AddAssignee.
+func (mr *MockRepositoryMockRecorder) AddAssignee(ctx, taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAssignee", reflect.TypeOf((*MockRepository)(nil).AddAssignee), ctx, taskID, userID)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, arg1 *task.Task) error {
 	m.ctrl.T.Helper()
@@ -55,19 +70,33 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, arg1)
 }
 
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id, deletedAt)
+}
+
 // FindAll mocks base method.
-func (m *MockRepository) FindAll(ctx context.Context) ([]*task.Task, error) {
+func (m *MockRepository) FindAll(ctx context.Context, filter task.ListFilter) ([]*task.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
 	ret0, _ := ret[0].([]*task.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository)(nil).FindAll), ctx, filter)
 }
 
 // FindByID mocks base method.
@@ -85,6 +114,79 @@ func (mr *MockRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, id)
 }
 
+// FindDeleted mocks base method.
+func (m *MockRepository) FindDeleted(ctx context.Context) ([]*task.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeleted", ctx)
+	ret0, _ := ret[0].([]*task.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeleted indicates an expected call of FindDeleted.
+func (mr *MockRepositoryMockRecorder) FindDeleted(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeleted", reflect.TypeOf((*MockRepository)(nil).FindDeleted), ctx)
+}
+
+// FindDueWithin mocks base method.
+func (m *MockRepository) FindDueWithin(ctx context.Context, now time.Time, window time.Duration) ([]*task.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDueWithin", ctx, now, window)
+	ret0, _ := ret[0].([]*task.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDueWithin indicates an expected call of FindDueWithin.
+func (mr *MockRepositoryMockRecorder) FindDueWithin(ctx, now, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueWithin", reflect.TypeOf((*MockRepository)(nil).FindDueWithin), ctx, now, window)
+}
+
+// FindTree mocks base method.
+func (m *MockRepository) FindTree(ctx context.Context, id string) ([]*task.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTree", ctx, id)
+	ret0, _ := ret[0].([]*task.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTree indicates an expected call of FindTree.
+func (mr *MockRepositoryMockRecorder) FindTree(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTree", reflect.TypeOf((*MockRepository)(nil).FindTree), ctx, id)
+}
+
+// RemoveAssignee mocks base method.
+func (m *MockRepository) RemoveAssignee(ctx context.Context, taskID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAssignee", ctx, taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAssignee indicates an expected call of RemoveAssignee.
+func (mr *MockRepositoryMockRecorder) RemoveAssignee(ctx, taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAssignee", reflect.TypeOf((*MockRepository)(nil).RemoveAssignee), ctx, taskID, userID)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, arg1 *task.Task) error {
 	m.ctrl.T.Helper()
@@ -98,3 +200,99 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, arg1)
 }
+
+// MockDependencyRepository is a mock of DependencyRepository interface.
+type MockDependencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDependencyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDependencyRepositoryMockRecorder is the mock recorder for MockDependencyRepository.
+type MockDependencyRepositoryMockRecorder struct {
+	mock *MockDependencyRepository
+}
+
+// NewMockDependencyRepository creates a new mock instance.
+func NewMockDependencyRepository(ctrl *gomock.Controller) *MockDependencyRepository {
+	mock := &MockDependencyRepository{ctrl: ctrl}
+	mock.recorder = &MockDependencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDependencyRepository) EXPECT() *MockDependencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockDependencyRepository) Add(ctx context.Context, d task.Dependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockDependencyRepositoryMockRecorder) Add(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockDependencyRepository)(nil).Add), ctx, d)
+}
+
+// FindGraph mocks base method.
+func (m *MockDependencyRepository) FindGraph(ctx context.Context, taskID string) ([]task.Dependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindGraph", ctx, taskID)
+	ret0, _ := ret[0].([]task.Dependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindGraph indicates an expected call of FindGraph.
+func (mr *MockDependencyRepositoryMockRecorder) FindGraph(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindGraph", reflect.TypeOf((*MockDependencyRepository)(nil).FindGraph), ctx, taskID)
+}
+
+// FindOpenBlockers mocks base method.
+func (m *MockDependencyRepository) FindOpenBlockers(ctx context.Context, taskIDs []string) ([]task.Dependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOpenBlockers", ctx, taskIDs)
+	ret0, _ := ret[0].([]task.Dependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOpenBlockers indicates an expected call of FindOpenBlockers.
+func (mr *MockDependencyRepositoryMockRecorder) FindOpenBlockers(ctx, taskIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOpenBlockers", reflect.TypeOf((*MockDependencyRepository)(nil).FindOpenBlockers), ctx, taskIDs)
+}
+
+// Lock mocks base method.
+func (m *MockDependencyRepository) Lock(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockDependencyRepositoryMockRecorder) Lock(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockDependencyRepository)(nil).Lock), ctx)
+}
+
+// Remove mocks base method.
+func (m *MockDependencyRepository) Remove(ctx context.Context, d task.Dependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockDependencyRepositoryMockRecorder) Remove(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockDependencyRepository)(nil).Remove), ctx, d)
+}
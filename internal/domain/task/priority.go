@@ -0,0 +1,28 @@
+package task
+
+import "fmt"
+
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityMedium Priority = "medium"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+func ParsePriority(s string) (Priority, error) {
+	priority := Priority(s)
+	if !priority.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPriority, s)
+	}
+	return priority, nil
+}
+
+func (p Priority) Valid() bool {
+	switch p {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
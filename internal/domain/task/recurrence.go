@@ -0,0 +1,224 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+const untilLayout = "20060102T150405Z"
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Recurrence is the subset of an RFC 5545 RRULE that tasks support: FREQ
+// (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY for weekly rules, and either
+// UNTIL or COUNT. Weeks start on Monday.
+type Recurrence struct {
+	Frequency Frequency
+	Interval  int
+	Weekdays  []time.Weekday
+	Until     *time.Time
+	Count     int
+}
+
+// ParseRecurrence parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+// An optional "RRULE:" prefix is accepted.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	r := &Recurrence{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" || seen[key] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, rule)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Frequency = Frequency(value)
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.Weekdays, err = parseWeekdays(value)
+		default:
+			err = fmt.Errorf("unsupported part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidRecurrence, rule, err)
+		}
+	}
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrInvalidRecurrence, rule, err)
+	}
+	return r, nil
+}
+
+func (r *Recurrence) validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return fmt.Errorf("unsupported frequency %q", r.Frequency)
+	}
+	if r.Interval < 1 {
+		return fmt.Errorf("interval must be positive")
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("count must be positive")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("until and count are mutually exclusive")
+	}
+	if len(r.Weekdays) > 0 && r.Frequency != FrequencyWeekly {
+		return fmt.Errorf("byday is only supported for weekly rules")
+	}
+	return nil
+}
+
+// String formats r as an RRULE value without the "RRULE:" prefix.
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		codes := make([]string, 0, len(r.Weekdays))
+		for _, day := range r.Weekdays {
+			codes = append(codes, weekdayCode(day))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilLayout))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the occurrence after prev, which is occurrence number
+// occurrence of the series (starting at 1). It reports false when the series
+// has ended.
+func (r *Recurrence) Next(prev time.Time, occurrence int) (time.Time, bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		next = prev.AddDate(0, 0, r.Interval)
+	case FrequencyWeekly:
+		next = r.nextWeekly(prev)
+	case FrequencyMonthly:
+		var ok bool
+		if next, ok = r.nextMonthly(prev); !ok {
+			return time.Time{}, false
+		}
+	}
+
+	if r.Until != nil && next.After(*r.Until) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (r *Recurrence) nextWeekly(prev time.Time) time.Time {
+	if len(r.Weekdays) == 0 {
+		return prev.AddDate(0, 0, 7*r.Interval)
+	}
+	// Remaining days of the current week first, then the first matching day
+	// of the week Interval weeks later.
+	for d := 1; weekIndex(prev.Weekday())+d < 7; d++ {
+		if candidate := prev.AddDate(0, 0, d); slices.Contains(r.Weekdays, candidate.Weekday()) {
+			return candidate
+		}
+	}
+	weekStart := prev.AddDate(0, 0, 7*r.Interval-weekIndex(prev.Weekday()))
+	for d := 0; ; d++ {
+		if candidate := weekStart.AddDate(0, 0, d); slices.Contains(r.Weekdays, candidate.Weekday()) {
+			return candidate
+		}
+	}
+}
+
+// nextMonthly keeps the day of the month and, as RFC 5545 requires, skips
+// months that do not have that day.
+func (r *Recurrence) nextMonthly(prev time.Time) (time.Time, bool) {
+	for n := 1; n <= 100; n++ {
+		candidate := time.Date(
+			prev.Year(), prev.Month()+time.Month(n*r.Interval), prev.Day(),
+			prev.Hour(), prev.Minute(), prev.Second(), prev.Nanosecond(), prev.Location(),
+		)
+		if candidate.Day() == prev.Day() {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseUntil(value string) (*time.Time, error) {
+	if until, err := time.Parse(untilLayout, value); err == nil {
+		return &until, nil
+	}
+	date, err := time.Parse("20060102", value)
+	if err != nil {
+		return nil, fmt.Errorf("until must be a UTC date-time or date")
+	}
+	// A date-only UNTIL includes the whole day.
+	until := date.Add(24*time.Hour - time.Nanosecond)
+	return &until, nil
+}
+
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		day, ok := weekdayCodes[code]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", code)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	slices.SortFunc(days, func(a, b time.Weekday) int { return weekIndex(a) - weekIndex(b) })
+	return days, nil
+}
+
+func weekdayCode(day time.Weekday) string {
+	for code, d := range weekdayCodes {
+		if d == day {
+			return code
+		}
+	}
+	return ""
+}
+
+// weekIndex numbers weekdays from Monday (0) to Sunday (6).
+func weekIndex(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
@@ -0,0 +1,150 @@
+package task_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+var _ = Describe("Recurrence", func() {
+	// 2030-01-07 is a Monday.
+	monday := time.Date(2030, 1, 7, 9, 0, 0, 0, time.UTC)
+
+	mustParse := func(rule string) *task.Recurrence {
+		r, err := task.ParseRecurrence(rule)
+		Expect(err).NotTo(HaveOccurred())
+		return r
+	}
+
+	Describe("ParseRecurrence", func() {
+		It("should round-trip supported rules", func() {
+			rule := "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20301231T000000Z"
+
+			Expect(mustParse(rule).String()).To(Equal(rule))
+		})
+
+		It("should accept the RRULE prefix and normalise weekdays", func() {
+			Expect(mustParse("RRULE:FREQ=WEEKLY;BYDAY=FR,MO,FR").String()).To(Equal("FREQ=WEEKLY;BYDAY=MO,FR"))
+		})
+
+		DescribeTable("should reject unsupported rules",
+			func(rule string) {
+				_, err := task.ParseRecurrence(rule)
+				Expect(err).To(MatchError(task.ErrInvalidRecurrence))
+			},
+			Entry("missing frequency", "INTERVAL=2"),
+			Entry("yearly frequency", "FREQ=YEARLY"),
+			Entry("zero interval", "FREQ=DAILY;INTERVAL=0"),
+			Entry("until and count", "FREQ=DAILY;COUNT=3;UNTIL=20301231"),
+			Entry("byday on daily rules", "FREQ=DAILY;BYDAY=MO"),
+			Entry("unknown weekday", "FREQ=WEEKLY;BYDAY=XX"),
+			Entry("unsupported part", "FREQ=WEEKLY;BYMONTH=1"),
+			Entry("repeated part", "FREQ=DAILY;FREQ=WEEKLY"),
+		)
+	})
+
+	Describe("Next", func() {
+		It("should add the interval for daily rules", func() {
+			next, ok := mustParse("FREQ=DAILY;INTERVAL=3").Next(monday, 1)
+
+			Expect(ok).To(BeTrue())
+			Expect(next).To(Equal(monday.AddDate(0, 0, 3)))
+		})
+
+		It("should move to the next listed weekday in the same week", func() {
+			next, ok := mustParse("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH").Next(monday, 1)
+
+			Expect(ok).To(BeTrue())
+			Expect(next).To(Equal(monday.AddDate(0, 0, 3)))
+		})
+
+		It("should skip to the first weekday Interval weeks later", func() {
+			thursday := monday.AddDate(0, 0, 3)
+
+			next, ok := mustParse("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH").Next(thursday, 2)
+
+			Expect(ok).To(BeTrue())
+			Expect(next).To(Equal(monday.AddDate(0, 0, 14)))
+		})
+
+		It("should skip months without the day", func() {
+			january31 := time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC)
+
+			next, ok := mustParse("FREQ=MONTHLY").Next(january31, 1)
+
+			Expect(ok).To(BeTrue())
+			Expect(next).To(Equal(time.Date(2030, 3, 31, 9, 0, 0, 0, time.UTC)))
+		})
+
+		It("should end after COUNT occurrences", func() {
+			rule := mustParse("FREQ=DAILY;COUNT=2")
+
+			_, ok := rule.Next(monday, 1)
+			Expect(ok).To(BeTrue())
+			_, ok = rule.Next(monday.AddDate(0, 0, 1), 2)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should end after UNTIL", func() {
+			rule := mustParse("FREQ=DAILY;UNTIL=20300108")
+
+			_, ok := rule.Next(monday, 1)
+			Expect(ok).To(BeTrue())
+			_, ok = rule.Next(monday.AddDate(0, 0, 1), 2)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("Task.NextOccurrence", func() {
+		var recurring *task.Task
+
+		BeforeEach(func() {
+			recurring, _ = task.New("task-1", "Weekly checklist", monday.Add(-time.Hour), monday.Add(-time.Hour))
+			recurring.WorkspaceID = "workspace-1"
+			Expect(recurring.SetPriority(task.PriorityHigh, monday)).To(Succeed())
+			Expect(recurring.SetDueAt(&monday, monday)).To(Succeed())
+			Expect(recurring.SetRecurrence(mustParse("FREQ=WEEKLY"), monday)).To(Succeed())
+		})
+
+		It("should start a series on the first occurrence", func() {
+			Expect(recurring.SeriesID).To(HaveValue(Equal("task-1")))
+			Expect(recurring.Occurrence).To(Equal(1))
+		})
+
+		It("should require a due date", func() {
+			oneOff, _ := task.New("task-2", "One-off", monday, monday)
+
+			Expect(oneOff.SetRecurrence(mustParse("FREQ=DAILY"), monday)).To(MatchError(task.ErrRecurrenceWithoutDueDate))
+		})
+
+		It("should copy the task into the next slot of the series", func() {
+			next := recurring.NextOccurrence("task-2", monday)
+
+			Expect(next).NotTo(BeNil())
+			Expect(next.ID).To(Equal("task-2"))
+			Expect(next.WorkspaceID).To(Equal("workspace-1"))
+			Expect(next.Title).To(Equal("Weekly checklist"))
+			Expect(next.Status).To(Equal(task.StatusTodo))
+			Expect(next.Priority).To(Equal(task.PriorityHigh))
+			Expect(next.DueAt).To(HaveValue(Equal(monday.AddDate(0, 0, 7))))
+			Expect(next.SeriesID).To(HaveValue(Equal("task-1")))
+			Expect(next.Occurrence).To(Equal(2))
+		})
+
+		It("should skip occurrences that are already past", func() {
+			next := recurring.NextOccurrence("task-2", monday.AddDate(0, 0, 10))
+
+			Expect(next.DueAt).To(HaveValue(Equal(monday.AddDate(0, 0, 14))))
+			Expect(next.Occurrence).To(Equal(3))
+		})
+
+		It("should return nil for one-off tasks", func() {
+			Expect(recurring.SetRecurrence(nil, monday)).To(Succeed())
+
+			Expect(recurring.NextOccurrence("task-2", monday)).To(BeNil())
+		})
+	})
+})
@@ -4,11 +4,50 @@ package task
 
 import (
 	"context"
+	"time"
 )
 
+// Repository stores tasks. Deleted tasks stay in the trash until they are
+// restored or purged; every method except FindDeleted and Restore treats
+// them as missing.
 type Repository interface {
-	FindAll(ctx context.Context) ([]*Task, error)
+	FindAll(ctx context.Context, filter ListFilter) ([]*Task, error)
 	FindByID(ctx context.Context, id string) (*Task, error)
+	// FindTree returns the task with the given id followed by all of its
+	// descendants, parents before their subtasks.
+	FindTree(ctx context.Context, id string) ([]*Task, error)
 	Create(ctx context.Context, task *Task) error
 	Update(ctx context.Context, task *Task) error
+	// Delete moves the task and its subtasks to the trash.
+	Delete(ctx context.Context, id string, deletedAt time.Time) error
+	// FindDeleted returns the tasks in the trash, most recently deleted first.
+	FindDeleted(ctx context.Context) ([]*Task, error)
+	// Restore takes the task out of the trash together with the subtasks
+	// deleted along with it. It returns ErrTaskNotFound when the task is not
+	// in the trash and ErrParentDeleted while its parent still is.
+	Restore(ctx context.Context, id string) error
+	// AddAssignee and RemoveAssignee persist changes made by Task.Assign and
+	// Task.Unassign. Create stores the assignees of a new task itself.
+	AddAssignee(ctx context.Context, taskID string, userID string) error
+	RemoveAssignee(ctx context.Context, taskID string, userID string) error
+	// FindDueWithin returns open tasks due in [now, now+window), earliest first.
+	FindDueWithin(ctx context.Context, now time.Time, window time.Duration) ([]*Task, error)
+}
+
+// DependencyRepository stores blocked-by edges between tasks of a workspace.
+type DependencyRepository interface {
+	// Lock keeps other transactions from changing the dependencies of the
+	// workspace of ctx until the transaction of ctx ends, so that a cycle
+	// check stays valid until its edge is stored.
+	Lock(ctx context.Context) error
+	// Add stores d; adding an existing dependency is a no-op.
+	Add(ctx context.Context, d Dependency) error
+	// Remove deletes d or returns ErrDependencyNotFound.
+	Remove(ctx context.Context, d Dependency) error
+	// FindGraph returns every edge reachable from taskID in either direction:
+	// its blockers, their blockers and so on, and likewise its dependents.
+	FindGraph(ctx context.Context, taskID string) ([]Dependency, error)
+	// FindOpenBlockers returns the dependencies of taskIDs whose blocker is
+	// still open.
+	FindOpenBlockers(ctx context.Context, taskIDs []string) ([]Dependency, error)
 }
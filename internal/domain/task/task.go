@@ -1,6 +1,8 @@
 package task
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -8,39 +10,199 @@ import (
 type Status string
 
 const (
-	StatusTodo     Status = "todo"
-	StatusComplete Status = "complete"
+	StatusTodo       Status = "todo"
+	StatusInProgress Status = "in_progress"
+	StatusBlocked    Status = "blocked"
+	StatusComplete   Status = "complete"
+	StatusCancelled  Status = "cancelled"
 )
 
+func ParseStatus(s string) (Status, error) {
+	status := Status(s)
+	if !status.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidStatus, s)
+	}
+	return status, nil
+}
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusTodo, StatusInProgress, StatusBlocked, StatusComplete, StatusCancelled:
+		return true
+	}
+	return false
+}
+
 type Task struct {
-	ID        string
-	Title     string
-	Status    Status
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          string
+	WorkspaceID string
+	ProjectID   *string
+	ParentID    *string
+	Title       string
+	Status      Status
+	Priority    Priority
+	DueAt       *time.Time
+	Recurrence  *Recurrence
+	// SeriesID links the occurrences of a recurring task; it is the ID of the
+	// first occurrence. Occurrence numbers the task within its series from 1.
+	SeriesID   *string
+	Occurrence int
+	// Assignees holds the IDs of the users working on the task, sorted.
+	Assignees []string
+	// Subtasks, Labels and CommentCount are filled in by the repository when
+	// reading and are not persisted with the task. Labels holds label names,
+	// sorted.
+	Subtasks     Progress
+	Labels       []string
+	CommentCount int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// DeletedAt is set while the task is in the trash. Deleted tasks are only
+	// returned by Repository.FindDeleted.
+	DeletedAt *time.Time
 }
 
 func New(id string, title string, createdAt time.Time, updatedAt time.Time) (*Task, error) {
-	if title == "" {
-		return nil, ErrInvalidTitle
-	}
-	if strings.TrimSpace(title) == "" {
-		return nil, ErrTitleBlank
-	}
-	if len(title) > 200 {
-		return nil, ErrTitleTooLong
+	if err := validateTitle(title); err != nil {
+		return nil, err
 	}
 
 	return &Task{
 		ID:        id,
 		Title:     title,
 		Status:    StatusTodo,
+		Priority:  PriorityMedium,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
 	}, nil
 }
 
-func (t *Task) Complete(now time.Time) {
-	t.Status = StatusComplete
+func (t *Task) Rename(title string, now time.Time) error {
+	if err := validateTitle(title); err != nil {
+		return err
+	}
+	t.Title = title
+	t.UpdatedAt = now
+	return nil
+}
+
+func (t *Task) SetPriority(priority Priority, now time.Time) error {
+	if !priority.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidPriority, priority)
+	}
+	t.Priority = priority
+	t.UpdatedAt = now
+	return nil
+}
+
+// SetDueAt schedules the task, or clears its due date when dueAt is nil. A
+// task cannot be due before it was created.
+func (t *Task) SetDueAt(dueAt *time.Time, now time.Time) error {
+	if dueAt != nil && dueAt.Before(t.CreatedAt) {
+		return ErrDueBeforeCreation
+	}
+	t.DueAt = dueAt
+	t.UpdatedAt = now
+	return nil
+}
+
+// SetRecurrence makes the task repeat, or stops it repeating when r is nil.
+// A recurring task needs a due date to compute the next one from.
+func (t *Task) SetRecurrence(r *Recurrence, now time.Time) error {
+	if r != nil {
+		if t.DueAt == nil {
+			return ErrRecurrenceWithoutDueDate
+		}
+		if t.SeriesID == nil {
+			seriesID := t.ID
+			t.SeriesID = &seriesID
+			t.Occurrence = 1
+		}
+	}
+	t.Recurrence = r
+	t.UpdatedAt = now
+	return nil
+}
+
+// NextOccurrence returns the task that follows t in its series, or nil when t
+// does not recur or the series has ended. Occurrences that would already be
+// due at now are skipped, so a late completion does not spawn overdue tasks.
+func (t *Task) NextOccurrence(id string, now time.Time) *Task {
+	if t.Recurrence == nil || t.DueAt == nil {
+		return nil
+	}
+	dueAt, occurrence := *t.DueAt, t.Occurrence
+	for {
+		next, ok := t.Recurrence.Next(dueAt, occurrence)
+		if !ok {
+			return nil
+		}
+		dueAt, occurrence = next, occurrence+1
+		if !dueAt.Before(now) {
+			break
+		}
+	}
+
+	return &Task{
+		ID:          id,
+		WorkspaceID: t.WorkspaceID,
+		ProjectID:   t.ProjectID,
+		ParentID:    t.ParentID,
+		Title:       t.Title,
+		Status:      StatusTodo,
+		Priority:    t.Priority,
+		DueAt:       &dueAt,
+		Recurrence:  t.Recurrence,
+		SeriesID:    t.SeriesID,
+		Occurrence:  occurrence,
+		Assignees:   slices.Clone(t.Assignees),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Clone returns a copy of t that shares no mutable state with it.
+func (t *Task) Clone() *Task {
+	c := *t
+	c.Assignees = slices.Clone(t.Assignees)
+	c.Labels = slices.Clone(t.Labels)
+	return &c
+}
+
+func (t *Task) IsOpen() bool {
+	return t.Status == StatusTodo || t.Status == StatusInProgress || t.Status == StatusBlocked
+}
+
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.IsOpen() && t.DueAt != nil && t.DueAt.Before(now)
+}
+
+func (t *Task) Complete(now time.Time) error {
+	return t.TransitionTo(DefaultWorkflow, StatusComplete, now)
+}
+
+// TransitionTo moves the task to status if w allows it.
+func (t *Task) TransitionTo(w Workflow, status Status, now time.Time) error {
+	if !status.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+	if !w.CanTransition(t.Status, status) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, t.Status, status)
+	}
+	t.Status = status
 	t.UpdatedAt = now
+	return nil
+}
+
+func validateTitle(title string) error {
+	if title == "" {
+		return ErrInvalidTitle
+	}
+	if strings.TrimSpace(title) == "" {
+		return ErrTitleBlank
+	}
+	if len(title) > 200 {
+		return ErrTitleTooLong
+	}
+	return nil
 }
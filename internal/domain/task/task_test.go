@@ -32,6 +32,8 @@ var _ = Describe("Task Domain", func() {
 				Expect(newTask.ID).To(Equal(taskID))
 				Expect(newTask.Title).To(Equal(title))
 				Expect(newTask.Status).To(Equal(task.StatusTodo))
+				Expect(newTask.Priority).To(Equal(task.PriorityMedium))
+				Expect(newTask.DueAt).To(BeNil())
 				Expect(newTask.CreatedAt).To(Equal(createdAt))
 				Expect(newTask.UpdatedAt).To(Equal(updatedAt))
 			})
@@ -112,6 +114,19 @@ var _ = Describe("Task Domain", func() {
 			})
 		})
 
+		Context("when task is cancelled", func() {
+			It("should return ErrInvalidTransition", func() {
+				createdAt := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+				testTask, _ := task.New("task-1", "Test Task", createdAt, createdAt)
+				testTask.Status = task.StatusCancelled
+
+				err := testTask.Complete(createdAt.Add(time.Minute))
+
+				Expect(err).To(MatchError(task.ErrInvalidTransition))
+				Expect(testTask.Status).To(Equal(task.StatusCancelled))
+			})
+		})
+
 		Context("when task is already complete", func() {
 			It("should update the timestamp even if already complete", func() {
 				createdAt := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
@@ -150,7 +165,230 @@ var _ = Describe("Task Domain", func() {
 	Describe("Status Constants", func() {
 		It("should have correct status values", func() {
 			Expect(task.StatusTodo).To(Equal(task.Status("todo")))
+			Expect(task.StatusInProgress).To(Equal(task.Status("in_progress")))
+			Expect(task.StatusBlocked).To(Equal(task.Status("blocked")))
 			Expect(task.StatusComplete).To(Equal(task.Status("complete")))
+			Expect(task.StatusCancelled).To(Equal(task.Status("cancelled")))
+		})
+	})
+
+	Describe("ParseStatus", func() {
+		It("should accept known statuses", func() {
+			status, err := task.ParseStatus("in_progress")
+
+			Expect(err).To(BeNil())
+			Expect(status).To(Equal(task.StatusInProgress))
+		})
+
+		It("should reject unknown statuses", func() {
+			_, err := task.ParseStatus("done")
+
+			Expect(err).To(MatchError(task.ErrInvalidStatus))
+		})
+	})
+
+	Describe("TransitionTo", func() {
+		var (
+			createdAt time.Time
+			testTask  *task.Task
+		)
+
+		BeforeEach(func() {
+			createdAt = time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+			testTask, _ = task.New("task-1", "Test Task", createdAt, createdAt)
+		})
+
+		moveTo := func(statuses ...task.Status) {
+			for _, status := range statuses {
+				Expect(testTask.TransitionTo(task.DefaultWorkflow, status, createdAt)).To(Succeed())
+			}
+		}
+
+		DescribeTable("allowed transitions in the default workflow",
+			func(path []task.Status, to task.Status) {
+				moveTo(path...)
+				transitionedAt := createdAt.Add(time.Minute)
+
+				err := testTask.TransitionTo(task.DefaultWorkflow, to, transitionedAt)
+
+				Expect(err).To(BeNil())
+				Expect(testTask.Status).To(Equal(to))
+				Expect(testTask.UpdatedAt).To(Equal(transitionedAt))
+			},
+			Entry("todo → in_progress", []task.Status{}, task.StatusInProgress),
+			Entry("in_progress → blocked", []task.Status{task.StatusInProgress}, task.StatusBlocked),
+			Entry("blocked → in_progress", []task.Status{task.StatusBlocked}, task.StatusInProgress),
+			Entry("in_progress → complete", []task.Status{task.StatusInProgress}, task.StatusComplete),
+			Entry("complete → todo", []task.Status{task.StatusComplete}, task.StatusTodo),
+			Entry("cancelled → todo", []task.Status{task.StatusCancelled}, task.StatusTodo),
+			Entry("blocked → blocked", []task.Status{task.StatusBlocked}, task.StatusBlocked),
+		)
+
+		DescribeTable("forbidden transitions in the default workflow",
+			func(path []task.Status, to task.Status) {
+				moveTo(path...)
+				from := testTask.Status
+
+				err := testTask.TransitionTo(task.DefaultWorkflow, to, createdAt.Add(time.Minute))
+
+				Expect(err).To(MatchError(task.ErrInvalidTransition))
+				Expect(testTask.Status).To(Equal(from))
+				Expect(testTask.UpdatedAt).To(Equal(createdAt))
+			},
+			Entry("complete → blocked", []task.Status{task.StatusComplete}, task.StatusBlocked),
+			Entry("complete → in_progress", []task.Status{task.StatusComplete}, task.StatusInProgress),
+			Entry("cancelled → complete", []task.Status{task.StatusCancelled}, task.StatusComplete),
+			Entry("blocked → complete", []task.Status{task.StatusBlocked}, task.StatusComplete),
+		)
+
+		It("should reject unknown statuses", func() {
+			err := testTask.TransitionTo(task.DefaultWorkflow, task.Status("done"), createdAt)
+
+			Expect(err).To(MatchError(task.ErrInvalidStatus))
+		})
+
+		It("should follow a custom workflow", func() {
+			strict := task.MustNewWorkflow(map[task.Status][]task.Status{
+				task.StatusTodo: {task.StatusInProgress},
+			})
+
+			Expect(testTask.TransitionTo(strict, task.StatusComplete, createdAt)).To(MatchError(task.ErrInvalidTransition))
+			Expect(testTask.TransitionTo(strict, task.StatusInProgress, createdAt)).To(Succeed())
+		})
+	})
+
+	Describe("Rename", func() {
+		It("should validate the new title", func() {
+			createdAt := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+			testTask, _ := task.New("task-1", "Test Task", createdAt, createdAt)
+
+			Expect(testTask.Rename("   ", createdAt.Add(time.Minute))).To(MatchError(task.ErrTitleBlank))
+			Expect(testTask.Title).To(Equal("Test Task"))
+
+			Expect(testTask.Rename("Renamed", createdAt.Add(time.Minute))).To(Succeed())
+			Expect(testTask.Title).To(Equal("Renamed"))
+			Expect(testTask.UpdatedAt).To(Equal(createdAt.Add(time.Minute)))
+		})
+	})
+
+	Describe("SetPriority", func() {
+		It("should accept known priorities", func() {
+			createdAt := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+			testTask, _ := task.New("task-1", "Test Task", createdAt, createdAt)
+
+			Expect(testTask.SetPriority(task.PriorityUrgent, createdAt)).To(Succeed())
+			Expect(testTask.Priority).To(Equal(task.PriorityUrgent))
+		})
+
+		It("should reject unknown priorities", func() {
+			createdAt := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+			testTask, _ := task.New("task-1", "Test Task", createdAt, createdAt)
+
+			Expect(testTask.SetPriority(task.Priority("critical"), createdAt)).To(MatchError(task.ErrInvalidPriority))
+			Expect(testTask.Priority).To(Equal(task.PriorityMedium))
+		})
+	})
+
+	Describe("SetDueAt", func() {
+		var (
+			createdAt time.Time
+			testTask  *task.Task
+		)
+
+		BeforeEach(func() {
+			createdAt = time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+			testTask, _ = task.New("task-1", "Test Task", createdAt, createdAt)
+		})
+
+		It("should schedule the task", func() {
+			dueAt := createdAt.Add(24 * time.Hour)
+
+			Expect(testTask.SetDueAt(&dueAt, createdAt)).To(Succeed())
+			Expect(testTask.DueAt).To(HaveValue(Equal(dueAt)))
+		})
+
+		It("should accept a due date equal to the creation time", func() {
+			Expect(testTask.SetDueAt(&createdAt, createdAt)).To(Succeed())
+		})
+
+		It("should reject a due date before creation", func() {
+			dueAt := createdAt.Add(-time.Second)
+
+			Expect(testTask.SetDueAt(&dueAt, createdAt)).To(MatchError(task.ErrDueBeforeCreation))
+			Expect(testTask.DueAt).To(BeNil())
+		})
+
+		It("should clear the due date", func() {
+			dueAt := createdAt.Add(time.Hour)
+			Expect(testTask.SetDueAt(&dueAt, createdAt)).To(Succeed())
+
+			Expect(testTask.SetDueAt(nil, createdAt)).To(Succeed())
+			Expect(testTask.DueAt).To(BeNil())
+		})
+	})
+
+	Describe("IsOverdue", func() {
+		var (
+			createdAt time.Time
+			dueAt     time.Time
+			testTask  *task.Task
+		)
+
+		BeforeEach(func() {
+			createdAt = time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+			dueAt = createdAt.Add(time.Hour)
+			testTask, _ = task.New("task-1", "Test Task", createdAt, createdAt)
+		})
+
+		It("should be false without a due date", func() {
+			Expect(testTask.IsOverdue(createdAt.Add(48 * time.Hour))).To(BeFalse())
+		})
+
+		It("should be true for open tasks past their due date", func() {
+			Expect(testTask.SetDueAt(&dueAt, createdAt)).To(Succeed())
+
+			Expect(testTask.IsOverdue(dueAt.Add(-time.Second))).To(BeFalse())
+			Expect(testTask.IsOverdue(dueAt.Add(time.Second))).To(BeTrue())
+		})
+
+		It("should be false for finished tasks", func() {
+			Expect(testTask.SetDueAt(&dueAt, createdAt)).To(Succeed())
+			Expect(testTask.Complete(createdAt)).To(Succeed())
+
+			Expect(testTask.IsOverdue(dueAt.Add(time.Hour))).To(BeFalse())
+		})
+	})
+
+	Describe("ParsePriority", func() {
+		It("should reject unknown priorities", func() {
+			_, err := task.ParsePriority("critical")
+
+			Expect(err).To(MatchError(task.ErrInvalidPriority))
+		})
+	})
+
+	Describe("ParseSortOrder", func() {
+		It("should accept due_at", func() {
+			order, err := task.ParseSortOrder("due_at")
+
+			Expect(err).To(BeNil())
+			Expect(order).To(Equal(task.SortByDueAt))
+		})
+
+		It("should reject unknown orders", func() {
+			_, err := task.ParseSortOrder("title")
+
+			Expect(err).To(MatchError(task.ErrInvalidSort))
+		})
+	})
+
+	Describe("NewWorkflow", func() {
+		It("should reject unknown statuses in the transition table", func() {
+			_, err := task.NewWorkflow(map[task.Status][]task.Status{
+				task.StatusTodo: {task.Status("done")},
+			})
+
+			Expect(err).To(MatchError(task.ErrInvalidStatus))
 		})
 	})
 
@@ -160,6 +398,8 @@ var _ = Describe("Task Domain", func() {
 			Expect(task.ErrInvalidTitle).To(MatchError(task.ErrInvalidTitle))
 			Expect(task.ErrTitleBlank).To(MatchError(task.ErrTitleBlank))
 			Expect(task.ErrTitleTooLong).To(MatchError(task.ErrTitleTooLong))
+			Expect(task.ErrInvalidStatus).To(MatchError(task.ErrInvalidStatus))
+			Expect(task.ErrInvalidTransition).To(MatchError(task.ErrInvalidTransition))
 		})
 	})
 })
@@ -0,0 +1,534 @@
+// Package tasktest provides a conformance suite that every task.Repository
+// implementation runs, so that the storage adapters behave alike.
+package tasktest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Factory returns the repository under test for one spec. It may register
+// cleanup with DeferCleanup, or Skip when its backend is unavailable.
+type Factory func() domain.Repository
+
+// DescribeRepository declares the contract specs of task.Repository for the
+// repositories newRepository returns. Call it from a container node of the
+// adapter's suite.
+//
+// Every spec works in workspaces and on tasks with fresh UUIDs, so the
+// repositories may share a database that is never emptied. Timestamps are
+// whole microseconds, the precision of Postgres.
+func DescribeRepository(newRepository Factory) {
+	base := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var (
+		ctx   context.Context
+		other context.Context
+		repo  domain.Repository
+	)
+
+	newTask := func(createdAt time.Time) *domain.Task {
+		t, err := domain.New(uuid.NewString(), "Write report", createdAt, createdAt)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	create := func(createdAt time.Time) *domain.Task {
+		t := newTask(createdAt)
+		Expect(repo.Create(ctx, t)).To(Succeed())
+		return t
+	}
+
+	createChild := func(parent *domain.Task, createdAt time.Time) *domain.Task {
+		t := newTask(createdAt)
+		t.ParentID = &parent.ID
+		Expect(repo.Create(ctx, t)).To(Succeed())
+		return t
+	}
+
+	ids := func(tasks []*domain.Task) []string {
+		ids := make([]string, len(tasks))
+		for i, t := range tasks {
+			ids[i] = t.ID
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), uuid.NewString())
+		other = workspace.WithID(context.Background(), uuid.NewString())
+		repo = newRepository()
+	})
+
+	It("requires a workspace", func() {
+		none := context.Background()
+		t := newTask(base)
+
+		_, err := repo.FindAll(none, domain.ListFilter{})
+		Expect(err).To(MatchError(workspace.ErrWorkspaceRequired))
+		_, err = repo.FindByID(none, t.ID)
+		Expect(err).To(MatchError(workspace.ErrWorkspaceRequired))
+		_, err = repo.FindTree(none, t.ID)
+		Expect(err).To(MatchError(workspace.ErrWorkspaceRequired))
+		_, err = repo.FindDeleted(none)
+		Expect(err).To(MatchError(workspace.ErrWorkspaceRequired))
+		_, err = repo.FindDueWithin(none, base, time.Hour)
+		Expect(err).To(MatchError(workspace.ErrWorkspaceRequired))
+		Expect(repo.Create(none, t)).To(MatchError(workspace.ErrWorkspaceRequired))
+		Expect(repo.Update(none, t)).To(MatchError(workspace.ErrWorkspaceRequired))
+		Expect(repo.Delete(none, t.ID, base)).To(MatchError(workspace.ErrWorkspaceRequired))
+		Expect(repo.Restore(none, t.ID)).To(MatchError(workspace.ErrWorkspaceRequired))
+		Expect(repo.AddAssignee(none, t.ID, "alice")).To(MatchError(workspace.ErrWorkspaceRequired))
+		Expect(repo.RemoveAssignee(none, t.ID, "alice")).To(MatchError(workspace.ErrWorkspaceRequired))
+	})
+
+	It("stores nothing without a workspace", func() {
+		t := newTask(base)
+
+		Expect(repo.Create(context.Background(), t)).NotTo(Succeed())
+
+		Expect(t.WorkspaceID).To(BeEmpty())
+		_, err := repo.FindByID(ctx, t.ID)
+		Expect(err).To(Equal(domain.ErrTaskNotFound))
+	})
+
+	Describe("Create and FindByID", func() {
+		It("round-trips every persisted field", func() {
+			dueAt := base.Add(90*time.Minute + 123*time.Microsecond).In(time.FixedZone("UTC+9", 9*60*60))
+			recurrence, err := domain.ParseRecurrence("FREQ=WEEKLY;INTERVAL=2")
+			Expect(err).NotTo(HaveOccurred())
+			t := newTask(base.Add(456 * time.Microsecond))
+			t.Status = domain.StatusInProgress
+			t.Priority = domain.PriorityUrgent
+			t.DueAt = &dueAt
+			t.Recurrence = recurrence
+			t.SeriesID = &t.ID
+			t.Occurrence = 1
+			t.UpdatedAt = base.Add(time.Hour)
+			t.Assignees = []string{"bob", "alice"}
+
+			Expect(repo.Create(ctx, t)).To(Succeed())
+			found, err := repo.FindByID(ctx, t.ID)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.ID).To(Equal(t.ID))
+			Expect(found.WorkspaceID).To(Equal(t.WorkspaceID))
+			Expect(found.ProjectID).To(BeNil())
+			Expect(found.ParentID).To(BeNil())
+			Expect(found.Title).To(Equal("Write report"))
+			Expect(found.Status).To(Equal(domain.StatusInProgress))
+			Expect(found.Priority).To(Equal(domain.PriorityUrgent))
+			Expect(*found.DueAt).To(BeTemporally("==", dueAt))
+			Expect(found.Recurrence.String()).To(Equal(recurrence.String()))
+			Expect(*found.SeriesID).To(Equal(t.ID))
+			Expect(found.Occurrence).To(Equal(1))
+			Expect(found.Assignees).To(Equal([]string{"alice", "bob"}))
+			Expect(found.Labels).To(BeEmpty())
+			Expect(found.CommentCount).To(BeZero())
+			Expect(found.CreatedAt).To(BeTemporally("==", base.Add(456*time.Microsecond)))
+			Expect(found.UpdatedAt).To(BeTemporally("==", base.Add(time.Hour)))
+			Expect(found.DeletedAt).To(BeNil())
+		})
+
+		It("stamps the task with the workspace of ctx", func() {
+			workspaceID, _ := workspace.IDFromContext(ctx)
+			t := newTask(base)
+			t.WorkspaceID = uuid.NewString()
+
+			Expect(repo.Create(ctx, t)).To(Succeed())
+
+			Expect(t.WorkspaceID).To(Equal(workspaceID))
+			found, err := repo.FindByID(ctx, t.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.WorkspaceID).To(Equal(workspaceID))
+		})
+
+		It("returns no assignees as an empty list", func() {
+			t := create(base)
+
+			found, err := repo.FindByID(ctx, t.ID)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Assignees).To(BeEmpty())
+		})
+
+		It("reports a missing task", func() {
+			_, err := repo.FindByID(ctx, uuid.NewString())
+
+			Expect(err).To(Equal(domain.ErrTaskNotFound))
+		})
+
+		It("hides tasks of other workspaces", func() {
+			t := create(base)
+
+			_, err := repo.FindByID(other, t.ID)
+
+			Expect(err).To(Equal(domain.ErrTaskNotFound))
+		})
+
+		It("counts the live subtasks that are not cancelled", func() {
+			parent := create(base)
+			for _, status := range []domain.Status{domain.StatusComplete, domain.StatusTodo, domain.StatusCancelled} {
+				child := newTask(base)
+				child.ParentID = &parent.ID
+				child.Status = status
+				Expect(repo.Create(ctx, child)).To(Succeed())
+			}
+			deleted := createChild(parent, base)
+			Expect(repo.Delete(ctx, deleted.ID, base)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, parent.ID)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Subtasks).To(Equal(domain.Progress{Total: 2, Completed: 1}))
+		})
+	})
+
+	Describe("Update", func() {
+		It("saves the changed fields", func() {
+			t := create(base)
+			dueAt := base.Add(24 * time.Hour)
+			Expect(t.Rename("Write summary", base.Add(time.Minute))).To(Succeed())
+			t.Priority = domain.PriorityLow
+			t.DueAt = &dueAt
+			t.Status = domain.StatusBlocked
+
+			Expect(repo.Update(ctx, t)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, t.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Title).To(Equal("Write summary"))
+			Expect(found.Priority).To(Equal(domain.PriorityLow))
+			Expect(found.Status).To(Equal(domain.StatusBlocked))
+			Expect(*found.DueAt).To(BeTemporally("==", dueAt))
+			Expect(found.CreatedAt).To(BeTemporally("==", base))
+			Expect(found.UpdatedAt).To(BeTemporally("==", base.Add(time.Minute)))
+		})
+
+		It("clears optional fields", func() {
+			dueAt := base.Add(time.Hour)
+			t := newTask(base)
+			t.DueAt = &dueAt
+			Expect(repo.Create(ctx, t)).To(Succeed())
+			t.DueAt = nil
+
+			Expect(repo.Update(ctx, t)).To(Succeed())
+
+			found, err := repo.FindByID(ctx, t.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.DueAt).To(BeNil())
+		})
+
+		It("reports a missing task", func() {
+			Expect(repo.Update(ctx, newTask(base))).To(Equal(domain.ErrTaskNotFound))
+		})
+
+		It("leaves tasks of other workspaces alone", func() {
+			t := create(base)
+			t.Title = "Hijacked"
+
+			Expect(repo.Update(other, t)).To(Equal(domain.ErrTaskNotFound))
+
+			found, err := repo.FindByID(ctx, t.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Title).To(Equal("Write report"))
+		})
+	})
+
+	Describe("FindAll", func() {
+		var first, second, third *domain.Task
+
+		BeforeEach(func() {
+			later, earlier := base.Add(time.Hour), base.Add(-time.Hour)
+			first = newTask(base)
+			second = newTask(base.Add(time.Minute))
+			second.DueAt = &later
+			second.Assignees = []string{"alice"}
+			third = newTask(base.Add(2 * time.Minute))
+			third.DueAt = &earlier
+			third.Priority = domain.PriorityHigh
+			for _, t := range []*domain.Task{third, first, second} {
+				Expect(repo.Create(ctx, t)).To(Succeed())
+			}
+		})
+
+		It("orders by creation by default", func() {
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{first.ID, second.ID, third.ID}))
+		})
+
+		It("orders by due date with undated tasks last", func() {
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{SortBy: domain.SortByDueAt})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{third.ID, second.ID, first.ID}))
+		})
+
+		It("returns an empty list for an empty workspace", func() {
+			tasks, err := repo.FindAll(other, domain.ListFilter{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).NotTo(BeNil())
+			Expect(tasks).To(BeEmpty())
+		})
+
+		It("keeps the tasks with the given IDs", func() {
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{IDs: []string{third.ID, first.ID, uuid.NewString()}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{first.ID, third.ID}))
+
+			none, err := repo.FindAll(ctx, domain.ListFilter{IDs: []string{}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(none).To(BeEmpty())
+		})
+
+		It("keeps tasks of the priority", func() {
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{Priority: domain.PriorityHigh})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{third.ID}))
+		})
+
+		It("keeps open tasks due before OverdueAt", func() {
+			overdue, err := repo.FindAll(ctx, domain.ListFilter{OverdueAt: base})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(overdue)).To(Equal([]string{third.ID}))
+
+			Expect(third.Complete(base)).To(Succeed())
+			Expect(repo.Update(ctx, third)).To(Succeed())
+			overdue, err = repo.FindAll(ctx, domain.ListFilter{OverdueAt: base})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overdue).To(BeEmpty())
+		})
+
+		It("keeps tasks assigned to the user", func() {
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{Assignee: "alice"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{second.ID}))
+		})
+
+		It("keeps the occurrences of the series", func() {
+			rule, err := domain.ParseRecurrence("FREQ=DAILY")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.SetRecurrence(rule, base)).To(Succeed())
+			Expect(repo.Update(ctx, second)).To(Succeed())
+			next := second.NextOccurrence(uuid.NewString(), base)
+			Expect(repo.Create(ctx, next)).To(Succeed())
+
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{SeriesID: second.ID})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(ConsistOf(second.ID, next.ID))
+		})
+
+		It("keeps the later occurrences of the series", func() {
+			rule, err := domain.ParseRecurrence("FREQ=DAILY")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.SetRecurrence(rule, base)).To(Succeed())
+			Expect(repo.Update(ctx, second)).To(Succeed())
+			next := second.NextOccurrence(uuid.NewString(), base)
+			Expect(repo.Create(ctx, next)).To(Succeed())
+
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{SeriesID: second.ID, MinOccurrence: second.Occurrence + 1})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{next.ID}))
+		})
+
+		It("skips deleted tasks and tasks of other workspaces", func() {
+			Expect(repo.Delete(ctx, second.ID, base)).To(Succeed())
+			Expect(repo.Create(other, newTask(base))).To(Succeed())
+
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{first.ID, third.ID}))
+		})
+	})
+
+	Describe("FindTree", func() {
+		It("returns the task and its live descendants, parents first", func() {
+			root := create(base)
+			later := createChild(root, base.Add(2*time.Minute))
+			earlier := createChild(root, base.Add(time.Minute))
+			grandchild := createChild(earlier, base)
+			deleted := createChild(root, base)
+			Expect(repo.Delete(ctx, deleted.ID, base)).To(Succeed())
+
+			tree, err := repo.FindTree(ctx, root.ID)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tree)).To(Equal([]string{root.ID, earlier.ID, later.ID, grandchild.ID}))
+		})
+
+		It("reports a missing or deleted task", func() {
+			_, err := repo.FindTree(ctx, uuid.NewString())
+			Expect(err).To(Equal(domain.ErrTaskNotFound))
+
+			t := create(base)
+			Expect(repo.Delete(ctx, t.ID, base)).To(Succeed())
+			_, err = repo.FindTree(ctx, t.ID)
+			Expect(err).To(Equal(domain.ErrTaskNotFound))
+		})
+	})
+
+	Describe("Delete, FindDeleted and Restore", func() {
+		var parent, child *domain.Task
+
+		BeforeEach(func() {
+			parent = create(base)
+			child = createChild(parent, base)
+		})
+
+		It("moves the task and its subtasks to the trash", func() {
+			Expect(repo.Delete(ctx, parent.ID, base.Add(time.Hour))).To(Succeed())
+
+			_, err := repo.FindByID(ctx, child.ID)
+			Expect(err).To(Equal(domain.ErrTaskNotFound))
+			trash, err := repo.FindDeleted(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(trash).To(HaveLen(2))
+			for _, t := range trash {
+				Expect(*t.DeletedAt).To(BeTemporally("==", base.Add(time.Hour)))
+			}
+		})
+
+		It("lists the most recently deleted tasks first", func() {
+			sibling := create(base)
+			Expect(repo.Delete(ctx, child.ID, base.Add(time.Minute))).To(Succeed())
+			Expect(repo.Delete(ctx, sibling.ID, base.Add(time.Hour))).To(Succeed())
+
+			trash, err := repo.FindDeleted(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(trash)).To(Equal([]string{sibling.ID, child.ID}))
+			empty, err := repo.FindDeleted(other)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(empty).To(BeEmpty())
+		})
+
+		It("reports deleting a missing or already deleted task", func() {
+			Expect(repo.Delete(ctx, uuid.NewString(), base)).To(Equal(domain.ErrTaskNotFound))
+			Expect(repo.Delete(other, parent.ID, base)).To(Equal(domain.ErrTaskNotFound))
+
+			Expect(repo.Delete(ctx, parent.ID, base)).To(Succeed())
+			Expect(repo.Delete(ctx, parent.ID, base)).To(Equal(domain.ErrTaskNotFound))
+		})
+
+		It("restores the subtasks deleted along with the task, but not those deleted before", func() {
+			earlier := createChild(parent, base)
+			Expect(repo.Delete(ctx, earlier.ID, base.Add(time.Minute))).To(Succeed())
+			Expect(repo.Delete(ctx, parent.ID, base.Add(time.Hour))).To(Succeed())
+
+			Expect(repo.Restore(ctx, parent.ID)).To(Succeed())
+
+			tree, err := repo.FindTree(ctx, parent.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tree)).To(Equal([]string{parent.ID, child.ID}))
+			trash, err := repo.FindDeleted(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(trash)).To(Equal([]string{earlier.ID}))
+		})
+
+		It("refuses to restore a subtask while its parent is deleted", func() {
+			Expect(repo.Delete(ctx, parent.ID, base)).To(Succeed())
+
+			Expect(repo.Restore(ctx, child.ID)).To(Equal(domain.ErrParentDeleted))
+		})
+
+		It("reports restoring a task that is not in the trash", func() {
+			Expect(repo.Restore(ctx, parent.ID)).To(Equal(domain.ErrTaskNotFound))
+			Expect(repo.Restore(ctx, uuid.NewString())).To(Equal(domain.ErrTaskNotFound))
+
+			Expect(repo.Delete(ctx, parent.ID, base)).To(Succeed())
+			Expect(repo.Restore(other, parent.ID)).To(Equal(domain.ErrTaskNotFound))
+		})
+	})
+
+	Describe("AddAssignee and RemoveAssignee", func() {
+		It("keeps the assignees sorted and ignores repeated assignments", func() {
+			t := create(base)
+
+			Expect(repo.AddAssignee(ctx, t.ID, "carol")).To(Succeed())
+			Expect(repo.AddAssignee(ctx, t.ID, "alice")).To(Succeed())
+			Expect(repo.AddAssignee(ctx, t.ID, "alice")).To(Succeed())
+
+			found, err := repo.FindByID(ctx, t.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Assignees).To(Equal([]string{"alice", "carol"}))
+		})
+
+		It("removes an assignee and reports users who are not assigned", func() {
+			t := newTask(base)
+			t.Assignees = []string{"alice"}
+			Expect(repo.Create(ctx, t)).To(Succeed())
+
+			Expect(repo.RemoveAssignee(ctx, t.ID, "alice")).To(Succeed())
+			Expect(repo.RemoveAssignee(ctx, t.ID, "alice")).To(Equal(domain.ErrAssigneeNotFound))
+
+			found, err := repo.FindByID(ctx, t.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Assignees).To(BeEmpty())
+		})
+	})
+
+	Describe("FindDueWithin", func() {
+		It("returns the open tasks due in the window, earliest first", func() {
+			due := func(offset time.Duration, status domain.Status) *domain.Task {
+				dueAt := base.Add(offset)
+				t := newTask(base)
+				t.DueAt = &dueAt
+				t.Status = status
+				Expect(repo.Create(ctx, t)).To(Succeed())
+				return t
+			}
+			atStart := due(0, domain.StatusTodo)
+			inside := due(-time.Minute+time.Hour, domain.StatusInProgress)
+			due(time.Hour, domain.StatusTodo)
+			due(-time.Minute, domain.StatusTodo)
+			due(time.Minute, domain.StatusComplete)
+			deleted := due(time.Minute, domain.StatusTodo)
+			Expect(repo.Delete(ctx, deleted.ID, base)).To(Succeed())
+
+			tasks, err := repo.FindDueWithin(ctx, base, time.Hour)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(Equal([]string{atStart.ID, inside.ID}))
+		})
+	})
+
+	It("is safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				t := newTask(base.Add(time.Duration(i) * time.Second))
+				Expect(repo.Create(ctx, t)).To(Succeed())
+				Expect(t.Rename("Renamed", base.Add(time.Hour))).To(Succeed())
+				Expect(repo.Update(ctx, t)).To(Succeed())
+				_, err := repo.FindAll(ctx, domain.ListFilter{})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		tasks, err := repo.FindAll(ctx, domain.ListFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(10))
+		for _, t := range tasks {
+			Expect(t.Title).To(Equal("Renamed"))
+		}
+	})
+}
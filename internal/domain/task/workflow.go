@@ -0,0 +1,55 @@
+package task
+
+import "fmt"
+
+// Workflow is the state machine that governs which status changes a task may
+// go through. Staying in the current status is always allowed; it is no
+// change, so the usecases neither save nor audit it.
+type Workflow struct {
+	transitions map[Status]map[Status]struct{}
+}
+
+// DefaultWorkflow allows work to move freely between the open statuses, lets
+// finished (complete or cancelled) tasks be reopened to todo, and forbids
+// everything else, e.g. complete → blocked or cancelled → complete.
+var DefaultWorkflow = MustNewWorkflow(map[Status][]Status{
+	StatusTodo:       {StatusInProgress, StatusBlocked, StatusComplete, StatusCancelled},
+	StatusInProgress: {StatusTodo, StatusBlocked, StatusComplete, StatusCancelled},
+	StatusBlocked:    {StatusTodo, StatusInProgress, StatusCancelled},
+	StatusComplete:   {StatusTodo},
+	StatusCancelled:  {StatusTodo},
+})
+
+func NewWorkflow(table map[Status][]Status) (Workflow, error) {
+	w := Workflow{transitions: make(map[Status]map[Status]struct{}, len(table))}
+	for from, targets := range table {
+		if !from.Valid() {
+			return Workflow{}, fmt.Errorf("%w: %q", ErrInvalidStatus, from)
+		}
+		allowed := make(map[Status]struct{}, len(targets))
+		for _, to := range targets {
+			if !to.Valid() {
+				return Workflow{}, fmt.Errorf("%w: %q", ErrInvalidStatus, to)
+			}
+			allowed[to] = struct{}{}
+		}
+		w.transitions[from] = allowed
+	}
+	return w, nil
+}
+
+func MustNewWorkflow(table map[Status][]Status) Workflow {
+	w, err := NewWorkflow(table)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func (w Workflow) CanTransition(from Status, to Status) bool {
+	if from == to {
+		return true
+	}
+	_, ok := w.transitions[from][to]
+	return ok
+}
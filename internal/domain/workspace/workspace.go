@@ -0,0 +1,22 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrWorkspaceRequired = errors.New("workspace is required")
+
+type idKey struct{}
+
+// WithID scopes ctx to a single workspace (tenant). Repositories read it back
+// with IDFromContext and must never return data belonging to another
+// workspace.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+func IDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(idKey{}).(string)
+	return id, ok && id != ""
+}
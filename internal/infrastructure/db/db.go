@@ -3,24 +3,193 @@ package db
 import (
 	"context"
 	"fmt"
+	"log"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func New(dsn string) (*pgxpool.Pool, error) {
-	pool, err := pgxpool.New(context.Background(), dsn)
+// Pools are the connection pools of the primary database and, when one is
+// configured, of a read replica.
+type Pools struct {
+	Primary *pgxpool.Pool
+	// Replica is nil without a replica DSN.
+	Replica *pgxpool.Pool
+
+	replicaHealthy atomic.Bool
+}
+
+// Options tune the pools opened by New. Zero values keep the pgx defaults.
+type Options struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	// StatementTimeout makes Postgres cancel statements that run longer.
+	StatementTimeout time.Duration
+	ConnectTimeout   time.Duration
+	ApplicationName  string
+	// StartupTimeout is how long New keeps retrying, with backoff, until the
+	// primary answers; 0 tries once.
+	StartupTimeout time.Duration
+}
+
+// startupBackoff bounds the wait between two connection attempts of New.
+const (
+	startupBackoffMin = 500 * time.Millisecond
+	startupBackoffMax = 5 * time.Second
+)
+
+// New connects to the primary at dsn and, unless replicaDSN is empty, to a
+// read replica. The primary must become reachable within
+// opts.StartupTimeout; a replica that is not only starts out unhealthy, see
+// WatchReplica.
+func New(dsn string, replicaDSN string, opts Options) (*Pools, error) {
+	primary, err := connect(dsn, opts)
+	if err != nil {
+		return nil, err
+	}
+	pools := &Pools{Primary: primary}
+	if replicaDSN == "" {
+		return pools, nil
+	}
+
+	replicaConfig, err := poolConfig(replicaDSN, opts)
+	if err != nil {
+		primary.Close()
+		return nil, fmt.Errorf("parse replica dsn: %w", err)
+	}
+	pools.Replica, err = pgxpool.NewWithConfig(context.Background(), replicaConfig)
+	if err != nil {
+		primary.Close()
+		return nil, fmt.Errorf("open replica pool: %w", err)
+	}
+	if !pools.checkReplica(context.Background()) {
+		log.Printf("db: replica is unreachable, reading from the primary until it answers")
+	}
+	return pools, nil
+}
+
+func connect(dsn string, opts Options) (*pgxpool.Pool, error) {
+	config, err := poolConfig(dsn, opts)
+	if err != nil {
+		return nil, fmt.Errorf("parse dsn: %w", err)
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, fmt.Errorf("open db pool: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	deadline := time.Now().Add(opts.StartupTimeout)
+	backoff := startupBackoffMin
+	for {
+		err = ping(pool, opts.ConnectTimeout)
+		if err == nil {
+			return pool, nil
+		}
+		wait := min(backoff, time.Until(deadline))
+		if wait <= 0 {
+			pool.Close()
+			return nil, fmt.Errorf("ping db: %w", err)
+		}
+		log.Printf("db: database is not reachable yet, retrying in %s: %v", wait.Round(time.Millisecond), err)
+		time.Sleep(wait)
+		backoff = min(2*backoff, startupBackoffMax)
+	}
+}
+
+func ping(pool *pgxpool.Pool, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return pool.Ping(ctx)
+}
+
+// poolConfig parses dsn, which may be a URL or key=value pairs, and applies
+// opts on top of the settings it carries.
+func poolConfig(dsn string, opts Options) (*pgxpool.Config, error) {
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxConns > 0 {
+		config.MaxConns = opts.MaxConns
+	}
+	if opts.MinConns > 0 {
+		config.MinConns = opts.MinConns
+	}
+	if opts.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = opts.MaxConnLifetime
+	}
+	if opts.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = opts.MaxConnIdleTime
+	}
+	if opts.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = opts.HealthCheckPeriod
+	}
+	if opts.ConnectTimeout > 0 {
+		config.ConnConfig.ConnectTimeout = opts.ConnectTimeout
+	}
+	if opts.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(opts.StatementTimeout.Milliseconds(), 10)
+	}
+	// An application_name set in the DSN wins over the default.
+	if _, ok := config.ConnConfig.RuntimeParams["application_name"]; !ok && opts.ApplicationName != "" {
+		config.ConnConfig.RuntimeParams["application_name"] = opts.ApplicationName
+	}
+	return config, nil
+}
+
+// ReplicaHealthy reports whether the replica answered its last health check.
+// It is false without a replica.
+func (p *Pools) ReplicaHealthy() bool {
+	return p.replicaHealthy.Load()
+}
+
+// WatchReplica checks the replica every interval until ctx is done. It does
+// nothing without a replica.
+func (p *Pools) WatchReplica(ctx context.Context, interval time.Duration) {
+	if p.Replica == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkReplica(ctx)
+		}
+	}
+}
+
+func (p *Pools) checkReplica(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
-	if err := pool.Ping(ctx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("ping db: %w", err)
+	err := p.Replica.Ping(ctx)
+	healthy := err == nil
+	if p.replicaHealthy.Swap(healthy) != healthy {
+		if healthy {
+			log.Printf("db: replica is healthy, reading from it")
+		} else {
+			log.Printf("db: replica is unhealthy, reading from the primary: %v", err)
+		}
 	}
+	return healthy
+}
 
-	return pool, nil
+// Close closes both pools.
+func (p *Pools) Close() {
+	p.Primary.Close()
+	if p.Replica != nil {
+		p.Replica.Close()
+	}
 }
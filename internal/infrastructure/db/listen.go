@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// listenRetryDelay is how long Listen waits before it reconnects after
+// losing its connection.
+const listenRetryDelay = 5 * time.Second
+
+// Listen holds a connection LISTENing on channel and calls notify with the
+// payload of every notification until ctx is done. Notifications sent while
+// it was not listening are lost, so reset is called each time it starts
+// listening again after the connection was lost.
+func Listen(ctx context.Context, pool *pgxpool.Pool, channel string, notify func(payload string), reset func()) {
+	lost := false
+	listening := func() {
+		if lost {
+			reset()
+		}
+	}
+	for {
+		err := listen(ctx, pool, channel, listening, notify)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("db: listen %s: %v", channel, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+		lost = true
+	}
+}
+
+func listen(ctx context.Context, pool *pgxpool.Pool, channel string, listening func(), notify func(payload string)) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	// The session keeps listening until it ends, so the connection must not
+	// go back to the pool.
+	defer func() {
+		_ = conn.Conn().Close(context.Background())
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	listening()
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+		notify(notification.Payload)
+	}
+}
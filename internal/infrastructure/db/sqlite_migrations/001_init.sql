@@ -0,0 +1,123 @@
+-- SQLite schema matching the Postgres migrations up to 014_task_trash.sql.
+-- UUIDs are stored as text and timestamps as fixed-width UTC RFC 3339 text,
+-- which sorts chronologically. Row level security has no SQLite equivalent;
+-- the repositories filter every query by workspace_id.
+CREATE TABLE projects (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 100),
+    description TEXT NOT NULL DEFAULT '',
+    archived INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_projects_workspace_id ON projects(workspace_id);
+
+CREATE TABLE tasks (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    project_id TEXT REFERENCES projects(id) ON DELETE SET NULL,
+    parent_id TEXT REFERENCES tasks(id) ON DELETE CASCADE CHECK (parent_id <> id),
+    title TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('todo', 'in_progress', 'blocked', 'complete', 'cancelled')),
+    priority TEXT NOT NULL DEFAULT 'medium' CHECK (priority IN ('low', 'medium', 'high', 'urgent')),
+    due_at TEXT,
+    recurrence TEXT,
+    series_id TEXT,
+    occurrence INTEGER NOT NULL DEFAULT 0 CHECK (occurrence >= 0),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    deleted_at TEXT
+);
+
+CREATE INDEX idx_tasks_workspace_id ON tasks(workspace_id, created_at, id);
+CREATE INDEX idx_tasks_project_id ON tasks(project_id);
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id) WHERE parent_id IS NOT NULL;
+CREATE INDEX idx_tasks_open_due_at ON tasks(workspace_id, due_at)
+    WHERE due_at IS NOT NULL AND status IN ('todo', 'in_progress', 'blocked');
+CREATE INDEX idx_tasks_deleted_at ON tasks(workspace_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE TABLE task_assignees (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL CHECK (length(user_id) > 0),
+    workspace_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX idx_task_assignees_user_id ON task_assignees(user_id, task_id);
+
+CREATE TABLE task_dependencies (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocker_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    workspace_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, blocker_id),
+    CHECK (task_id <> blocker_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocker_id ON task_dependencies(blocker_id);
+
+CREATE TABLE labels (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 50),
+    color TEXT NOT NULL CHECK (color GLOB '#[0-9a-f][0-9a-f][0-9a-f][0-9a-f][0-9a-f][0-9a-f]'),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    UNIQUE (workspace_id, name)
+);
+
+CREATE TABLE task_labels (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label_id TEXT NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    workspace_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX idx_task_labels_label_id ON task_labels(label_id, task_id);
+
+CREATE TABLE comments (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author_id TEXT NOT NULL,
+    body TEXT NOT NULL CHECK (length(body) BETWEEN 1 AND 10000),
+    created_at TEXT NOT NULL,
+    edited_at TEXT
+);
+
+CREATE INDEX idx_comments_task_id_created_at ON comments(task_id, created_at, id);
+
+CREATE TABLE reminders_sent (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    workspace_id TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('due_soon', 'overdue')),
+    due_at TEXT NOT NULL,
+    PRIMARY KEY (task_id, kind, due_at)
+);
+
+-- Append-only like its Postgres counterpart; task_id has no foreign key so
+-- that records outlive their tasks.
+CREATE TABLE audit_log (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL,
+    task_id TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'complete', 'delete', 'restore')),
+    changes TEXT NOT NULL,
+    request_id TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_audit_log_task_id ON audit_log(task_id, created_at, id);
+CREATE INDEX idx_audit_log_created_at ON audit_log(workspace_id, created_at, id);
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
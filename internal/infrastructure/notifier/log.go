@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+)
+
+// LogNotifier writes reminders to the standard logger. It is the default for
+// local development.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, r reminder.Reminder) error {
+	log.Printf("reminder: %s", subject(r))
+	return nil
+}
+
+func subject(r reminder.Reminder) string {
+	due := r.DueAt.UTC().Format(time.RFC3339)
+	if r.Kind == reminder.KindOverdue {
+		return "Task \"" + r.Title + "\" is overdue since " + due
+	}
+	return "Task \"" + r.Title + "\" is due at " + due
+}
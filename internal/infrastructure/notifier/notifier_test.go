@@ -0,0 +1,155 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/notifier"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+)
+
+func TestNotifier(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Notifier Suite")
+}
+
+var testReminder = reminder.Reminder{
+	Kind:        reminder.KindOverdue,
+	TaskID:      "task-1",
+	WorkspaceID: "11111111-1111-1111-1111-111111111111",
+	Title:       "Ship release",
+	DueAt:       time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC),
+}
+
+var _ = Describe("WebhookNotifier", func() {
+	It("posts the reminder as JSON", func() {
+		var payload map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		n, err := notifier.NewWebhookNotifier(server.URL, server.Client())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(n.Notify(context.Background(), testReminder)).To(Succeed())
+		Expect(payload).To(HaveKeyWithValue("kind", "overdue"))
+		Expect(payload).To(HaveKeyWithValue("task_id", "task-1"))
+		Expect(payload).To(HaveKeyWithValue("due_at", "2030-01-01T09:00:00Z"))
+	})
+
+	It("fails on non-2xx responses", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		n, err := notifier.NewWebhookNotifier(server.URL, server.Client())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(n.Notify(context.Background(), testReminder)).To(MatchError(ContainSubstring("unexpected status 502")))
+	})
+})
+
+var _ = Describe("SMTPNotifier", func() {
+	It("mails the reminder to every recipient", func() {
+		addr, received := fakeSMTPServer()
+
+		n, err := notifier.NewSMTPNotifier(addr, "reminders@example.com", []string{"a@example.com", "b@example.com"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(n.Notify(context.Background(), testReminder)).To(Succeed())
+
+		var mail fakeMail
+		Eventually(received).Should(Receive(&mail))
+		Expect(mail.from).To(Equal("<reminders@example.com>"))
+		Expect(mail.to).To(ConsistOf("<a@example.com>", "<b@example.com>"))
+		Expect(mail.data).To(ContainSubstring(`Subject: Task "Ship release" is overdue since 2030-01-01T09:00:00Z`))
+	})
+
+	It("requires recipients", func() {
+		_, err := notifier.NewSMTPNotifier("localhost:1025", "reminders@example.com", nil)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+type fakeMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTPServer accepts a single unauthenticated SMTP session and reports
+// the delivered mail.
+func fakeSMTPServer() (string, <-chan fakeMail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(listener.Close)
+
+	received := make(chan fakeMail, 1)
+	go func() {
+		defer GinkgoRecover()
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var mail fakeMail
+		reply("220 fake ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 fake")
+			case strings.HasPrefix(line, "MAIL FROM:"):
+				mail.from = strings.TrimPrefix(line, "MAIL FROM:")
+				reply("250 OK")
+			case strings.HasPrefix(line, "RCPT TO:"):
+				mail.to = append(mail.to, strings.TrimPrefix(line, "RCPT TO:"))
+				reply("250 OK")
+			case line == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil || dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				mail.data = data.String()
+				received <- mail
+				reply("250 OK")
+			case line == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
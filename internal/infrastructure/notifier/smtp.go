@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+)
+
+// SMTPNotifier mails reminders without authentication, which suits a local
+// relay or a fake server such as Mailpit.
+type SMTPNotifier struct {
+	addr string
+	from string
+	to   []string
+}
+
+func NewSMTPNotifier(addr, from string, to []string) (*SMTPNotifier, error) {
+	if addr == "" || from == "" || len(to) == 0 {
+		return nil, fmt.Errorf("smtp notifier needs an address, a sender and at least one recipient")
+	}
+	return &SMTPNotifier{addr: addr, from: from, to: to}, nil
+}
+
+func (n *SMTPNotifier) Notify(_ context.Context, r reminder.Reminder) error {
+	subj := subject(r)
+	msg := strings.Join([]string{
+		"From: " + n.from,
+		"To: " + strings.Join(n.to, ", "),
+		"Subject: " + subj,
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		subj + ".",
+		"",
+		"Task: " + r.TaskID,
+		"Workspace: " + r.WorkspaceID,
+		"",
+	}, "\r\n")
+
+	if err := smtp.SendMail(n.addr, nil, n.from, n.to, []byte(msg)); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
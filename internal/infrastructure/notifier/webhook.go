@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+)
+
+// WebhookNotifier posts reminders as JSON to a URL. Any non-2xx response is
+// treated as a failed delivery.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+type webhookPayload struct {
+	Kind        string    `json:"kind"`
+	TaskID      string    `json:"task_id"`
+	WorkspaceID string    `json:"workspace_id"`
+	Title       string    `json:"title"`
+	DueAt       time.Time `json:"due_at"`
+}
+
+func NewWebhookNotifier(url string, client *http.Client) (*WebhookNotifier, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook notifier needs a url")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookNotifier{url: url, client: client}, nil
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, r reminder.Reminder) error {
+	body, err := json.Marshal(webhookPayload{
+		Kind:        string(r.Kind),
+		TaskID:      r.TaskID,
+		WorkspaceID: r.WorkspaceID,
+		Title:       r.Title,
+		DueAt:       r.DueAt,
+	})
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("post webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
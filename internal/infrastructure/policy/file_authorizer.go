@@ -0,0 +1,144 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+const wildcard = "*"
+
+// document is the on-disk policy format. YAML is a superset of JSON, so the
+// same decoder accepts both.
+type document struct {
+	Roles map[string][]string `yaml:"roles"`
+}
+
+type permissions map[string]map[string]struct{}
+
+// FileAuthorizer grants actions to principals based on a roles → permissions
+// policy file. A permission is either an exact action ("task:read"), a
+// resource wildcard ("task:*") or "*" for every action.
+type FileAuthorizer struct {
+	path string
+
+	mu      sync.RWMutex
+	roles   permissions
+	modTime time.Time
+}
+
+func NewFileAuthorizer(path string) (*FileAuthorizer, error) {
+	a := &FileAuthorizer{path: path}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *FileAuthorizer) Can(_ context.Context, principal authz.Principal, action authz.Action, _ authz.Resource) (bool, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	resource, _, _ := strings.Cut(string(action), ":")
+	for _, role := range principal.Roles {
+		granted, ok := a.roles[role]
+		if !ok {
+			continue
+		}
+		if _, ok := granted[string(action)]; ok {
+			return true, nil
+		}
+		if _, ok := granted[resource+":"+wildcard]; ok {
+			return true, nil
+		}
+		if _, ok := granted[wildcard]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Watch polls the policy file and reloads it whenever its modification time
+// changes, until ctx is cancelled. A policy that fails to load is logged and
+// the previous one stays in effect.
+func (a *FileAuthorizer) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(a.path)
+			if err != nil {
+				log.Printf("policy: stat %s: %v", a.path, err)
+				continue
+			}
+			a.mu.RLock()
+			changed := !info.ModTime().Equal(a.modTime)
+			a.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := a.reload(); err != nil {
+				log.Printf("policy: keeping previous policy: %v", err)
+				continue
+			}
+			log.Printf("policy: reloaded %s", a.path)
+		}
+	}
+}
+
+func (a *FileAuthorizer) reload() error {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return fmt.Errorf("stat policy file: %w", err)
+	}
+	data, err := os.ReadFile(a.path)
+	if err != nil {
+		return fmt.Errorf("read policy file: %w", err)
+	}
+	roles, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("parse policy file %s: %w", a.path, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.roles = roles
+	a.modTime = info.ModTime()
+	return nil
+}
+
+func parse(data []byte) (permissions, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Roles) == 0 {
+		return nil, fmt.Errorf("no roles defined")
+	}
+
+	roles := make(permissions, len(doc.Roles))
+	for role, actions := range doc.Roles {
+		granted := make(map[string]struct{}, len(actions))
+		for _, action := range actions {
+			action = strings.TrimSpace(action)
+			if action == "" {
+				return nil, fmt.Errorf("role %q: empty permission", role)
+			}
+			granted[action] = struct{}{}
+		}
+		roles[role] = granted
+	}
+	return roles, nil
+}
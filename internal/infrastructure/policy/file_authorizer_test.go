@@ -0,0 +1,147 @@
+package policy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/policy"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+func TestPolicy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Policy Suite")
+}
+
+const testPolicy = `
+roles:
+  viewer:
+    - task:read
+  editor:
+    - task:*
+  admin:
+    - "*"
+`
+
+var _ = Describe("FileAuthorizer", func() {
+	var (
+		ctx      context.Context
+		path     string
+		resource authz.Resource
+	)
+
+	writePolicy := func(content string) {
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+	}
+
+	can := func(a *policy.FileAuthorizer, roles []string, action authz.Action) bool {
+		allowed, err := a.Can(ctx, authz.Principal{ID: "user-1", Roles: roles}, action, resource)
+		Expect(err).NotTo(HaveOccurred())
+		return allowed
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		path = filepath.Join(GinkgoT().TempDir(), "policy.yaml")
+		resource = authz.Resource{Type: "task"}
+		writePolicy(testPolicy)
+	})
+
+	Describe("NewFileAuthorizer", func() {
+		It("should fail when the file does not exist", func() {
+			_, err := policy.NewFileAuthorizer(filepath.Join(GinkgoT().TempDir(), "missing.yaml"))
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail when no roles are defined", func() {
+			writePolicy("roles: {}")
+
+			_, err := policy.NewFileAuthorizer(path)
+
+			Expect(err).To(MatchError(ContainSubstring("no roles defined")))
+		})
+
+		It("should accept a JSON policy", func() {
+			writePolicy(`{"roles": {"viewer": ["task:read"]}}`)
+
+			a, err := policy.NewFileAuthorizer(path)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(can(a, []string{"viewer"}, authz.ActionTaskRead)).To(BeTrue())
+		})
+	})
+
+	Describe("Can", func() {
+		var a *policy.FileAuthorizer
+
+		BeforeEach(func() {
+			var err error
+			a, err = policy.NewFileAuthorizer(path)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should grant exact permissions", func() {
+			Expect(can(a, []string{"viewer"}, authz.ActionTaskRead)).To(BeTrue())
+			Expect(can(a, []string{"viewer"}, authz.ActionTaskCreate)).To(BeFalse())
+		})
+
+		It("should grant resource wildcards", func() {
+			Expect(can(a, []string{"editor"}, authz.ActionTaskCreate)).To(BeTrue())
+			Expect(can(a, []string{"editor"}, authz.Action("project:read"))).To(BeFalse())
+		})
+
+		It("should grant everything for the global wildcard", func() {
+			Expect(can(a, []string{"admin"}, authz.Action("project:delete"))).To(BeTrue())
+		})
+
+		It("should combine permissions across roles", func() {
+			Expect(can(a, []string{"unknown", "viewer"}, authz.ActionTaskRead)).To(BeTrue())
+		})
+
+		It("should deny principals without roles", func() {
+			Expect(can(a, nil, authz.ActionTaskRead)).To(BeFalse())
+		})
+	})
+
+	Describe("Watch", func() {
+		It("should reload the policy when the file changes", func() {
+			a, err := policy.NewFileAuthorizer(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			watchCtx, cancel := context.WithCancel(ctx)
+			DeferCleanup(cancel)
+			go a.Watch(watchCtx, 10*time.Millisecond)
+
+			writePolicy("roles:\n  viewer:\n    - task:create\n")
+			Expect(os.Chtimes(path, time.Now(), time.Now().Add(time.Second))).To(Succeed())
+
+			Eventually(func() bool {
+				return can(a, []string{"viewer"}, authz.ActionTaskCreate)
+			}).Should(BeTrue())
+			Expect(can(a, []string{"viewer"}, authz.ActionTaskRead)).To(BeFalse())
+		})
+
+		It("should keep the previous policy when the new one is invalid", func() {
+			a, err := policy.NewFileAuthorizer(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			watchCtx, cancel := context.WithCancel(ctx)
+			DeferCleanup(cancel)
+			go a.Watch(watchCtx, 10*time.Millisecond)
+
+			writePolicy("roles: [")
+			Expect(os.Chtimes(path, time.Now(), time.Now().Add(time.Second))).To(Succeed())
+
+			Consistently(func() bool {
+				return can(a, []string{"viewer"}, authz.ActionTaskRead)
+			}, 100*time.Millisecond, 10*time.Millisecond).Should(BeTrue())
+		})
+	})
+})
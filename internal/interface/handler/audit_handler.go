@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/audit"
+)
+
+type ChangeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+type AuditRecordResponse struct {
+	ID        string                    `json:"id"`
+	TaskID    string                    `json:"task_id"`
+	Actor     string                    `json:"actor"`
+	Action    string                    `json:"action"`
+	Changes   map[string]ChangeResponse `json:"changes"`
+	RequestID string                    `json:"request_id"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
+
+type AuditHandler struct {
+	usecase audit.Interactor
+}
+
+func NewAuditHandler(usecase audit.Interactor) *AuditHandler {
+	return &AuditHandler{usecase: usecase}
+}
+
+// GetTaskHistory handles GET /tasks/:id/history.
+func (h *AuditHandler) GetTaskHistory(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	records, err := h.usecase.GetTaskHistory(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, audit.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to read tasks")
+			return
+		}
+		writeServerError(c, err, "failed to get task history")
+		return
+	}
+	c.JSON(http.StatusOK, toAuditRecordResponses(records))
+}
+
+// GetAuditLog handles GET /audit?actor=...&since=2025-10-01T00:00:00Z&limit=100.
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	input := audit.ListRecordsInput{Actor: c.Query("actor")}
+	if since := c.Query("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since"})
+			return
+		}
+		input.Since = parsed
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		input.Limit = parsed
+	}
+	records, err := h.usecase.GetAuditLog(c.Request.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, audit.ErrInvalidLimit):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, audit.ErrForbidden):
+			problem.Write(c, http.StatusForbidden, "not allowed to read the audit log")
+		case errors.Is(err, audit.ErrWorkspaceRequired):
+			problem.Write(c, http.StatusBadRequest, "a workspace must be selected")
+		default:
+			writeServerError(c, err, "failed to get audit log")
+		}
+		return
+	}
+	c.JSON(http.StatusOK, toAuditRecordResponses(records))
+}
+
+func toAuditRecordResponses(records []audit.RecordOutput) []AuditRecordResponse {
+	responses := make([]AuditRecordResponse, 0, len(records))
+	for _, record := range records {
+		changes := make(map[string]ChangeResponse, len(record.Changes))
+		for field, change := range record.Changes {
+			changes[field] = ChangeResponse{Before: change.Before, After: change.After}
+		}
+		responses = append(responses, AuditRecordResponse{
+			ID:        record.ID,
+			TaskID:    record.TaskID,
+			Actor:     record.Actor,
+			Action:    record.Action,
+			Changes:   changes,
+			RequestID: record.RequestID,
+			CreatedAt: record.CreatedAt,
+		})
+	}
+	return responses
+}
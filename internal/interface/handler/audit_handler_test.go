@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/handler"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/audit/mocks"
+)
+
+var _ = Describe("Audit Handler", func() {
+	const taskID = "550e8400-e29b-41d4-a716-446655440000"
+
+	var (
+		ctrl           *gomock.Controller
+		mockInteractor *mocks.MockInteractor
+		router         *gin.Engine
+		recorder       *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		ctrl = gomock.NewController(GinkgoT())
+		mockInteractor = mocks.NewMockInteractor(ctrl)
+		auditHandler := handler.NewAuditHandler(mockInteractor)
+		router = gin.New()
+		router.GET("/tasks/:id/history", auditHandler.GetTaskHistory)
+		router.GET("/audit", auditHandler.GetAuditLog)
+		recorder = httptest.NewRecorder()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	get := func(path string) {
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(recorder, req)
+	}
+
+	Describe("GetTaskHistory", func() {
+		It("should return the records with their changes", func() {
+			mockInteractor.EXPECT().GetTaskHistory(gomock.Any(), taskID).Return([]audit.RecordOutput{{
+				ID:      "record-1",
+				TaskID:  taskID,
+				Actor:   "alice",
+				Action:  "update",
+				Changes: map[string]audit.ChangeOutput{"status": {Before: "todo", After: "in_progress"}},
+			}}, nil)
+
+			get("/tasks/" + taskID + "/history")
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			var response []handler.AuditRecordResponse
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+			Expect(response).To(HaveLen(1))
+			Expect(response[0].Changes["status"]).To(Equal(handler.ChangeResponse{Before: "todo", After: "in_progress"}))
+		})
+
+		It("should reject invalid ids", func() {
+			get("/tasks/not-a-uuid/history")
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("GetAuditLog", func() {
+		It("should pass the filters to the usecase", func() {
+			since := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+			mockInteractor.EXPECT().GetAuditLog(gomock.Any(), audit.ListRecordsInput{Actor: "alice", Since: since, Limit: 10}).
+				Return([]audit.RecordOutput{}, nil)
+
+			get("/audit?actor=alice&since=2025-10-01T00:00:00Z&limit=10")
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(MatchJSON(`[]`))
+		})
+
+		It("should reject malformed timestamps", func() {
+			get("/audit?since=yesterday")
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 403 without the audit:read permission", func() {
+			mockInteractor.EXPECT().GetAuditLog(gomock.Any(), gomock.Any()).Return(nil, audit.ErrForbidden)
+
+			get("/audit")
+
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
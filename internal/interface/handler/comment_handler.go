@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/comment"
+)
+
+type CommentResponse struct {
+	ID          string     `json:"id"`
+	WorkspaceID string     `json:"workspace_id"`
+	TaskID      string     `json:"task_id"`
+	AuthorID    string     `json:"author_id"`
+	Body        string     `json:"body"`
+	CreatedAt   time.Time  `json:"created_at"`
+	EditedAt    *time.Time `json:"edited_at"`
+}
+
+// CommentPageResponse is one page of a task's comments, oldest first.
+// NextCursor is null on the last page.
+type CommentPageResponse struct {
+	Comments   []CommentResponse `json:"comments"`
+	NextCursor *string           `json:"next_cursor"`
+}
+
+type CommentHandler struct {
+	usecase comment.Interactor
+}
+
+func NewCommentHandler(usecase comment.Interactor) *CommentHandler {
+	return &CommentHandler{usecase: usecase}
+}
+
+// GetComments handles GET /tasks/:id/comments?limit=20&cursor=...
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	taskID, ok := commentParam(c, "id")
+	if !ok {
+		return
+	}
+	input := comment.ListCommentsInput{Cursor: c.Query("cursor")}
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		input.Limit = parsed
+	}
+	page, err := h.usecase.GetComments(c.Request.Context(), taskID, input)
+	if err != nil {
+		h.writeError(c, err, "failed to get comments")
+		return
+	}
+	response := CommentPageResponse{Comments: make([]CommentResponse, 0, len(page.Comments))}
+	for _, commentOutput := range page.Comments {
+		response.Comments = append(response.Comments, toCommentResponse(commentOutput))
+	}
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CommentHandler) AddComment(c *gin.Context) {
+	taskID, ok := commentParam(c, "id")
+	if !ok {
+		return
+	}
+	body, ok := commentBody(c)
+	if !ok {
+		return
+	}
+	output, err := h.usecase.AddComment(c.Request.Context(), taskID, body)
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusCreated, toCommentResponse(output))
+}
+
+func (h *CommentHandler) EditComment(c *gin.Context) {
+	taskID, ok := commentParam(c, "id")
+	if !ok {
+		return
+	}
+	id, ok := commentParam(c, "comment_id")
+	if !ok {
+		return
+	}
+	body, ok := commentBody(c)
+	if !ok {
+		return
+	}
+	output, err := h.usecase.EditComment(c.Request.Context(), taskID, id, body)
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toCommentResponse(output))
+}
+
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	taskID, ok := commentParam(c, "id")
+	if !ok {
+		return
+	}
+	id, ok := commentParam(c, "comment_id")
+	if !ok {
+		return
+	}
+	if err := h.usecase.DeleteComment(c.Request.Context(), taskID, id); err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CommentHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, comment.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, comment.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+	case errors.Is(err, comment.ErrInvalidBody), errors.Is(err, comment.ErrBodyBlank), errors.Is(err, comment.ErrBodyTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+	case errors.Is(err, comment.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	case errors.Is(err, comment.ErrInvalidPageSize):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+	case errors.Is(err, comment.ErrNotAuthor):
+		problem.Write(c, http.StatusForbidden, "only the author may change a comment")
+	case errors.Is(err, comment.ErrForbidden):
+		problem.Write(c, http.StatusForbidden, "not allowed to access comments")
+	case errors.Is(err, comment.ErrWorkspaceRequired):
+		problem.Write(c, http.StatusBadRequest, "a workspace must be selected")
+	default:
+		writeServerError(c, err, fallback)
+	}
+}
+
+func commentParam(c *gin.Context, param string) (string, bool) {
+	id := c.Param(param)
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+		return "", false
+	}
+	return id, true
+}
+
+func commentBody(c *gin.Context) (string, bool) {
+	type request struct {
+		Body string `json:"body"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return "", false
+	}
+	return req.Body, true
+}
+
+func toCommentResponse(commentOutput comment.CommentOutput) CommentResponse {
+	return CommentResponse{
+		ID:          commentOutput.ID,
+		WorkspaceID: commentOutput.WorkspaceID,
+		TaskID:      commentOutput.TaskID,
+		AuthorID:    commentOutput.AuthorID,
+		Body:        commentOutput.Body,
+		CreatedAt:   commentOutput.CreatedAt,
+		EditedAt:    commentOutput.EditedAt,
+	}
+}
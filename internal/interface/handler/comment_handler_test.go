@@ -0,0 +1,128 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/handler"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/comment/mocks"
+)
+
+var _ = Describe("Comment Handler", func() {
+	const (
+		taskID    = "550e8400-e29b-41d4-a716-446655440000"
+		commentID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	)
+
+	var (
+		ctrl           *gomock.Controller
+		mockInteractor *mocks.MockInteractor
+		commentHandler *handler.CommentHandler
+		router         *gin.Engine
+		recorder       *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		ctrl = gomock.NewController(GinkgoT())
+		mockInteractor = mocks.NewMockInteractor(ctrl)
+		commentHandler = handler.NewCommentHandler(mockInteractor)
+		router = gin.New()
+		router.GET("/tasks/:id/comments", commentHandler.GetComments)
+		router.POST("/tasks/:id/comments", commentHandler.AddComment)
+		router.PUT("/tasks/:id/comments/:comment_id", commentHandler.EditComment)
+		router.DELETE("/tasks/:id/comments/:comment_id", commentHandler.DeleteComment)
+		recorder = httptest.NewRecorder()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	send := func(method, path, body string) {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(recorder, req)
+	}
+
+	Describe("GetComments", func() {
+		It("should return a page with the next cursor", func() {
+			mockInteractor.EXPECT().GetComments(gomock.Any(), taskID, comment.ListCommentsInput{Limit: 1, Cursor: "abc"}).
+				Return(comment.CommentPageOutput{
+					Comments:   []comment.CommentOutput{{ID: commentID, Body: "LGTM"}},
+					NextCursor: "def",
+				}, nil)
+
+			send("GET", "/tasks/"+taskID+"/comments?limit=1&cursor=abc", "")
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			var response handler.CommentPageResponse
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Comments).To(HaveLen(1))
+			Expect(response.NextCursor).To(HaveValue(Equal("def")))
+		})
+
+		It("should return null as next cursor on the last page", func() {
+			mockInteractor.EXPECT().GetComments(gomock.Any(), taskID, comment.ListCommentsInput{}).
+				Return(comment.CommentPageOutput{}, nil)
+
+			send("GET", "/tasks/"+taskID+"/comments", "")
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).To(MatchJSON(`{"comments": [], "next_cursor": null}`))
+		})
+
+		It("should reject a non-numeric limit", func() {
+			send("GET", "/tasks/"+taskID+"/comments?limit=ten", "")
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("AddComment", func() {
+		It("should return the created comment", func() {
+			mockInteractor.EXPECT().AddComment(gomock.Any(), taskID, "*Deployed*").
+				Return(comment.CommentOutput{ID: commentID, Body: "*Deployed*", AuthorID: "user-1"}, nil)
+
+			send("POST", "/tasks/"+taskID+"/comments", `{"body": "*Deployed*"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusCreated))
+		})
+
+		It("should return 400 for a blank body", func() {
+			mockInteractor.EXPECT().AddComment(gomock.Any(), taskID, " ").Return(comment.CommentOutput{}, comment.ErrBodyBlank)
+
+			send("POST", "/tasks/"+taskID+"/comments", `{"body": " "}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("EditComment", func() {
+		It("should return 403 for someone else's comment", func() {
+			mockInteractor.EXPECT().EditComment(gomock.Any(), taskID, commentID, "Edited").Return(comment.CommentOutput{}, comment.ErrNotAuthor)
+
+			send("PUT", "/tasks/"+taskID+"/comments/"+commentID, `{"body": "Edited"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("DeleteComment", func() {
+		It("should return 404 when the comment does not exist", func() {
+			mockInteractor.EXPECT().DeleteComment(gomock.Any(), taskID, commentID).Return(comment.ErrCommentNotFound)
+
+			send("DELETE", "/tasks/"+taskID+"/comments/"+commentID, "")
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
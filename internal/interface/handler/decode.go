@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+)
+
+// maxBodyBytes bounds the body of write requests. The largest bodies are
+// comments, which fit with room to spare.
+const maxBodyBytes = 1 << 20
+
+// decodeJSON decodes the request body into v, which must point to a struct,
+// and answers the request itself when that fails: 415 unless the body is
+// application/json, 413 when it is larger than maxBodyBytes, and 400 when it
+// is not a single JSON object matching v. Fields v does not know are
+// rejected rather than ignored, so misspelled ones do not go unnoticed. The
+// 400 names the malformed field whenever it can be told.
+func decodeJSON(c *gin.Context, v any) bool {
+	mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		problem.Write(c, http.StatusUnsupportedMediaType, "the request body must be application/json")
+		return false
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem.Write(c, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("the request body must not exceed %d bytes", maxBodyBytes))
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return false
+	}
+
+	if err := decodeStrict(body, v); err != nil {
+		field, detail := describeDecodeError(err, body, v)
+		response := gin.H{"error": "invalid request body", "detail": detail}
+		if field != "" {
+			response["field"] = field
+		}
+		c.JSON(http.StatusBadRequest, response)
+		return false
+	}
+	return true
+}
+
+var errTrailingData = errors.New("unexpected data after the JSON object")
+
+func decodeStrict(body []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errTrailingData
+	}
+	return nil
+}
+
+// describeDecodeError returns the field err is about, if any, and a message
+// for the client.
+func describeDecodeError(err error, body []byte, v any) (string, string) {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return "", "the request body is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "", "the request body ends in the middle of a JSON value"
+	case errors.Is(err, errTrailingData):
+		return "", errTrailingData.Error()
+	case errors.As(err, &syntaxErr):
+		return "", fmt.Sprintf("malformed JSON at byte %d", syntaxErr.Offset)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return typeErr.Field, fmt.Sprintf("%s must be %s", typeErr.Field, jsonKind(typeErr.Type))
+	case errors.As(err, &typeErr):
+		return "", "the request body must be a JSON object"
+	}
+	if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if unquoted, unquoteErr := strconv.Unquote(name); unquoteErr == nil {
+			name = unquoted
+		}
+		return name, fmt.Sprintf("%s is not a known field", name)
+	}
+	// Errors of types decoding themselves, such as time.Time, do not say
+	// which field they came from, so the members are decoded one at a time
+	// to find it.
+	if field := malformedField(body, v); field != "" {
+		return field, fmt.Sprintf("%s is malformed: %v", field, err)
+	}
+	return "", err.Error()
+}
+
+// malformedField returns the first member, by name, of the JSON object in
+// body that does not decode on its own into the struct v points to.
+func malformedField(body []byte, v any) string {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return ""
+	}
+	target := reflect.TypeOf(v).Elem()
+	for _, name := range slices.Sorted(maps.Keys(members)) {
+		member, err := json.Marshal(map[string]json.RawMessage{name: members[name]})
+		if err != nil {
+			continue
+		}
+		if err := json.Unmarshal(member, reflect.New(target).Interface()); err != nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// jsonKind names the JSON value expected for a Go type.
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Pointer:
+		return jsonKind(t.Elem())
+	default:
+		return "an object"
+	}
+}
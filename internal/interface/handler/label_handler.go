@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/label"
+)
+
+type LabelResponse struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Color       string    `json:"color"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type LabelHandler struct {
+	usecase label.Interactor
+}
+
+func NewLabelHandler(usecase label.Interactor) *LabelHandler {
+	return &LabelHandler{usecase: usecase}
+}
+
+func (h *LabelHandler) GetLabels(c *gin.Context) {
+	labels, err := h.usecase.GetLabels(c.Request.Context())
+	if err != nil {
+		h.writeError(c, err, "failed to get labels")
+		return
+	}
+	c.JSON(http.StatusOK, toLabelResponses(labels))
+}
+
+func (h *LabelHandler) GetLabel(c *gin.Context) {
+	id, ok := labelID(c, "id")
+	if !ok {
+		return
+	}
+	output, err := h.usecase.GetLabel(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toLabelResponse(output))
+}
+
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	type request struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	output, err := h.usecase.CreateLabel(c.Request.Context(), label.CreateLabelInput{
+		Name:  req.Name,
+		Color: req.Color,
+	})
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusCreated, toLabelResponse(output))
+}
+
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	id, ok := labelID(c, "id")
+	if !ok {
+		return
+	}
+	type request struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	output, err := h.usecase.UpdateLabel(c.Request.Context(), id, label.UpdateLabelInput{
+		Name:  req.Name,
+		Color: req.Color,
+	})
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toLabelResponse(output))
+}
+
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	id, ok := labelID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.usecase.DeleteLabel(c.Request.Context(), id); err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AttachLabel handles POST /tasks/:id/labels with a body of
+// {"label_id": "uuid"}.
+func (h *LabelHandler) AttachLabel(c *gin.Context) {
+	taskID := c.Param("id")
+	if _, err := uuid.Parse(taskID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	type request struct {
+		LabelID string `json:"label_id"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	if _, err := uuid.Parse(req.LabelID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label_id"})
+		return
+	}
+	if err := h.usecase.AttachLabel(c.Request.Context(), taskID, req.LabelID); err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *LabelHandler) DetachLabel(c *gin.Context) {
+	taskID := c.Param("id")
+	if _, err := uuid.Parse(taskID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	id, ok := labelID(c, "label_id")
+	if !ok {
+		return
+	}
+	if err := h.usecase.DetachLabel(c.Request.Context(), taskID, id); err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *LabelHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, label.ErrLabelNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "label not found"})
+	case errors.Is(err, label.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, label.ErrLabelNotAttached):
+		c.JSON(http.StatusNotFound, gin.H{"error": "label not attached"})
+	case errors.Is(err, label.ErrLabelExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "label name already in use"})
+	case errors.Is(err, label.ErrInvalidName), errors.Is(err, label.ErrNameBlank), errors.Is(err, label.ErrNameTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid name"})
+	case errors.Is(err, label.ErrInvalidColor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid color"})
+	case errors.Is(err, label.ErrForbidden):
+		problem.Write(c, http.StatusForbidden, "not allowed to access labels")
+	case errors.Is(err, label.ErrWorkspaceRequired):
+		problem.Write(c, http.StatusBadRequest, "a workspace must be selected")
+	default:
+		writeServerError(c, err, fallback)
+	}
+}
+
+func labelID(c *gin.Context, param string) (string, bool) {
+	id := c.Param(param)
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+		return "", false
+	}
+	return id, true
+}
+
+func toLabelResponses(labels []label.LabelOutput) []LabelResponse {
+	responses := make([]LabelResponse, 0, len(labels))
+	for _, labelOutput := range labels {
+		responses = append(responses, toLabelResponse(labelOutput))
+	}
+	return responses
+}
+
+func toLabelResponse(labelOutput label.LabelOutput) LabelResponse {
+	return LabelResponse{
+		ID:          labelOutput.ID,
+		WorkspaceID: labelOutput.WorkspaceID,
+		Name:        labelOutput.Name,
+		Color:       labelOutput.Color,
+		CreatedAt:   labelOutput.CreatedAt,
+		UpdatedAt:   labelOutput.UpdatedAt,
+	}
+}
@@ -0,0 +1,143 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/handler"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/label"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/label/mocks"
+)
+
+var _ = Describe("Label Handler", func() {
+	const (
+		labelID = "550e8400-e29b-41d4-a716-446655440000"
+		taskID  = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	)
+
+	var (
+		ctrl           *gomock.Controller
+		mockInteractor *mocks.MockInteractor
+		labelHandler   *handler.LabelHandler
+		router         *gin.Engine
+		recorder       *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		ctrl = gomock.NewController(GinkgoT())
+		mockInteractor = mocks.NewMockInteractor(ctrl)
+		labelHandler = handler.NewLabelHandler(mockInteractor)
+		router = gin.New()
+		router.GET("/labels", labelHandler.GetLabels)
+		router.POST("/labels", labelHandler.CreateLabel)
+		router.PUT("/labels/:id", labelHandler.UpdateLabel)
+		router.DELETE("/labels/:id", labelHandler.DeleteLabel)
+		router.POST("/tasks/:id/labels", labelHandler.AttachLabel)
+		router.DELETE("/tasks/:id/labels/:label_id", labelHandler.DetachLabel)
+		recorder = httptest.NewRecorder()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	send := func(method, path, body string) {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(recorder, req)
+	}
+
+	Describe("CreateLabel", func() {
+		It("should return the created label", func() {
+			mockInteractor.EXPECT().CreateLabel(gomock.Any(), label.CreateLabelInput{Name: "bug", Color: "#d73a4a"}).
+				Return(label.LabelOutput{ID: labelID, Name: "bug", Color: "#d73a4a"}, nil)
+
+			send("POST", "/labels", `{"name": "bug", "color": "#d73a4a"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusCreated))
+			var response handler.LabelResponse
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Color).To(Equal("#d73a4a"))
+		})
+
+		It("should return 409 for a duplicate name", func() {
+			mockInteractor.EXPECT().CreateLabel(gomock.Any(), gomock.Any()).Return(label.LabelOutput{}, label.ErrLabelExists)
+
+			send("POST", "/labels", `{"name": "bug"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should return 400 for an invalid colour", func() {
+			mockInteractor.EXPECT().CreateLabel(gomock.Any(), gomock.Any()).Return(label.LabelOutput{}, label.ErrInvalidColor)
+
+			send("POST", "/labels", `{"name": "bug", "color": "red"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			Expect(recorder.Body.String()).To(ContainSubstring("invalid color"))
+		})
+	})
+
+	Describe("UpdateLabel", func() {
+		It("should return 404 when the label does not exist", func() {
+			mockInteractor.EXPECT().UpdateLabel(gomock.Any(), labelID, gomock.Any()).Return(label.LabelOutput{}, label.ErrLabelNotFound)
+
+			send("PUT", "/labels/"+labelID, `{"name": "defect"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("DeleteLabel", func() {
+		It("should return 204", func() {
+			mockInteractor.EXPECT().DeleteLabel(gomock.Any(), labelID).Return(nil)
+
+			send("DELETE", "/labels/"+labelID, "")
+
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	Describe("AttachLabel", func() {
+		It("should attach the label", func() {
+			mockInteractor.EXPECT().AttachLabel(gomock.Any(), taskID, labelID).Return(nil)
+
+			send("POST", "/tasks/"+taskID+"/labels", `{"label_id": "`+labelID+`"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should reject an invalid label_id", func() {
+			send("POST", "/tasks/"+taskID+"/labels", `{"label_id": "bug"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 404 when the task does not exist", func() {
+			mockInteractor.EXPECT().AttachLabel(gomock.Any(), taskID, labelID).Return(label.ErrTaskNotFound)
+
+			send("POST", "/tasks/"+taskID+"/labels", `{"label_id": "`+labelID+`"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			Expect(recorder.Body.String()).To(ContainSubstring("task not found"))
+		})
+	})
+
+	Describe("DetachLabel", func() {
+		It("should return 404 when the label is not attached", func() {
+			mockInteractor.EXPECT().DetachLabel(gomock.Any(), taskID, labelID).Return(label.ErrLabelNotAttached)
+
+			send("DELETE", "/tasks/"+taskID+"/labels/"+labelID, "")
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
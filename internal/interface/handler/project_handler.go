@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/project"
+)
+
+type ProjectResponse struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Archived    bool      `json:"archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ProjectHandler struct {
+	usecase project.Interactor
+}
+
+func NewProjectHandler(usecase project.Interactor) *ProjectHandler {
+	return &ProjectHandler{usecase: usecase}
+}
+
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	projects, err := h.usecase.GetProjects(c.Request.Context())
+	if err != nil {
+		h.writeError(c, err, "failed to get projects")
+		return
+	}
+	c.JSON(http.StatusOK, toProjectResponses(projects))
+}
+
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	id, ok := projectID(c)
+	if !ok {
+		return
+	}
+	output, err := h.usecase.GetProject(c.Request.Context(), id)
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toProjectResponse(output))
+}
+
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	type request struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	output, err := h.usecase.CreateProject(c.Request.Context(), project.CreateProjectInput{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusCreated, toProjectResponse(output))
+}
+
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, ok := projectID(c)
+	if !ok {
+		return
+	}
+	type request struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Archived    bool   `json:"archived"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	output, err := h.usecase.UpdateProject(c.Request.Context(), id, project.UpdateProjectInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Archived:    req.Archived,
+	})
+	if err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toProjectResponse(output))
+}
+
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, ok := projectID(c)
+	if !ok {
+		return
+	}
+	if err := h.usecase.DeleteProject(c.Request.Context(), id); err != nil {
+		h.writeError(c, err, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ProjectHandler) writeError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, project.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+	case errors.Is(err, project.ErrInvalidName), errors.Is(err, project.ErrNameBlank), errors.Is(err, project.ErrNameTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid name"})
+	case errors.Is(err, project.ErrDescriptionTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid description"})
+	case errors.Is(err, project.ErrForbidden):
+		problem.Write(c, http.StatusForbidden, "not allowed to access projects")
+	case errors.Is(err, project.ErrWorkspaceRequired):
+		problem.Write(c, http.StatusBadRequest, "a workspace must be selected")
+	default:
+		writeServerError(c, err, fallback)
+	}
+}
+
+func projectID(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return "", false
+	}
+	return id, true
+}
+
+func toProjectResponses(projects []project.ProjectOutput) []ProjectResponse {
+	responses := make([]ProjectResponse, 0, len(projects))
+	for _, projectOutput := range projects {
+		responses = append(responses, toProjectResponse(projectOutput))
+	}
+	return responses
+}
+
+func toProjectResponse(projectOutput project.ProjectOutput) ProjectResponse {
+	return ProjectResponse{
+		ID:          projectOutput.ID,
+		WorkspaceID: projectOutput.WorkspaceID,
+		Name:        projectOutput.Name,
+		Description: projectOutput.Description,
+		Archived:    projectOutput.Archived,
+		CreatedAt:   projectOutput.CreatedAt,
+		UpdatedAt:   projectOutput.UpdatedAt,
+	}
+}
@@ -0,0 +1,168 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/handler"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/project"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/project/mocks"
+)
+
+var _ = Describe("Project Handler", func() {
+	const projectID = "550e8400-e29b-41d4-a716-446655440000"
+
+	var (
+		ctrl           *gomock.Controller
+		mockInteractor *mocks.MockInteractor
+		projectHandler *handler.ProjectHandler
+		router         *gin.Engine
+		recorder       *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		ctrl = gomock.NewController(GinkgoT())
+		mockInteractor = mocks.NewMockInteractor(ctrl)
+		projectHandler = handler.NewProjectHandler(mockInteractor)
+		router = gin.New()
+		router.GET("/projects", projectHandler.GetProjects)
+		router.POST("/projects", projectHandler.CreateProject)
+		router.GET("/projects/:id", projectHandler.GetProject)
+		router.PUT("/projects/:id", projectHandler.UpdateProject)
+		router.DELETE("/projects/:id", projectHandler.DeleteProject)
+		recorder = httptest.NewRecorder()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("GetProjects", func() {
+		It("should return 200 with projects list", func() {
+			mockInteractor.EXPECT().GetProjects(gomock.Any()).Return([]project.ProjectOutput{{ID: projectID, Name: "Ops"}}, nil)
+
+			req, _ := http.NewRequest("GET", "/projects", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			var response []handler.ProjectResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			Expect(err).To(BeNil())
+			Expect(response).To(HaveLen(1))
+			Expect(response[0].Name).To(Equal("Ops"))
+		})
+
+		It("should return 500 when the usecase fails", func() {
+			mockInteractor.EXPECT().GetProjects(gomock.Any()).Return(nil, errors.New("database error"))
+
+			req, _ := http.NewRequest("GET", "/projects", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Describe("GetProject", func() {
+		It("should return 404 when the project does not exist", func() {
+			mockInteractor.EXPECT().GetProject(gomock.Any(), projectID).Return(project.ProjectOutput{}, project.ErrProjectNotFound)
+
+			req, _ := http.NewRequest("GET", "/projects/"+projectID, nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 400 for an invalid id", func() {
+			req, _ := http.NewRequest("GET", "/projects/not-a-uuid", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("CreateProject", func() {
+		It("should return 201 with the created project", func() {
+			mockInteractor.EXPECT().
+				CreateProject(gomock.Any(), project.CreateProjectInput{Name: "Ops", Description: "Runbooks"}).
+				Return(project.ProjectOutput{ID: projectID, Name: "Ops", Description: "Runbooks"}, nil)
+
+			body, _ := json.Marshal(map[string]string{"name": "Ops", "description": "Runbooks"})
+			req, _ := http.NewRequest("POST", "/projects", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusCreated))
+
+			var response handler.ProjectResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			Expect(err).To(BeNil())
+			Expect(response.ID).To(Equal(projectID))
+		})
+
+		It("should return 400 for an invalid name", func() {
+			mockInteractor.EXPECT().CreateProject(gomock.Any(), gomock.Any()).Return(project.ProjectOutput{}, project.ErrNameBlank)
+
+			body, _ := json.Marshal(map[string]string{"name": "  "})
+			req, _ := http.NewRequest("POST", "/projects", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+
+			var response map[string]string
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			Expect(err).To(BeNil())
+			Expect(response["error"]).To(Equal("invalid name"))
+		})
+	})
+
+	Describe("UpdateProject", func() {
+		It("should return 200 with the archived project", func() {
+			mockInteractor.EXPECT().
+				UpdateProject(gomock.Any(), projectID, project.UpdateProjectInput{Name: "Ops", Archived: true}).
+				Return(project.ProjectOutput{ID: projectID, Name: "Ops", Archived: true}, nil)
+
+			body, _ := json.Marshal(map[string]any{"name": "Ops", "archived": true})
+			req, _ := http.NewRequest("PUT", "/projects/"+projectID, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			var response handler.ProjectResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			Expect(err).To(BeNil())
+			Expect(response.Archived).To(BeTrue())
+		})
+	})
+
+	Describe("DeleteProject", func() {
+		It("should return 204", func() {
+			mockInteractor.EXPECT().DeleteProject(gomock.Any(), projectID).Return(nil)
+
+			req, _ := http.NewRequest("DELETE", "/projects/"+projectID, nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should return 403 when not allowed", func() {
+			mockInteractor.EXPECT().DeleteProject(gomock.Any(), projectID).Return(project.ErrForbidden)
+
+			req, _ := http.NewRequest("DELETE", "/projects/"+projectID, nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
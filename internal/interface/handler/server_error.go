@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+)
+
+// writeServerError answers errors no other case of a handler matched. When
+// the deadline of the request passed it answers 504; the database may have
+// reported that as a cancelled statement, so the request context is checked
+// as well. When storage is unavailable for now it answers 503 with
+// Retry-After, so clients back off instead of retrying at once. Anything else
+// is a 500 carrying fallback.
+func writeServerError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+		problem.Write(c, http.StatusGatewayTimeout, "the request took too long")
+		return
+	}
+	var unavailable *transaction.UnavailableError
+	if errors.As(err, &unavailable) {
+		seconds := max(1, int(math.Ceil(unavailable.RetryAfter.Seconds())))
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		problem.Write(c, http.StatusServiceUnavailable, "storage is temporarily unavailable")
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
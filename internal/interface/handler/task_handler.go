@@ -3,19 +3,55 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
 	"github.com/ko44d/go-clean-hexapp/internal/usecase/task"
 )
 
 type TaskResponse struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           string     `json:"id"`
+	WorkspaceID  string     `json:"workspace_id"`
+	ProjectID    *string    `json:"project_id"`
+	ParentID     *string    `json:"parent_id"`
+	Title        string     `json:"title"`
+	Status       string     `json:"status"`
+	Priority     string     `json:"priority"`
+	DueAt        *time.Time `json:"due_at"`
+	Recurrence   *string    `json:"recurrence"`
+	SeriesID     *string    `json:"series_id"`
+	Assignees    []string   `json:"assignees"`
+	Progress     Progress   `json:"progress"`
+	Labels       []string   `json:"labels"`
+	CommentCount int        `json:"comment_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	// DeletedAt is only present on tasks in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Progress counts the direct, non-cancelled subtasks of a task.
+type Progress struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+}
+
+type TaskTreeResponse struct {
+	TaskResponse
+	Subtasks []TaskTreeResponse `json:"subtasks"`
+}
+
+type DependencyResponse struct {
+	TaskID    string `json:"task_id"`
+	BlockerID string `json:"blocker_id"`
+}
+
+type DependencyGraphResponse struct {
+	Nodes []TaskResponse       `json:"nodes"`
+	Edges []DependencyResponse `json:"edges"`
 }
 
 type TaskHandler struct {
@@ -27,34 +63,224 @@ func New(usecase task.Interactor) *TaskHandler {
 }
 
 func (h *TaskHandler) GetTasks(c *gin.Context) {
-	tasks, err := h.usecase.GetTasks(c.Request.Context())
+	filter := task.TaskFilter{
+		Priority:   c.Query("priority"),
+		Sort:       c.Query("sort"),
+		Assignee:   c.Query("assignee"),
+		Labels:     c.QueryArray("label"),
+		LabelMatch: c.Query("label_match"),
+	}
+	if overdue := c.Query("overdue"); overdue != "" {
+		parsed, err := strconv.ParseBool(overdue)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid overdue"})
+			return
+		}
+		filter.Overdue = parsed
+	}
+	tasks, err := h.usecase.GetTasks(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, task.ErrInvalidPriority) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid priority"})
+			return
+		}
+		if errors.Is(err, task.ErrInvalidSort) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort"})
+			return
+		}
+		if errors.Is(err, task.ErrInvalidLabelMatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label_match"})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to read tasks")
+			return
+		}
+		writeServerError(c, err, "failed to get tasks")
+		return
+	}
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
+
+func (h *TaskHandler) GetProjectTasks(c *gin.Context) {
+	projectID := c.Param("id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	tasks, err := h.usecase.GetTasks(c.Request.Context(), task.TaskFilter{ProjectID: projectID})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get tasks"})
+		if errors.Is(err, task.ErrProjectNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to read tasks")
+			return
+		}
+		writeServerError(c, err, "failed to get tasks")
 		return
 	}
 	c.JSON(http.StatusOK, toTaskResponses(tasks))
 }
 
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	output, err := h.usecase.GetTask(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, task.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to read tasks")
+			return
+		}
+		writeServerError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toTaskResponse(output))
+}
+
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	subtasks, err := h.usecase.GetSubtasks(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, task.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to read tasks")
+			return
+		}
+		writeServerError(c, err, "failed to get subtasks")
+		return
+	}
+	c.JSON(http.StatusOK, toTaskTreeResponses(subtasks))
+}
+
 func (h *TaskHandler) AddTask(c *gin.Context) {
 	type request struct {
-		Title string `json:"title"`
+		Title      string     `json:"title"`
+		ProjectID  string     `json:"project_id"`
+		ParentID   string     `json:"parent_id"`
+		Priority   string     `json:"priority"`
+		DueAt      *time.Time `json:"due_at"`
+		Recurrence string     `json:"recurrence"`
 	}
 	var req request
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	if !decodeJSON(c, &req) {
+		return
+	}
+	if req.ProjectID != "" {
+		if _, err := uuid.Parse(req.ProjectID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project_id"})
+			return
+		}
+	}
+	if !validParentID(c, req.ParentID) {
 		return
 	}
-	if err := h.usecase.AddTask(c.Request.Context(), req.Title); err != nil {
-		if errors.Is(err, task.ErrInvalidTitle) || errors.Is(err, task.ErrTitleBlank) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid title"})
+	input := task.AddTaskInput{
+		Title:      req.Title,
+		ProjectID:  req.ProjectID,
+		ParentID:   req.ParentID,
+		Priority:   req.Priority,
+		DueAt:      req.DueAt,
+		Recurrence: req.Recurrence,
+	}
+	if err := h.usecase.AddTask(c.Request.Context(), input); err != nil {
+		if writeValidationError(c, err) {
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to create tasks")
+			return
+		}
+		if errors.Is(err, task.ErrProjectNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+			return
+		}
+		if errors.Is(err, task.ErrParentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "parent task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrProjectArchived) {
+			c.JSON(http.StatusConflict, gin.H{"error": "project is archived"})
+			return
+		}
+		if errors.Is(err, task.ErrWorkspaceRequired) {
+			problem.Write(c, http.StatusBadRequest, "a workspace must be selected")
+			return
+		}
+		writeServerError(c, err, "internal server error")
 		return
 	}
 	c.Status(http.StatusCreated)
 }
 
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	type request struct {
+		Title      string     `json:"title"`
+		ParentID   string     `json:"parent_id"`
+		Priority   string     `json:"priority"`
+		DueAt      *time.Time `json:"due_at"`
+		Recurrence string     `json:"recurrence"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	if !validParentID(c, req.ParentID) {
+		return
+	}
+	output, err := h.usecase.UpdateTask(c.Request.Context(), id, task.UpdateTaskInput{
+		Title:      req.Title,
+		ParentID:   req.ParentID,
+		Priority:   req.Priority,
+		DueAt:      req.DueAt,
+		Recurrence: req.Recurrence,
+	})
+	if err != nil {
+		if writeValidationError(c, err) {
+			return
+		}
+		if errors.Is(err, task.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrParentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "parent task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to update tasks")
+			return
+		}
+		writeServerError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toTaskResponse(output))
+}
+
 func (h *TaskHandler) CompleteTask(c *gin.Context) {
 	id := c.Query("id")
 	if id == "" {
@@ -65,17 +291,319 @@ func (h *TaskHandler) CompleteTask(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
 		return
 	}
-	if err := h.usecase.CompleteTask(c.Request.Context(), id); err != nil {
+	cascade := false
+	if value := c.Query("cascade"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cascade"})
+			return
+		}
+		cascade = parsed
+	}
+	if err := h.usecase.CompleteTask(c.Request.Context(), id, cascade); err != nil {
 		if errors.Is(err, task.ErrTaskNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		if errors.Is(err, task.ErrInvalidTransition) || errors.Is(err, task.ErrOpenSubtasks) ||
+			errors.Is(err, task.ErrOpenBlockers) || errors.Is(err, task.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to update tasks")
+			return
+		}
+		writeServerError(c, err, "internal server error")
 		return
 	}
 	c.Status(http.StatusOK)
 }
 
+func (h *TaskHandler) TransitionTask(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	type request struct {
+		Status string `json:"status"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	output, err := h.usecase.TransitionTask(c.Request.Context(), id, req.Status)
+	if err != nil {
+		if errors.Is(err, task.ErrInvalidStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+			return
+		}
+		if errors.Is(err, task.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrInvalidTransition) || errors.Is(err, task.ErrOpenSubtasks) ||
+			errors.Is(err, task.ErrOpenBlockers) || errors.Is(err, task.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to update tasks")
+			return
+		}
+		writeServerError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusOK, toTaskResponse(output))
+}
+
+// AssignTask handles POST /tasks/:id/assignees with a body of
+// {"user_id": "..."} and returns the updated task.
+func (h *TaskHandler) AssignTask(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	type request struct {
+		UserID string `json:"user_id"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	output, err := h.usecase.AssignTask(c.Request.Context(), id, req.UserID)
+	if err != nil {
+		writeAssigneeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toTaskResponse(output))
+}
+
+func (h *TaskHandler) UnassignTask(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	if err := h.usecase.UnassignTask(c.Request.Context(), id, c.Param("user")); err != nil {
+		writeAssigneeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func writeAssigneeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, task.ErrInvalidAssignee):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id"})
+	case errors.Is(err, task.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, task.ErrAssigneeNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "assignee not found"})
+	case errors.Is(err, task.ErrTooManyAssignees), errors.Is(err, task.ErrTaskFinished):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, task.ErrForbidden):
+		problem.Write(c, http.StatusForbidden, "not allowed to update tasks")
+	default:
+		writeServerError(c, err, "internal server error")
+	}
+}
+
+// DeleteTask handles DELETE /tasks/:id, which moves the task and its subtasks
+// to the trash.
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	if err := h.usecase.DeleteTask(c.Request.Context(), id); err != nil {
+		if errors.Is(err, task.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to delete tasks")
+			return
+		}
+		writeServerError(c, err, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTrash handles GET /trash.
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	tasks, err := h.usecase.GetTrash(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to read tasks")
+			return
+		}
+		writeServerError(c, err, "failed to get trash")
+		return
+	}
+	c.JSON(http.StatusOK, toTaskResponses(tasks))
+}
+
+// RestoreTask handles POST /tasks/:id/restore.
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	output, err := h.usecase.RestoreTask(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, task.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found in trash"})
+		case errors.Is(err, task.ErrParentDeleted):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, task.ErrForbidden):
+			problem.Write(c, http.StatusForbidden, "not allowed to restore tasks")
+		default:
+			writeServerError(c, err, "internal server error")
+		}
+		return
+	}
+	c.JSON(http.StatusOK, toTaskResponse(output))
+}
+
+func (h *TaskHandler) AddDependency(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	type request struct {
+		BlockerID string `json:"blocker_id"`
+	}
+	var req request
+	if !decodeJSON(c, &req) {
+		return
+	}
+	if _, err := uuid.Parse(req.BlockerID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid blocker_id"})
+		return
+	}
+	if err := h.usecase.AddDependency(c.Request.Context(), id, req.BlockerID); err != nil {
+		if errors.Is(err, task.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrBlockerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "blocker task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrDependencyCycle) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to update tasks")
+			return
+		}
+		writeServerError(c, err, "internal server error")
+		return
+	}
+	c.JSON(http.StatusCreated, DependencyResponse{TaskID: id, BlockerID: req.BlockerID})
+}
+
+func (h *TaskHandler) RemoveDependency(c *gin.Context) {
+	id := c.Param("id")
+	blockerID := c.Param("blocker_id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	if _, err := uuid.Parse(blockerID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid blocker_id"})
+		return
+	}
+	if err := h.usecase.RemoveDependency(c.Request.Context(), id, blockerID); err != nil {
+		if errors.Is(err, task.ErrDependencyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "dependency not found"})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to update tasks")
+			return
+		}
+		writeServerError(c, err, "internal server error")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TaskHandler) GetDependencyGraph(c *gin.Context) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	graph, err := h.usecase.GetDependencyGraph(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, task.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if errors.Is(err, task.ErrForbidden) {
+			problem.Write(c, http.StatusForbidden, "not allowed to read tasks")
+			return
+		}
+		writeServerError(c, err, "failed to get dependency graph")
+		return
+	}
+	response := DependencyGraphResponse{
+		Nodes: toTaskResponses(graph.Tasks),
+		Edges: make([]DependencyResponse, 0, len(graph.Edges)),
+	}
+	for _, edge := range graph.Edges {
+		response.Edges = append(response.Edges, DependencyResponse{TaskID: edge.TaskID, BlockerID: edge.BlockerID})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// writeValidationError renders the 400 response for task field validation
+// errors and reports whether err was one of them.
+func writeValidationError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, task.ErrInvalidTitle), errors.Is(err, task.ErrTitleBlank), errors.Is(err, task.ErrTitleTooLong):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid title"})
+	case errors.Is(err, task.ErrInvalidPriority):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid priority"})
+	case errors.Is(err, task.ErrDueBeforeCreation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid due_at"})
+	case errors.Is(err, task.ErrInvalidRecurrence):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recurrence"})
+	case errors.Is(err, task.ErrRecurrenceWithoutDueDate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recurrence requires due_at"})
+	case errors.Is(err, task.ErrHierarchyCycle):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "parent_id would create a cycle"})
+	case errors.Is(err, task.ErrHierarchyTooDeep):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "subtasks nested too deeply"})
+	default:
+		return false
+	}
+	return true
+}
+
+// validParentID writes a 400 response and returns false when parentID is set
+// but not a UUID.
+func validParentID(c *gin.Context, parentID string) bool {
+	if parentID == "" {
+		return true
+	}
+	if _, err := uuid.Parse(parentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid parent_id"})
+		return false
+	}
+	return true
+}
+
 func toTaskResponses(tasks []task.TaskOutput) []TaskResponse {
 	responses := make([]TaskResponse, 0, len(tasks))
 	for _, taskOutput := range tasks {
@@ -85,11 +613,56 @@ func toTaskResponses(tasks []task.TaskOutput) []TaskResponse {
 }
 
 func toTaskResponse(taskOutput task.TaskOutput) TaskResponse {
-	return TaskResponse{
-		ID:        taskOutput.ID,
-		Title:     taskOutput.Title,
-		Status:    taskOutput.Status,
-		CreatedAt: taskOutput.CreatedAt,
-		UpdatedAt: taskOutput.UpdatedAt,
+	response := TaskResponse{
+		ID:          taskOutput.ID,
+		WorkspaceID: taskOutput.WorkspaceID,
+		Title:       taskOutput.Title,
+		Status:      taskOutput.Status,
+		Priority:    taskOutput.Priority,
+		DueAt:       taskOutput.DueAt,
+		Progress: Progress{
+			Total:     taskOutput.Progress.Total,
+			Completed: taskOutput.Progress.Completed,
+		},
+		Assignees:    taskOutput.Assignees,
+		Labels:       taskOutput.Labels,
+		CommentCount: taskOutput.CommentCount,
+		CreatedAt:    taskOutput.CreatedAt,
+		UpdatedAt:    taskOutput.UpdatedAt,
+		DeletedAt:    taskOutput.DeletedAt,
+	}
+	if response.Assignees == nil {
+		response.Assignees = []string{}
+	}
+	if response.Labels == nil {
+		response.Labels = []string{}
 	}
+	if taskOutput.ProjectID != "" {
+		projectID := taskOutput.ProjectID
+		response.ProjectID = &projectID
+	}
+	if taskOutput.ParentID != "" {
+		parentID := taskOutput.ParentID
+		response.ParentID = &parentID
+	}
+	if taskOutput.Recurrence != "" {
+		recurrence := taskOutput.Recurrence
+		response.Recurrence = &recurrence
+	}
+	if taskOutput.SeriesID != "" {
+		seriesID := taskOutput.SeriesID
+		response.SeriesID = &seriesID
+	}
+	return response
+}
+
+func toTaskTreeResponses(trees []task.TaskTreeOutput) []TaskTreeResponse {
+	responses := make([]TaskTreeResponse, 0, len(trees))
+	for _, tree := range trees {
+		responses = append(responses, TaskTreeResponse{
+			TaskResponse: toTaskResponse(tree.TaskOutput),
+			Subtasks:     toTaskTreeResponses(tree.Subtasks),
+		})
+	}
+	return responses
 }
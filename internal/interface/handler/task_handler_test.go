@@ -2,8 +2,10 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,8 +17,10 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/ko44d/go-clean-hexapp/internal/interface/handler"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
 	"github.com/ko44d/go-clean-hexapp/internal/usecase/task"
 	"github.com/ko44d/go-clean-hexapp/internal/usecase/task/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
 )
 
 func TestTaskHandler(t *testing.T) {
@@ -66,7 +70,7 @@ var _ = Describe("Task Handler", func() {
 					},
 				}
 
-				mockInteractor.EXPECT().GetTasks(gomock.Any()).Return(expectedTasks, nil)
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), task.TaskFilter{}).Return(expectedTasks, nil)
 
 				router.GET("/tasks", taskHandler.GetTasks)
 				req, _ := http.NewRequest("GET", "/tasks", nil)
@@ -89,7 +93,7 @@ var _ = Describe("Task Handler", func() {
 
 		Context("when usecase returns an error", func() {
 			It("should return 500 with error message", func() {
-				mockInteractor.EXPECT().GetTasks(gomock.Any()).Return(nil, errors.New("database error"))
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), task.TaskFilter{}).Return(nil, errors.New("database error"))
 
 				router.GET("/tasks", taskHandler.GetTasks)
 				req, _ := http.NewRequest("GET", "/tasks", nil)
@@ -104,9 +108,120 @@ var _ = Describe("Task Handler", func() {
 			})
 		})
 
+		Context("when the deadline of the request passes", func() {
+			It("should return 504 with a problem response", func() {
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), task.TaskFilter{}).
+					Return(nil, fmt.Errorf("GetTasks: %w", context.DeadlineExceeded))
+
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusGatewayTimeout))
+				Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+			})
+		})
+
+		Context("when storage is unavailable", func() {
+			It("should return 503 with Retry-After", func() {
+				unavailable := &transaction.UnavailableError{RetryAfter: 2500 * time.Millisecond, Err: errors.New("circuit breaker is open")}
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), task.TaskFilter{}).Return(nil, fmt.Errorf("GetTasks: %w", unavailable))
+
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+				Expect(recorder.Header().Get("Retry-After")).To(Equal("3"))
+				Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+			})
+		})
+
+		Context("when filters are given", func() {
+			It("should pass them to the usecase", func() {
+				mockInteractor.EXPECT().
+					GetTasks(gomock.Any(), task.TaskFilter{Overdue: true, Priority: "high", Sort: "due_at"}).
+					Return([]task.TaskOutput{}, nil)
+
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks?overdue=true&priority=high&sort=due_at", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+			})
+
+			It("should pass repeated label parameters", func() {
+				mockInteractor.EXPECT().
+					GetTasks(gomock.Any(), task.TaskFilter{Labels: []string{"bug", "backend"}, LabelMatch: "all"}).
+					Return([]task.TaskOutput{{ID: "task-1", Labels: []string{"backend", "bug"}}}, nil)
+
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks?label=bug&label=backend&label_match=all", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+
+				var response []handler.TaskResponse
+				Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+				Expect(response[0].Labels).To(Equal([]string{"backend", "bug"}))
+			})
+
+			It("should return 400 for an unknown label_match", func() {
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), gomock.Any()).Return(nil, task.ErrInvalidLabelMatch)
+
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks?label=bug&label_match=some", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("should return 400 for a malformed overdue flag", func() {
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks?overdue=maybe", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+
+			It("should return 400 for an unknown priority", func() {
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), gomock.Any()).Return(nil, task.ErrInvalidPriority)
+
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks?priority=critical", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+
+				var response map[string]string
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response["error"]).To(Equal("invalid priority"))
+			})
+		})
+
+		Context("when the caller is not allowed to read tasks", func() {
+			It("should return 403 with a problem response", func() {
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), task.TaskFilter{}).Return(nil, task.ErrForbidden)
+
+				router.GET("/tasks", taskHandler.GetTasks)
+				req, _ := http.NewRequest("GET", "/tasks", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusForbidden))
+				Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+
+				var response problem.Details
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response.Status).To(Equal(http.StatusForbidden))
+				Expect(response.Title).To(Equal("Forbidden"))
+			})
+		})
+
 		Context("when there are no tasks", func() {
 			It("should return 200 with empty list", func() {
-				mockInteractor.EXPECT().GetTasks(gomock.Any()).Return([]task.TaskOutput{}, nil)
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), task.TaskFilter{}).Return([]task.TaskOutput{}, nil)
 
 				router.GET("/tasks", taskHandler.GetTasks)
 				req, _ := http.NewRequest("GET", "/tasks", nil)
@@ -122,13 +237,108 @@ var _ = Describe("Task Handler", func() {
 		})
 	})
 
+	Describe("GetProjectTasks", func() {
+		const projectID = "550e8400-e29b-41d4-a716-446655440009"
+
+		Context("when the project exists", func() {
+			It("should return 200 with the project's tasks", func() {
+				mockInteractor.EXPECT().
+					GetTasks(gomock.Any(), task.TaskFilter{ProjectID: projectID}).
+					Return([]task.TaskOutput{{ID: "task-1", ProjectID: projectID}}, nil)
+
+				router.GET("/projects/:id/tasks", taskHandler.GetProjectTasks)
+				req, _ := http.NewRequest("GET", "/projects/"+projectID+"/tasks", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+
+				var response []handler.TaskResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response).To(HaveLen(1))
+				Expect(response[0].ProjectID).To(HaveValue(Equal(projectID)))
+			})
+		})
+
+		Context("when the project does not exist", func() {
+			It("should return 404 with error message", func() {
+				mockInteractor.EXPECT().GetTasks(gomock.Any(), gomock.Any()).Return(nil, task.ErrProjectNotFound)
+
+				router.GET("/projects/:id/tasks", taskHandler.GetProjectTasks)
+				req, _ := http.NewRequest("GET", "/projects/"+projectID+"/tasks", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+
+				var response map[string]string
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response["error"]).To(Equal("project not found"))
+			})
+		})
+	})
+
+	Describe("GetTask", func() {
+		Context("when the task exists", func() {
+			It("should return 200 with the task", func() {
+				taskID := "550e8400-e29b-41d4-a716-446655440000"
+				mockInteractor.EXPECT().GetTask(gomock.Any(), taskID).Return(task.TaskOutput{
+					ID:          taskID,
+					WorkspaceID: "11111111-1111-1111-1111-111111111111",
+					Title:       "Test Task",
+					Status:      "todo",
+				}, nil)
+
+				router.GET("/tasks/:id", taskHandler.GetTask)
+				req, _ := http.NewRequest("GET", "/tasks/"+taskID, nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+
+				var response handler.TaskResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response.ID).To(Equal(taskID))
+				Expect(response.WorkspaceID).To(Equal("11111111-1111-1111-1111-111111111111"))
+			})
+		})
+
+		Context("when the task belongs to another workspace", func() {
+			It("should return 404 with error message", func() {
+				taskID := "550e8400-e29b-41d4-a716-446655440001"
+				mockInteractor.EXPECT().GetTask(gomock.Any(), taskID).Return(task.TaskOutput{}, task.ErrTaskNotFound)
+
+				router.GET("/tasks/:id", taskHandler.GetTask)
+				req, _ := http.NewRequest("GET", "/tasks/"+taskID, nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+
+				var response map[string]string
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response["error"]).To(Equal("task not found"))
+			})
+		})
+
+		Context("when the task ID is invalid UUID", func() {
+			It("should return 400 with error message", func() {
+				router.GET("/tasks/:id", taskHandler.GetTask)
+				req, _ := http.NewRequest("GET", "/tasks/not-a-uuid", nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
 	Describe("AddTask", func() {
 		Context("when request body is valid", func() {
 			It("should return 201", func() {
 				requestBody := map[string]string{"title": "New Task"}
 				jsonBody, _ := json.Marshal(requestBody)
 
-				mockInteractor.EXPECT().AddTask(gomock.Any(), "New Task").Return(nil)
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: "New Task"}).Return(nil)
 
 				router.POST("/tasks", taskHandler.AddTask)
 				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
@@ -155,12 +365,62 @@ var _ = Describe("Task Handler", func() {
 			})
 		})
 
+		Context("when request body is not strict JSON", func() {
+			DescribeTable("should return 400 naming the malformed field",
+				func(body string, field string) {
+					router.POST("/tasks", taskHandler.AddTask)
+					req, _ := http.NewRequest("POST", "/tasks", bytes.NewBufferString(body))
+					req.Header.Set("Content-Type", "application/json; charset=utf-8")
+					router.ServeHTTP(recorder, req)
+
+					Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+
+					var response map[string]string
+					Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+					Expect(response["error"]).To(Equal("invalid request body"))
+					Expect(response["field"]).To(Equal(field))
+					Expect(response["detail"]).NotTo(BeEmpty())
+				},
+				Entry("unknown field", `{"title": "New Task", "titel": "typo"}`, "titel"),
+				Entry("wrong type", `{"title": 42}`, "title"),
+				Entry("malformed time", `{"title": "New Task", "due_at": "tomorrow"}`, "due_at"),
+				Entry("trailing data", `{"title": "New Task"} {"title": "Another"}`, ""),
+				Entry("empty body", ``, ""),
+			)
+		})
+
+		Context("when request body is not JSON", func() {
+			It("should return 415", func() {
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBufferString("title=New+Task"))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusUnsupportedMediaType))
+				Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+			})
+		})
+
+		Context("when request body is too large", func() {
+			It("should return 413", func() {
+				jsonBody, _ := json.Marshal(map[string]string{"title": string(bytes.Repeat([]byte("a"), 1<<20))})
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge))
+				Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+			})
+		})
+
 		Context("when title is empty", func() {
 			It("should return 400 with error message", func() {
 				requestBody := map[string]string{"title": ""}
 				jsonBody, _ := json.Marshal(requestBody)
 
-				mockInteractor.EXPECT().AddTask(gomock.Any(), "").Return(task.ErrInvalidTitle)
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: ""}).Return(task.ErrInvalidTitle)
 
 				router.POST("/tasks", taskHandler.AddTask)
 				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
@@ -181,7 +441,7 @@ var _ = Describe("Task Handler", func() {
 				requestBody := map[string]string{"title": "   "}
 				jsonBody, _ := json.Marshal(requestBody)
 
-				mockInteractor.EXPECT().AddTask(gomock.Any(), "   ").Return(task.ErrTitleBlank)
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: "   "}).Return(task.ErrTitleBlank)
 
 				router.POST("/tasks", taskHandler.AddTask)
 				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
@@ -202,7 +462,7 @@ var _ = Describe("Task Handler", func() {
 				requestBody := map[string]string{"title": "Test Task"}
 				jsonBody, _ := json.Marshal(requestBody)
 
-				mockInteractor.EXPECT().AddTask(gomock.Any(), "Test Task").Return(task.ErrInvalidTitle)
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: "Test Task"}).Return(task.ErrInvalidTitle)
 
 				router.POST("/tasks", taskHandler.AddTask)
 				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
@@ -223,7 +483,7 @@ var _ = Describe("Task Handler", func() {
 				requestBody := map[string]string{"title": "Test Task"}
 				jsonBody, _ := json.Marshal(requestBody)
 
-				mockInteractor.EXPECT().AddTask(gomock.Any(), "Test Task").Return(task.ErrTitleBlank)
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: "Test Task"}).Return(task.ErrTitleBlank)
 
 				router.POST("/tasks", taskHandler.AddTask)
 				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
@@ -239,12 +499,145 @@ var _ = Describe("Task Handler", func() {
 			})
 		})
 
+		Context("when priority and due date are given", func() {
+			It("should return 201", func() {
+				dueAt := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+				jsonBody := []byte(`{"title": "New Task", "priority": "high", "due_at": "2030-01-02T09:00:00Z"}`)
+
+				mockInteractor.EXPECT().AddTask(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, input task.AddTaskInput) error {
+						Expect(input.Priority).To(Equal("high"))
+						Expect(input.DueAt).To(HaveValue(BeTemporally("==", dueAt)))
+						return nil
+					},
+				)
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusCreated))
+			})
+
+			It("should return 400 for an invalid recurrence", func() {
+				jsonBody := []byte(`{"title": "New Task", "due_at": "2030-01-02T09:00:00Z", "recurrence": "FREQ=YEARLY"}`)
+
+				mockInteractor.EXPECT().AddTask(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, input task.AddTaskInput) error {
+						Expect(input.Recurrence).To(Equal("FREQ=YEARLY"))
+						return task.ErrInvalidRecurrence
+					},
+				)
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+
+				var response map[string]string
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response["error"]).To(Equal("invalid recurrence"))
+			})
+
+			It("should return 400 when the due date is before creation", func() {
+				jsonBody := []byte(`{"title": "New Task", "due_at": "2001-01-01T00:00:00Z"}`)
+
+				mockInteractor.EXPECT().AddTask(gomock.Any(), gomock.Any()).Return(task.ErrDueBeforeCreation)
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+
+				var response map[string]string
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response["error"]).To(Equal("invalid due_at"))
+			})
+		})
+
+		Context("when the task is added to a project", func() {
+			It("should return 201", func() {
+				projectID := "550e8400-e29b-41d4-a716-446655440009"
+				requestBody := map[string]string{"title": "New Task", "project_id": projectID}
+				jsonBody, _ := json.Marshal(requestBody)
+
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: "New Task", ProjectID: projectID}).Return(nil)
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("when the project is archived", func() {
+			It("should return 409 with error message", func() {
+				projectID := "550e8400-e29b-41d4-a716-446655440009"
+				requestBody := map[string]string{"title": "New Task", "project_id": projectID}
+				jsonBody, _ := json.Marshal(requestBody)
+
+				mockInteractor.EXPECT().AddTask(gomock.Any(), gomock.Any()).Return(task.ErrProjectArchived)
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusConflict))
+
+				var response map[string]string
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response["error"]).To(Equal("project is archived"))
+			})
+		})
+
+		Context("when the project id is invalid", func() {
+			It("should return 400 with error message", func() {
+				requestBody := map[string]string{"title": "New Task", "project_id": "not-a-uuid"}
+				jsonBody, _ := json.Marshal(requestBody)
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the caller is not allowed to create tasks", func() {
+			It("should return 403 with a problem response", func() {
+				requestBody := map[string]string{"title": "Test Task"}
+				jsonBody, _ := json.Marshal(requestBody)
+
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: "Test Task"}).Return(task.ErrForbidden)
+
+				router.POST("/tasks", taskHandler.AddTask)
+				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusForbidden))
+				Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+			})
+		})
+
 		Context("when usecase returns internal error", func() {
 			It("should return 500 with error message", func() {
 				requestBody := map[string]string{"title": "Test Task"}
 				jsonBody, _ := json.Marshal(requestBody)
 
-				mockInteractor.EXPECT().AddTask(gomock.Any(), "Test Task").Return(errors.New("database error"))
+				mockInteractor.EXPECT().AddTask(gomock.Any(), task.AddTaskInput{Title: "Test Task"}).Return(errors.New("database error"))
 
 				router.POST("/tasks", taskHandler.AddTask)
 				req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(jsonBody))
@@ -266,12 +659,38 @@ var _ = Describe("Task Handler", func() {
 			It("should return 200", func() {
 				taskID := "550e8400-e29b-41d4-a716-446655440000"
 
-				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID).Return(nil)
+				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID, false).Return(nil)
+
+				router.POST("/tasks/complete", taskHandler.CompleteTask)
+				req, _ := http.NewRequest("POST", "/tasks/complete?id="+taskID, nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when the task has open subtasks", func() {
+			It("should return 409 unless cascading", func() {
+				taskID := "550e8400-e29b-41d4-a716-446655440000"
+
+				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID, false).Return(task.ErrOpenSubtasks)
 
 				router.POST("/tasks/complete", taskHandler.CompleteTask)
 				req, _ := http.NewRequest("POST", "/tasks/complete?id="+taskID, nil)
 				router.ServeHTTP(recorder, req)
 
+				Expect(recorder.Code).To(Equal(http.StatusConflict))
+			})
+
+			It("should pass cascade to the usecase", func() {
+				taskID := "550e8400-e29b-41d4-a716-446655440000"
+
+				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID, true).Return(nil)
+
+				router.POST("/tasks/complete", taskHandler.CompleteTask)
+				req, _ := http.NewRequest("POST", "/tasks/complete?cascade=true&id="+taskID, nil)
+				router.ServeHTTP(recorder, req)
+
 				Expect(recorder.Code).To(Equal(http.StatusOK))
 			})
 		})
@@ -310,7 +729,7 @@ var _ = Describe("Task Handler", func() {
 			It("should return 404 with error message", func() {
 				taskID := "550e8400-e29b-41d4-a716-446655440001"
 
-				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID).Return(task.ErrTaskNotFound)
+				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID, false).Return(task.ErrTaskNotFound)
 
 				router.POST("/tasks/complete", taskHandler.CompleteTask)
 				req, _ := http.NewRequest("POST", "/tasks/complete?id="+taskID, nil)
@@ -325,11 +744,26 @@ var _ = Describe("Task Handler", func() {
 			})
 		})
 
+		Context("when the caller is not allowed to update tasks", func() {
+			It("should return 403 with a problem response", func() {
+				taskID := "550e8400-e29b-41d4-a716-446655440003"
+
+				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID, false).Return(task.ErrForbidden)
+
+				router.POST("/tasks/complete", taskHandler.CompleteTask)
+				req, _ := http.NewRequest("POST", "/tasks/complete?id="+taskID, nil)
+				router.ServeHTTP(recorder, req)
+
+				Expect(recorder.Code).To(Equal(http.StatusForbidden))
+				Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+			})
+		})
+
 		Context("when usecase returns internal error", func() {
 			It("should return 500 with error message", func() {
 				taskID := "550e8400-e29b-41d4-a716-446655440002"
 
-				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID).Return(errors.New("database error"))
+				mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID, false).Return(errors.New("database error"))
 
 				router.POST("/tasks/complete", taskHandler.CompleteTask)
 				req, _ := http.NewRequest("POST", "/tasks/complete?id="+taskID, nil)
@@ -344,4 +778,377 @@ var _ = Describe("Task Handler", func() {
 			})
 		})
 	})
+
+	Describe("TransitionTask", func() {
+		const taskID = "550e8400-e29b-41d4-a716-446655440000"
+
+		transition := func(body string) {
+			router.POST("/tasks/:id/transitions", taskHandler.TransitionTask)
+			req, _ := http.NewRequest("POST", "/tasks/"+taskID+"/transitions", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+		}
+
+		Context("when the transition is allowed", func() {
+			It("should return 200 with the updated task", func() {
+				mockInteractor.EXPECT().
+					TransitionTask(gomock.Any(), taskID, "in_progress").
+					Return(task.TaskOutput{ID: taskID, Status: "in_progress"}, nil)
+
+				transition(`{"status": "in_progress"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+
+				var response handler.TaskResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response.Status).To(Equal("in_progress"))
+			})
+		})
+
+		Context("when the transition is not allowed", func() {
+			It("should return 409 with error message", func() {
+				mockInteractor.EXPECT().
+					TransitionTask(gomock.Any(), taskID, "blocked").
+					Return(task.TaskOutput{}, fmt.Errorf("%w: complete -> blocked", task.ErrInvalidTransition))
+
+				transition(`{"status": "blocked"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusConflict))
+
+				var response map[string]string
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response["error"]).To(Equal("invalid status transition: complete -> blocked"))
+			})
+		})
+
+		Context("when the status is unknown", func() {
+			It("should return 400 with error message", func() {
+				mockInteractor.EXPECT().
+					TransitionTask(gomock.Any(), taskID, "done").
+					Return(task.TaskOutput{}, task.ErrInvalidStatus)
+
+				transition(`{"status": "done"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the task does not exist", func() {
+			It("should return 404 with error message", func() {
+				mockInteractor.EXPECT().
+					TransitionTask(gomock.Any(), taskID, "todo").
+					Return(task.TaskOutput{}, task.ErrTaskNotFound)
+
+				transition(`{"status": "todo"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("UpdateTask", func() {
+		const taskID = "550e8400-e29b-41d4-a716-446655440000"
+
+		update := func(body string) {
+			router.PUT("/tasks/:id", taskHandler.UpdateTask)
+			req, _ := http.NewRequest("PUT", "/tasks/"+taskID, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+		}
+
+		Context("when the update is valid", func() {
+			It("should return 200 with the updated task", func() {
+				dueAt := time.Date(2030, 1, 2, 9, 0, 0, 0, time.UTC)
+				mockInteractor.EXPECT().
+					UpdateTask(gomock.Any(), taskID, gomock.Any()).
+					Return(task.TaskOutput{ID: taskID, Title: "Renamed", Priority: "urgent", DueAt: &dueAt, Recurrence: "FREQ=WEEKLY", SeriesID: taskID}, nil)
+
+				update(`{"title": "Renamed", "priority": "urgent", "due_at": "2030-01-02T09:00:00Z", "recurrence": "FREQ=WEEKLY"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusOK))
+
+				var response handler.TaskResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response.Priority).To(Equal("urgent"))
+				Expect(response.DueAt).To(HaveValue(BeTemporally("==", dueAt)))
+				Expect(response.Recurrence).To(HaveValue(Equal("FREQ=WEEKLY")))
+				Expect(response.SeriesID).To(HaveValue(Equal(taskID)))
+			})
+		})
+
+		Context("when the priority is unknown", func() {
+			It("should return 400 with error message", func() {
+				mockInteractor.EXPECT().UpdateTask(gomock.Any(), taskID, gomock.Any()).Return(task.TaskOutput{}, task.ErrInvalidPriority)
+
+				update(`{"title": "Renamed", "priority": "critical"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the task does not exist", func() {
+			It("should return 404 with error message", func() {
+				mockInteractor.EXPECT().UpdateTask(gomock.Any(), taskID, gomock.Any()).Return(task.TaskOutput{}, task.ErrTaskNotFound)
+
+				update(`{"title": "Renamed"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the task was changed concurrently", func() {
+			It("should return 409 with error message", func() {
+				mockInteractor.EXPECT().UpdateTask(gomock.Any(), taskID, gomock.Any()).
+					Return(task.TaskOutput{}, fmt.Errorf("UpdateTask: %w", task.ErrVersionConflict))
+
+				update(`{"title": "Renamed"}`)
+
+				Expect(recorder.Code).To(Equal(http.StatusConflict))
+				Expect(recorder.Body.String()).To(ContainSubstring("changed concurrently"))
+			})
+		})
+	})
+
+	Describe("GetSubtasks", func() {
+		const taskID = "550e8400-e29b-41d4-a716-446655440000"
+		const childID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+		It("should return the nested subtasks with progress", func() {
+			mockInteractor.EXPECT().GetSubtasks(gomock.Any(), taskID).Return([]task.TaskTreeOutput{
+				{
+					TaskOutput: task.TaskOutput{ID: childID, ParentID: taskID, Progress: task.ProgressOutput{Total: 2, Completed: 1}},
+					Subtasks:   []task.TaskTreeOutput{{TaskOutput: task.TaskOutput{ID: "grandchild", ParentID: childID}}},
+				},
+			}, nil)
+
+			router.GET("/tasks/:id/subtasks", taskHandler.GetSubtasks)
+			req, _ := http.NewRequest("GET", "/tasks/"+taskID+"/subtasks", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			var response []handler.TaskTreeResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			Expect(err).To(BeNil())
+			Expect(response).To(HaveLen(1))
+			Expect(response[0].ParentID).To(HaveValue(Equal(taskID)))
+			Expect(response[0].Progress).To(Equal(handler.Progress{Total: 2, Completed: 1}))
+			Expect(response[0].Subtasks[0].ID).To(Equal("grandchild"))
+		})
+
+		It("should return 404 when the task does not exist", func() {
+			mockInteractor.EXPECT().GetSubtasks(gomock.Any(), taskID).Return(nil, task.ErrTaskNotFound)
+
+			router.GET("/tasks/:id/subtasks", taskHandler.GetSubtasks)
+			req, _ := http.NewRequest("GET", "/tasks/"+taskID+"/subtasks", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should reject an invalid parent_id on create", func() {
+			router.POST("/tasks", taskHandler.AddTask)
+			req, _ := http.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"title": "Subtask", "parent_id": "nope"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("Dependencies", func() {
+		const taskID = "550e8400-e29b-41d4-a716-446655440000"
+		const blockerID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+		addDependency := func(body string) {
+			router.POST("/tasks/:id/dependencies", taskHandler.AddDependency)
+			req, _ := http.NewRequest("POST", "/tasks/"+taskID+"/dependencies", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+		}
+
+		It("should add a dependency", func() {
+			mockInteractor.EXPECT().AddDependency(gomock.Any(), taskID, blockerID).Return(nil)
+
+			addDependency(`{"blocker_id": "` + blockerID + `"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusCreated))
+		})
+
+		It("should return 409 when the dependency would form a cycle", func() {
+			mockInteractor.EXPECT().AddDependency(gomock.Any(), taskID, blockerID).Return(task.ErrDependencyCycle)
+
+			addDependency(`{"blocker_id": "` + blockerID + `"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should return 404 when the blocker does not exist", func() {
+			mockInteractor.EXPECT().AddDependency(gomock.Any(), taskID, blockerID).Return(task.ErrBlockerNotFound)
+
+			addDependency(`{"blocker_id": "` + blockerID + `"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			Expect(recorder.Body.String()).To(ContainSubstring("blocker task not found"))
+		})
+
+		It("should reject an invalid blocker_id", func() {
+			addDependency(`{"blocker_id": "nope"}`)
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 404 when removing a missing dependency", func() {
+			mockInteractor.EXPECT().RemoveDependency(gomock.Any(), taskID, blockerID).Return(task.ErrDependencyNotFound)
+
+			router.DELETE("/tasks/:id/dependencies/:blocker_id", taskHandler.RemoveDependency)
+			req, _ := http.NewRequest("DELETE", "/tasks/"+taskID+"/dependencies/"+blockerID, nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return the dependency graph", func() {
+			mockInteractor.EXPECT().GetDependencyGraph(gomock.Any(), taskID).Return(task.DependencyGraphOutput{
+				Tasks: []task.TaskOutput{{ID: taskID}, {ID: blockerID}},
+				Edges: []task.DependencyOutput{{TaskID: taskID, BlockerID: blockerID}},
+			}, nil)
+
+			router.GET("/tasks/:id/dependency-graph", taskHandler.GetDependencyGraph)
+			req, _ := http.NewRequest("GET", "/tasks/"+taskID+"/dependency-graph", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			var response handler.DependencyGraphResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			Expect(err).To(BeNil())
+			Expect(response.Nodes).To(HaveLen(2))
+			Expect(response.Edges).To(Equal([]handler.DependencyResponse{{TaskID: taskID, BlockerID: blockerID}}))
+		})
+
+		It("should return 409 when completing a task with open blockers", func() {
+			mockInteractor.EXPECT().CompleteTask(gomock.Any(), taskID, false).Return(task.ErrOpenBlockers)
+
+			router.POST("/tasks/complete", taskHandler.CompleteTask)
+			req, _ := http.NewRequest("POST", "/tasks/complete?id="+taskID, nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+	})
+
+	Describe("Assignees", func() {
+		const taskID = "550e8400-e29b-41d4-a716-446655440000"
+
+		It("should assign a user and return the task", func() {
+			mockInteractor.EXPECT().AssignTask(gomock.Any(), taskID, "alice").
+				Return(task.TaskOutput{ID: taskID, Assignees: []string{"alice"}}, nil)
+
+			router.POST("/tasks/:id/assignees", taskHandler.AssignTask)
+			req, _ := http.NewRequest("POST", "/tasks/"+taskID+"/assignees", bytes.NewBufferString(`{"user_id": "alice"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			var response handler.TaskResponse
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Assignees).To(Equal([]string{"alice"}))
+		})
+
+		It("should return 409 when the task is finished", func() {
+			mockInteractor.EXPECT().AssignTask(gomock.Any(), taskID, "alice").Return(task.TaskOutput{}, task.ErrTaskFinished)
+
+			router.POST("/tasks/:id/assignees", taskHandler.AssignTask)
+			req, _ := http.NewRequest("POST", "/tasks/"+taskID+"/assignees", bytes.NewBufferString(`{"user_id": "alice"}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should unassign a user", func() {
+			mockInteractor.EXPECT().UnassignTask(gomock.Any(), taskID, "alice").Return(nil)
+
+			router.DELETE("/tasks/:id/assignees/:user", taskHandler.UnassignTask)
+			req, _ := http.NewRequest("DELETE", "/tasks/"+taskID+"/assignees/alice", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should pass assignee=me to the usecase", func() {
+			mockInteractor.EXPECT().GetTasks(gomock.Any(), task.TaskFilter{Assignee: "me"}).Return([]task.TaskOutput{}, nil)
+
+			router.GET("/tasks", taskHandler.GetTasks)
+			req, _ := http.NewRequest("GET", "/tasks?assignee=me", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Describe("DeleteTask", func() {
+		const taskID = "550e8400-e29b-41d4-a716-446655440000"
+
+		BeforeEach(func() {
+			router.DELETE("/tasks/:id", taskHandler.DeleteTask)
+		})
+
+		It("should return 204 when the task is deleted", func() {
+			mockInteractor.EXPECT().DeleteTask(gomock.Any(), taskID).Return(nil)
+
+			req, _ := http.NewRequest("DELETE", "/tasks/"+taskID, nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should return 403 when deleting is not allowed", func() {
+			mockInteractor.EXPECT().DeleteTask(gomock.Any(), taskID).Return(task.ErrForbidden)
+
+			req, _ := http.NewRequest("DELETE", "/tasks/"+taskID, nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should list deleted tasks in the trash", func() {
+			deletedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+			mockInteractor.EXPECT().GetTrash(gomock.Any()).Return([]task.TaskOutput{{ID: taskID, DeletedAt: &deletedAt}}, nil)
+
+			router.GET("/trash", taskHandler.GetTrash)
+			req, _ := http.NewRequest("GET", "/trash", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			var response []handler.TaskResponse
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+			Expect(response[0].DeletedAt).To(HaveValue(Equal(deletedAt)))
+		})
+
+		It("should restore a task from the trash", func() {
+			mockInteractor.EXPECT().RestoreTask(gomock.Any(), taskID).Return(task.TaskOutput{ID: taskID}, nil)
+
+			router.POST("/tasks/:id/restore", taskHandler.RestoreTask)
+			req, _ := http.NewRequest("POST", "/tasks/"+taskID+"/restore", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Body.String()).NotTo(ContainSubstring("deleted_at"))
+		})
+
+		It("should return 409 when restoring below a deleted parent", func() {
+			mockInteractor.EXPECT().RestoreTask(gomock.Any(), taskID).Return(task.TaskOutput{}, task.ErrParentDeleted)
+
+			router.POST("/tasks/:id/restore", taskHandler.RestoreTask)
+			req, _ := http.NewRequest("POST", "/tasks/"+taskID+"/restore", nil)
+			router.ServeHTTP(recorder, req)
+
+			Expect(recorder.Code).To(Equal(http.StatusConflict))
+		})
+	})
 })
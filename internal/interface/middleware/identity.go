@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+const (
+	HeaderUserID         = "X-User-ID"
+	HeaderUserRoles      = "X-User-Roles"
+	HeaderUserWorkspaces = "X-User-Workspaces"
+)
+
+// Identity attaches the caller's principal to the request context. The headers
+// are expected to be set by a trusted gateway that has already authenticated
+// the caller; requests without them run as an anonymous principal.
+func Identity() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := authz.Principal{
+			ID:         strings.TrimSpace(c.GetHeader(HeaderUserID)),
+			Roles:      parseList(c.GetHeader(HeaderUserRoles)),
+			Workspaces: parseWorkspaces(c.GetHeader(HeaderUserWorkspaces)),
+		}
+		c.Request = c.Request.WithContext(authz.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+func parseList(header string) []string {
+	values := []string{}
+	for _, value := range strings.Split(header, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseWorkspaces normalises workspace IDs so they compare equal to the
+// canonical form used by Workspace. Malformed entries are dropped.
+func parseWorkspaces(header string) []string {
+	workspaces := []string{}
+	for _, value := range parseList(header) {
+		if id, err := uuid.Parse(value); err == nil {
+			workspaces = append(workspaces, id.String())
+		}
+	}
+	return workspaces
+}
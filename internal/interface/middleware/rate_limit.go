@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit"
+)
+
+// HeaderAPIKey carries the API key of a client. Only keys listed in
+// RateLimits.APIKeys tell clients apart.
+const HeaderAPIKey = "X-API-Key"
+
+// RateLimits are the limits applied by RateLimit. Routes are keyed by method
+// and route pattern, such as "POST /tasks", and each has buckets of its own;
+// the other routes share buckets limited by Default. A zero limit leaves
+// requests unlimited. APIKeys holds the SHA-256 digests, in hex, of the API
+// keys that are known to be valid.
+type RateLimits struct {
+	Default ratelimit.Limit
+	Routes  map[string]ratelimit.Limit
+	APIKeys map[string]bool
+}
+
+// RateLimit gives every client a token bucket per limit and answers 429 once
+// it is empty. Clients are told apart by user, then by a valid API key, then
+// by IP address, so Identity must run first. Responses carry the RateLimit-Limit,
+// RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers, and
+// Retry-After when refused. When the store fails, requests are let through
+// rather than failing with it.
+func RateLimit(store ratelimit.Store, limits RateLimits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		limit, ok := limits.Routes[route]
+		if !ok {
+			route, limit = "*", limits.Default
+		}
+		if limit.IsZero() {
+			c.Next()
+			return
+		}
+
+		decision, err := store.Take(c.Request.Context(), route+" "+clientKey(c, limits.APIKeys), limit)
+		if err != nil {
+			log.Printf("middleware: rate limit %s: %v", route, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("RateLimit-Reset", seconds(decision.Reset))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limit.Burst, seconds(limit.Period)))
+		if !decision.Allowed {
+			c.Header("Retry-After", seconds(decision.RetryAfter))
+			problem.Write(c, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientKey identifies the caller. Unknown API keys are ignored, since any
+// client could send a new one with each request. Keys are hashed so stores
+// never hold them.
+func clientKey(c *gin.Context, apiKeys map[string]bool) string {
+	if principal, ok := authz.PrincipalFromContext(c.Request.Context()); ok && principal.ID != "" {
+		return "user:" + principal.ID
+	}
+	if key := c.GetHeader(HeaderAPIKey); key != "" {
+		sum := sha256.Sum256([]byte(key))
+		if digest := hex.EncodeToString(sum[:]); apiKeys[digest] {
+			return "key:" + digest[:32]
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// seconds rounds d up to whole seconds, as the headers carry.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(max(d, 0).Seconds())), 10)
+}
@@ -0,0 +1,146 @@
+package middleware_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit/mocks"
+)
+
+var _ = Describe("RateLimit", func() {
+	var (
+		ctrl      *gomock.Controller
+		mockStore *mocks.MockStore
+		recorder  *httptest.ResponseRecorder
+		limits    middleware.RateLimits
+	)
+
+	perMinute := ratelimit.Limit{Burst: 60, Period: time.Minute}
+	tight := ratelimit.Limit{Burst: 2, Period: 10 * time.Second}
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		ctrl = gomock.NewController(GinkgoT())
+		mockStore = mocks.NewMockStore(ctrl)
+		recorder = httptest.NewRecorder()
+		limits = middleware.RateLimits{Default: perMinute, Routes: map[string]ratelimit.Limit{"POST /tasks": tight}}
+	})
+
+	digest := func(key string) string {
+		sum := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(sum[:])
+	}
+
+	serve := func(method string, header http.Header) {
+		router := gin.New()
+		router.Use(middleware.Identity(), middleware.RateLimit(mockStore, limits))
+		router.GET("/tasks", func(c *gin.Context) { c.Status(http.StatusOK) })
+		router.POST("/tasks", func(c *gin.Context) { c.Status(http.StatusCreated) })
+		req, _ := http.NewRequest(method, "/tasks", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		for name, values := range header {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+		router.ServeHTTP(recorder, req)
+	}
+
+	It("should let requests through and describe the bucket", func() {
+		mockStore.EXPECT().Take(gomock.Any(), "* user:alice", perMinute).
+			Return(perMinute.Decide(true, 41.5), nil)
+
+		serve(http.MethodGet, http.Header{middleware.HeaderUserID: {"alice"}})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("RateLimit-Limit")).To(Equal("60"))
+		Expect(recorder.Header().Get("RateLimit-Remaining")).To(Equal("41"))
+		Expect(recorder.Header().Get("RateLimit-Reset")).To(Equal("19"))
+		Expect(recorder.Header().Get("RateLimit-Policy")).To(Equal("60;w=60"))
+		Expect(recorder.Header().Get("Retry-After")).To(BeEmpty())
+	})
+
+	It("should answer 429 with Retry-After once the bucket is empty", func() {
+		mockStore.EXPECT().Take(gomock.Any(), "POST /tasks user:alice", tight).
+			Return(tight.Decide(false, 0.25), nil)
+
+		serve(http.MethodPost, http.Header{middleware.HeaderUserID: {"alice"}})
+
+		Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(recorder.Header().Get("Content-Type")).To(HavePrefix(problem.ContentType))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("4"))
+		Expect(recorder.Header().Get("RateLimit-Remaining")).To(Equal("0"))
+	})
+
+	It("should tell clients apart by user before API key", func() {
+		limits.APIKeys = map[string]bool{digest("secret"): true}
+		mockStore.EXPECT().Take(gomock.Any(), "* user:alice", perMinute).Return(perMinute.Decide(true, 59), nil)
+
+		serve(http.MethodGet, http.Header{middleware.HeaderAPIKey: {"secret"}, middleware.HeaderUserID: {"alice"}})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should tell anonymous clients apart by a valid API key", func() {
+		limits.APIKeys = map[string]bool{digest("secret"): true}
+		mockStore.EXPECT().Take(gomock.Any(), gomock.Any(), perMinute).
+			DoAndReturn(func(_ any, key string, _ ratelimit.Limit) (ratelimit.Decision, error) {
+				Expect(key).To(HavePrefix("* key:"))
+				Expect(key).NotTo(ContainSubstring("secret"))
+				return perMinute.Decide(true, 59), nil
+			})
+
+		serve(http.MethodGet, http.Header{middleware.HeaderAPIKey: {"secret"}})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should ignore unknown API keys", func() {
+		limits.APIKeys = map[string]bool{digest("secret"): true}
+		mockStore.EXPECT().Take(gomock.Any(), "* ip:192.0.2.1", perMinute).Return(perMinute.Decide(true, 59), nil)
+
+		serve(http.MethodGet, http.Header{middleware.HeaderAPIKey: {"made-up"}})
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should fall back to the IP address of anonymous clients", func() {
+		mockStore.EXPECT().Take(gomock.Any(), "* ip:192.0.2.1", perMinute).Return(perMinute.Decide(true, 59), nil)
+
+		serve(http.MethodGet, nil)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should let requests through when the store fails", func() {
+		mockStore.EXPECT().Take(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(ratelimit.Decision{}, errors.New("connection refused"))
+
+		serve(http.MethodGet, nil)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("RateLimit-Limit")).To(BeEmpty())
+	})
+
+	It("should leave routes without a limit alone", func() {
+		limits.Default = ratelimit.Limit{}
+
+		serve(http.MethodGet, nil)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(strings.Join(recorder.Header().Values("RateLimit-Limit"), "")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+)
+
+const HeaderReadYourWrites = "X-Read-Your-Writes"
+
+// ReadYourWrites keeps the reads of a request off read replicas when it
+// writes, as the usecases read the tasks they are about to change, or when
+// the caller sends X-Read-Your-Writes: true because it just wrote and must
+// see the result.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requested, _ := strconv.ParseBool(c.GetHeader(HeaderReadYourWrites))
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			requested = true
+		}
+		if requested {
+			c.Request = c.Request.WithContext(transaction.WithReadYourWrites(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+)
+
+var _ = Describe("ReadYourWrites", func() {
+	var (
+		router *gin.Engine
+		marked bool
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(middleware.ReadYourWrites())
+		router.Any("/", func(c *gin.Context) {
+			marked = transaction.ReadYourWrites(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+		marked = false
+	})
+
+	serve := func(method string, header string) {
+		req, _ := http.NewRequest(method, "/", nil)
+		if header != "" {
+			req.Header.Set(middleware.HeaderReadYourWrites, header)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	It("should let plain reads use replicas", func() {
+		serve(http.MethodGet, "")
+
+		Expect(marked).To(BeFalse())
+	})
+
+	It("should keep reads on the primary when the caller asks to", func() {
+		serve(http.MethodGet, "true")
+
+		Expect(marked).To(BeTrue())
+	})
+
+	It("should ignore values that are not booleans", func() {
+		serve(http.MethodGet, "please")
+
+		Expect(marked).To(BeFalse())
+	})
+
+	It("should keep the reads of writes on the primary", func() {
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+			serve(method, "")
+
+			Expect(marked).To(BeTrue(), method)
+		}
+	})
+})
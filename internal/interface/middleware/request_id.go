@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+)
+
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength bounds caller supplied IDs, which end up in the audit log.
+const maxRequestIDLength = 128
+
+// RequestID tags every request with an ID, reusing the one sent by the caller
+// or the gateway when present, and echoes it in the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.New().String()
+		}
+		c.Header(HeaderRequestID, id)
+		c.Request = c.Request.WithContext(audit.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
+)
+
+var _ = Describe("RequestID", func() {
+	var (
+		router   *gin.Engine
+		recorder *httptest.ResponseRecorder
+		resolved string
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(middleware.RequestID())
+		router.GET("/", func(c *gin.Context) {
+			resolved, _ = audit.RequestIDFromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+		recorder = httptest.NewRecorder()
+		resolved = ""
+	})
+
+	serve := func(requestID string) {
+		req, _ := http.NewRequest("GET", "/", nil)
+		if requestID != "" {
+			req.Header.Set(middleware.HeaderRequestID, requestID)
+		}
+		router.ServeHTTP(recorder, req)
+	}
+
+	It("should propagate the caller's request ID", func() {
+		serve("request-1")
+
+		Expect(resolved).To(Equal("request-1"))
+		Expect(recorder.Header().Get(middleware.HeaderRequestID)).To(Equal("request-1"))
+	})
+
+	It("should generate an ID when none is sent", func() {
+		serve("")
+
+		Expect(resolved).NotTo(BeEmpty())
+		Expect(recorder.Header().Get(middleware.HeaderRequestID)).To(Equal(resolved))
+	})
+
+	It("should replace overlong IDs", func() {
+		serve(strings.Repeat("x", 200))
+
+		Expect(resolved).To(HaveLen(36))
+	})
+})
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeouts bound how long a request may take. Routes are keyed by method and
+// route pattern, such as "GET /tasks/:id"; the others get Default. A timeout
+// of 0 leaves requests unbounded.
+type Timeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// Timeout puts the deadline of the route on the request context. Repositories
+// pass it on to the database, which gives up on queries that outlive it, and
+// handlers answer 504 once it has passed.
+func Timeout(timeouts Timeouts) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout, ok := timeouts.Routes[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = timeouts.Default
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
+)
+
+var _ = Describe("Timeout", func() {
+	var (
+		router   *gin.Engine
+		remains  time.Duration
+		deadline bool
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		remains, deadline = 0, false
+	})
+
+	handle := func(c *gin.Context) {
+		var at time.Time
+		at, deadline = c.Request.Context().Deadline()
+		remains = time.Until(at)
+		c.Status(http.StatusOK)
+	}
+
+	serve := func(timeouts middleware.Timeouts, method string, path string) {
+		router = gin.New()
+		router.Use(middleware.Timeout(timeouts))
+		router.GET("/tasks", handle)
+		router.GET("/tasks/:id", handle)
+		router.POST("/tasks", handle)
+		req, _ := http.NewRequest(method, path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	It("should apply the default timeout", func() {
+		serve(middleware.Timeouts{Default: time.Minute}, http.MethodGet, "/tasks")
+
+		Expect(deadline).To(BeTrue())
+		Expect(remains).To(BeNumerically("~", time.Minute, time.Second))
+	})
+
+	It("should apply the timeout of the route pattern and method", func() {
+		timeouts := middleware.Timeouts{Default: time.Minute, Routes: map[string]time.Duration{"GET /tasks/:id": time.Second}}
+
+		serve(timeouts, http.MethodGet, "/tasks/task-1")
+		Expect(remains).To(BeNumerically("<=", time.Second))
+
+		serve(timeouts, http.MethodPost, "/tasks")
+		Expect(remains).To(BeNumerically("~", time.Minute, time.Second))
+	})
+
+	It("should leave requests unbounded without a timeout", func() {
+		serve(middleware.Timeouts{Routes: map[string]time.Duration{"GET /tasks/:id": time.Second}}, http.MethodGet, "/tasks")
+
+		Expect(deadline).To(BeFalse())
+	})
+})
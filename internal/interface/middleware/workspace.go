@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/problem"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+const HeaderWorkspaceID = "X-Workspace-ID"
+
+// Workspace resolves the tenant for the request and scopes the request context
+// to it. The X-Workspace-ID header selects the workspace explicitly; without it
+// a principal that belongs to exactly one workspace uses that one. Principals
+// may only select workspaces they are a member of. Identity must run first.
+func Workspace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, _ := authz.PrincipalFromContext(c.Request.Context())
+
+		id := strings.TrimSpace(c.GetHeader(HeaderWorkspaceID))
+		if id == "" {
+			if len(principal.Workspaces) != 1 {
+				problem.Write(c, http.StatusBadRequest, HeaderWorkspaceID+" header is required")
+				return
+			}
+			id = principal.Workspaces[0]
+		}
+
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			problem.Write(c, http.StatusBadRequest, "invalid workspace id")
+			return
+		}
+		id = parsed.String()
+		if !slices.Contains(principal.Workspaces, id) {
+			problem.Write(c, http.StatusForbidden, "not a member of this workspace")
+			return
+		}
+
+		c.Request = c.Request.WithContext(workspace.WithID(c.Request.Context(), id))
+		c.Next()
+	}
+}
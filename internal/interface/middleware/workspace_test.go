@@ -0,0 +1,102 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
+)
+
+func TestMiddleware(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Middleware Suite")
+}
+
+const (
+	workspaceA = "11111111-1111-1111-1111-111111111111"
+	workspaceB = "22222222-2222-2222-2222-222222222222"
+)
+
+var _ = Describe("Workspace", func() {
+	var (
+		router   *gin.Engine
+		recorder *httptest.ResponseRecorder
+		resolved string
+	)
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		router = gin.New()
+		router.Use(middleware.Identity(), middleware.Workspace())
+		router.GET("/", func(c *gin.Context) {
+			resolved, _ = workspace.IDFromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+		recorder = httptest.NewRecorder()
+		resolved = ""
+	})
+
+	serve := func(headers map[string]string) {
+		req, _ := http.NewRequest("GET", "/", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		router.ServeHTTP(recorder, req)
+	}
+
+	Context("when the principal belongs to a single workspace", func() {
+		It("should use it without an explicit header", func() {
+			serve(map[string]string{middleware.HeaderUserWorkspaces: workspaceA})
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(resolved).To(Equal(workspaceA))
+		})
+	})
+
+	Context("when the principal belongs to several workspaces", func() {
+		It("should use the workspace selected by the header", func() {
+			serve(map[string]string{
+				middleware.HeaderUserWorkspaces: workspaceA + "," + workspaceB,
+				middleware.HeaderWorkspaceID:    workspaceB,
+			})
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(resolved).To(Equal(workspaceB))
+		})
+
+		It("should return 400 without the header", func() {
+			serve(map[string]string{middleware.HeaderUserWorkspaces: workspaceA + "," + workspaceB})
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the header selects a workspace the principal is not a member of", func() {
+		It("should return 403", func() {
+			serve(map[string]string{
+				middleware.HeaderUserWorkspaces: workspaceA,
+				middleware.HeaderWorkspaceID:    workspaceB,
+			})
+
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+			Expect(resolved).To(BeEmpty())
+		})
+	})
+
+	Context("when the header is not a UUID", func() {
+		It("should return 400", func() {
+			serve(map[string]string{
+				middleware.HeaderUserWorkspaces: workspaceA,
+				middleware.HeaderWorkspaceID:    "not-a-uuid",
+			})
+
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/trash"
+)
+
+// CacheStats counts the lookups of a CachedRepository since it was created.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	// Size is the number of entries cached right now, including expired and
+	// invalidated ones that have not been looked up or evicted yet.
+	Size int
+}
+
+// CachedRepository is a read-through cache in front of another
+// domain.Repository. It caches the results of FindByID and FindAll per
+// workspace, for at most ttl and at most size results, dropping the least
+// recently used first. FindTree, FindDeleted and FindDueWithin always go to
+// the wrapped repository.
+//
+// Every write through the repository invalidates the whole workspace, since
+// a change to one task also shows in the progress of its parent and in the
+// lists it belongs to. Label and comment writes, project deletions and purges
+// do the same when their repositories are wrapped with Labels, Comments,
+// Projects and Trash. Writes by other
+// replicas show once the entries expire, unless InvalidateWorkspace is
+// called for them.
+type CachedRepository struct {
+	next domain.Repository
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries *lru[cacheKey, []*domain.Task]
+	// generations are bumped to invalidate a workspace, epoch to invalidate
+	// them all. Entries keyed by older ones are never looked up again and
+	// age out of the LRU.
+	generations map[string]uint64
+	epoch       uint64
+	stats       CacheStats
+}
+
+type cacheKey struct {
+	epoch       uint64
+	workspaceID string
+	generation  uint64
+	query       string
+}
+
+// cachedTxKey marks the ctx of a transaction opened through
+// CachedRepository.Transactor.
+type cachedTxKey struct{}
+
+func NewCachedRepository(next domain.Repository, size int, ttl time.Duration) *CachedRepository {
+	return &CachedRepository{
+		next:        next,
+		now:         time.Now,
+		entries:     newLRU[cacheKey, []*domain.Task](size, ttl),
+		generations: map[string]uint64{},
+	}
+}
+
+func (r *CachedRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	key, ok := r.key(ctx, "id:"+id)
+	if !ok {
+		return r.next.FindByID(ctx, id)
+	}
+	if tasks, ok := r.get(key); ok {
+		return tasks[0], nil
+	}
+	task, err := r.next.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.put(key, []*domain.Task{task})
+	return task, nil
+}
+
+func (r *CachedRepository) FindAll(ctx context.Context, filter domain.ListFilter) ([]*domain.Task, error) {
+	query, err := json.Marshal(filter)
+	if err != nil {
+		return r.next.FindAll(ctx, filter)
+	}
+	key, ok := r.key(ctx, "list:"+string(query))
+	if !ok {
+		return r.next.FindAll(ctx, filter)
+	}
+	if tasks, ok := r.get(key); ok {
+		return tasks, nil
+	}
+	tasks, err := r.next.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	r.put(key, tasks)
+	return tasks, nil
+}
+
+func (r *CachedRepository) FindTree(ctx context.Context, id string) ([]*domain.Task, error) {
+	return r.next.FindTree(ctx, id)
+}
+
+func (r *CachedRepository) FindDeleted(ctx context.Context) ([]*domain.Task, error) {
+	return r.next.FindDeleted(ctx)
+}
+
+func (r *CachedRepository) FindDueWithin(ctx context.Context, now time.Time, window time.Duration) ([]*domain.Task, error) {
+	return r.next.FindDueWithin(ctx, now, window)
+}
+
+func (r *CachedRepository) Create(ctx context.Context, task *domain.Task) error {
+	defer r.invalidate(ctx)
+	return r.next.Create(ctx, task)
+}
+
+func (r *CachedRepository) Update(ctx context.Context, task *domain.Task) error {
+	defer r.invalidate(ctx)
+	return r.next.Update(ctx, task)
+}
+
+func (r *CachedRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	defer r.invalidate(ctx)
+	return r.next.Delete(ctx, id, deletedAt)
+}
+
+func (r *CachedRepository) Restore(ctx context.Context, id string) error {
+	defer r.invalidate(ctx)
+	return r.next.Restore(ctx, id)
+}
+
+func (r *CachedRepository) AddAssignee(ctx context.Context, taskID string, userID string) error {
+	defer r.invalidate(ctx)
+	return r.next.AddAssignee(ctx, taskID, userID)
+}
+
+func (r *CachedRepository) RemoveAssignee(ctx context.Context, taskID string, userID string) error {
+	defer r.invalidate(ctx)
+	return r.next.RemoveAssignee(ctx, taskID, userID)
+}
+
+// InvalidateWorkspace drops the cached results of the workspace.
+func (r *CachedRepository) InvalidateWorkspace(workspaceID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generations[workspaceID]++
+}
+
+// InvalidateAll drops every cached result.
+func (r *CachedRepository) InvalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epoch++
+	r.entries = newLRU[cacheKey, []*domain.Task](r.entries.capacity, r.entries.ttl)
+}
+
+func (r *CachedRepository) Stats() CacheStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.stats
+	stats.Size = r.entries.len()
+	return stats
+}
+
+// Transactor wraps next so that reads inside its transactions bypass the
+// cache, which must not hold uncommitted results, and the workspace is
+// invalidated again once the transaction is over, as readers may have cached
+// the state before its commit.
+func (r *CachedRepository) Transactor(next transaction.Transactor) transaction.Transactor {
+	return &cachedTransactor{next: next, cache: r}
+}
+
+type cachedTransactor struct {
+	next  transaction.Transactor
+	cache *CachedRepository
+}
+
+func (t *cachedTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	defer t.cache.invalidate(ctx)
+	return t.next.Within(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, cachedTxKey{}, true))
+	})
+}
+
+// Labels wraps next so that its writes invalidate the workspace, as tasks
+// carry the names of their labels and are listed by them.
+func (r *CachedRepository) Labels(next label.Repository) label.Repository {
+	return &cachedLabelRepository{Repository: next, cache: r}
+}
+
+type cachedLabelRepository struct {
+	label.Repository
+	cache *CachedRepository
+}
+
+func (l *cachedLabelRepository) Update(ctx context.Context, lbl *label.Label) error {
+	defer l.cache.invalidate(ctx)
+	return l.Repository.Update(ctx, lbl)
+}
+
+func (l *cachedLabelRepository) Delete(ctx context.Context, id string) error {
+	defer l.cache.invalidate(ctx)
+	return l.Repository.Delete(ctx, id)
+}
+
+func (l *cachedLabelRepository) Attach(ctx context.Context, taskID string, labelID string) error {
+	defer l.cache.invalidate(ctx)
+	return l.Repository.Attach(ctx, taskID, labelID)
+}
+
+func (l *cachedLabelRepository) Detach(ctx context.Context, taskID string, labelID string) error {
+	defer l.cache.invalidate(ctx)
+	return l.Repository.Detach(ctx, taskID, labelID)
+}
+
+// Comments wraps next so that its writes invalidate the workspace, as tasks
+// carry the number of their comments.
+func (r *CachedRepository) Comments(next comment.Repository) comment.Repository {
+	return &cachedCommentRepository{Repository: next, cache: r}
+}
+
+type cachedCommentRepository struct {
+	comment.Repository
+	cache *CachedRepository
+}
+
+func (c *cachedCommentRepository) Create(ctx context.Context, cmt *comment.Comment) error {
+	defer c.cache.invalidate(ctx)
+	return c.Repository.Create(ctx, cmt)
+}
+
+func (c *cachedCommentRepository) Delete(ctx context.Context, id string) error {
+	defer c.cache.invalidate(ctx)
+	return c.Repository.Delete(ctx, id)
+}
+
+// Projects wraps next so that deleting a project invalidates the workspace,
+// as the database detaches its tasks from it.
+func (r *CachedRepository) Projects(next project.Repository) project.Repository {
+	return &cachedProjectRepository{Repository: next, cache: r}
+}
+
+type cachedProjectRepository struct {
+	project.Repository
+	cache *CachedRepository
+}
+
+func (p *cachedProjectRepository) Delete(ctx context.Context, id string) error {
+	defer p.cache.invalidate(ctx)
+	return p.Repository.Delete(ctx, id)
+}
+
+// Trash wraps next so that purges invalidate every workspace, as they run
+// across all of them without one in ctx.
+func (r *CachedRepository) Trash(next trash.Store) trash.Store {
+	return &cachedTrashStore{Store: next, cache: r}
+}
+
+type cachedTrashStore struct {
+	trash.Store
+	cache *CachedRepository
+}
+
+func (t *cachedTrashStore) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	purged, err := t.Store.Purge(ctx, deletedBefore)
+	if purged > 0 {
+		t.cache.InvalidateAll()
+	}
+	return purged, err
+}
+
+// key returns the cache key of query in the workspace of ctx, or false when
+// the lookup must bypass the cache: without a workspace, which the wrapped
+// repository reports, and inside a transaction.
+func (r *CachedRepository) key(ctx context.Context, query string) (cacheKey, bool) {
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok || ctx.Value(cachedTxKey{}) != nil {
+		return cacheKey{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return cacheKey{epoch: r.epoch, workspaceID: workspaceID, generation: r.generations[workspaceID], query: query}, true
+}
+
+// get returns copies of the cached tasks, which callers are free to change.
+func (r *CachedRepository) get(key cacheKey) ([]*domain.Task, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks, ok := r.entries.get(key, r.now())
+	if !ok {
+		r.stats.Misses++
+		return nil, false
+	}
+	r.stats.Hits++
+	return copyTasks(tasks), true
+}
+
+// put caches copies of tasks under key unless the workspace was invalidated
+// since key was taken, in which case tasks may predate the change.
+func (r *CachedRepository) put(key cacheKey, tasks []*domain.Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.epoch != key.epoch || r.generations[key.workspaceID] != key.generation {
+		return
+	}
+	r.stats.Evictions += uint64(r.entries.put(key, copyTasks(tasks), r.now()))
+}
+
+func (r *CachedRepository) invalidate(ctx context.Context) {
+	if workspaceID, ok := workspace.IDFromContext(ctx); ok {
+		r.InvalidateWorkspace(workspaceID)
+	}
+}
+
+func copyTasks(tasks []*domain.Task) []*domain.Task {
+	copies := make([]*domain.Task, len(tasks))
+	for i, t := range tasks {
+		copies[i] = copyTask(t)
+	}
+	return copies
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// countingRepository counts the reads that reach the repository behind a
+// cache.
+type countingRepository struct {
+	domain.Repository
+	reads int
+}
+
+func (r *countingRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	r.reads++
+	return r.Repository.FindByID(ctx, id)
+}
+
+func (r *countingRepository) FindAll(ctx context.Context, filter domain.ListFilter) ([]*domain.Task, error) {
+	r.reads++
+	return r.Repository.FindAll(ctx, filter)
+}
+
+// purgingStore is a trash.Store reporting that it purged as many tasks.
+type purgingStore int64
+
+func (s purgingStore) Purge(context.Context, time.Time) (int64, error) {
+	return int64(s), nil
+}
+
+var _ = Describe("CachedRepository", func() {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var (
+		ctx   context.Context
+		next  *countingRepository
+		cache *CachedRepository
+		clock time.Time
+	)
+
+	newCache := func(size int) {
+		cache = NewCachedRepository(next, size, time.Minute)
+		cache.now = func() time.Time { return clock }
+	}
+
+	create := func(ctx context.Context, id string) {
+		Expect(next.Create(ctx, &domain.Task{ID: id, Title: "Task " + id, Status: domain.StatusTodo,
+			Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		next = &countingRepository{Repository: NewMemoryRepository(NewMemoryStore())}
+		clock = now
+		newCache(10)
+		create(ctx, "task-1")
+	})
+
+	It("serves repeated reads from the cache", func() {
+		for range 3 {
+			found, err := cache.FindByID(ctx, "task-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Title).To(Equal("Task task-1"))
+			_, err = cache.FindAll(ctx, domain.ListFilter{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(next.reads).To(Equal(2))
+		Expect(cache.Stats()).To(Equal(CacheStats{Hits: 4, Misses: 2, Size: 2}))
+	})
+
+	It("keys lists by their filter", func() {
+		_, err := cache.FindAll(ctx, domain.ListFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		tasks, err := cache.FindAll(ctx, domain.ListFilter{Priority: domain.PriorityHigh})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(BeEmpty())
+		Expect(next.reads).To(Equal(2))
+	})
+
+	It("returns copies the caller cannot change", func() {
+		found, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		found.Title = "Changed"
+
+		found, err = cache.FindByID(ctx, "task-1")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Title).To(Equal("Task task-1"))
+	})
+
+	It("does not cache errors", func() {
+		for range 2 {
+			_, err := cache.FindByID(ctx, "missing")
+			Expect(err).To(MatchError(domain.ErrTaskNotFound))
+		}
+
+		Expect(next.reads).To(Equal(2))
+	})
+
+	It("invalidates the workspace on writes", func() {
+		_, err := cache.FindAll(ctx, domain.ListFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		found, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		found.Title = "Renamed"
+		Expect(cache.Update(ctx, found)).To(Succeed())
+		Expect(cache.Create(ctx, &domain.Task{ID: "task-2", Title: "Task task-2", Status: domain.StatusTodo,
+			Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now})).To(Succeed())
+
+		found, err = cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Title).To(Equal("Renamed"))
+		tasks, err := cache.FindAll(ctx, domain.ListFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(2))
+	})
+
+	It("keeps workspaces apart", func() {
+		other := workspace.WithID(context.Background(), workspaceB)
+		_, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = cache.FindByID(other, "task-1")
+		Expect(err).To(MatchError(domain.ErrTaskNotFound))
+
+		create(other, "task-2")
+		Expect(cache.Delete(other, "task-2", now)).To(Succeed())
+		_, err = cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.Stats().Hits).To(Equal(uint64(1)))
+	})
+
+	It("expires entries after the ttl", func() {
+		_, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		clock = now.Add(time.Minute)
+		_, err = cache.FindByID(ctx, "task-1")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.reads).To(Equal(2))
+	})
+
+	It("evicts the least recently used entry", func() {
+		newCache(2)
+		create(ctx, "task-2")
+		create(ctx, "task-3")
+		for _, id := range []string{"task-1", "task-2", "task-1", "task-3"} {
+			_, err := cache.FindByID(ctx, id)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(next.reads).To(Equal(3))
+
+		_, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.FindByID(ctx, "task-2")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(next.reads).To(Equal(4))
+		Expect(cache.Stats()).To(Equal(CacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2}))
+	})
+
+	It("drops entries changed elsewhere when told to", func() {
+		_, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		found, err := next.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		found.Title = "Renamed"
+		Expect(next.Update(ctx, found)).To(Succeed())
+
+		cache.InvalidateWorkspace(workspaceA)
+		found, err = cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Title).To(Equal("Renamed"))
+
+		found.Title = "Renamed again"
+		Expect(next.Update(ctx, found)).To(Succeed())
+		cache.InvalidateAll()
+		found, err = cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Title).To(Equal("Renamed again"))
+	})
+
+	It("invalidates the workspace on label and comment writes", func() {
+		store := NewMemoryStore()
+		cache = NewCachedRepository(NewMemoryRepository(store), 10, time.Minute)
+		labels := cache.Labels(NewMemoryLabelRepository(store))
+		comments := cache.Comments(NewMemoryCommentRepository(store))
+		Expect(cache.Create(ctx, &domain.Task{ID: "task-1", Title: "Task task-1", Status: domain.StatusTodo,
+			Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now})).To(Succeed())
+		bug, err := label.New("label-1", "bug", "", now, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(labels.Create(ctx, bug)).To(Succeed())
+		tasks, err := cache.FindAll(ctx, domain.ListFilter{Labels: []string{"bug"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(BeEmpty())
+
+		Expect(labels.Attach(ctx, "task-1", bug.ID)).To(Succeed())
+		tasks, err = cache.FindAll(ctx, domain.ListFilter{Labels: []string{"bug"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(1))
+
+		note, err := comment.New("comment-1", "task-1", "user-1", "Looks good", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments.Create(ctx, note)).To(Succeed())
+		found, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.CommentCount).To(Equal(1))
+	})
+
+	It("invalidates the workspace on project deletions", func() {
+		store := NewMemoryStore()
+		cache = NewCachedRepository(NewMemoryRepository(store), 10, time.Minute)
+		projects := cache.Projects(NewMemoryProjectRepository(store))
+		launch, err := project.New("project-1", "Launch", "", now, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(projects.Create(ctx, launch)).To(Succeed())
+		Expect(cache.Create(ctx, &domain.Task{ID: "task-1", Title: "Task task-1", Status: domain.StatusTodo,
+			Priority: domain.PriorityMedium, ProjectID: &launch.ID, CreatedAt: now, UpdatedAt: now})).To(Succeed())
+		found, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.ProjectID).To(HaveValue(Equal("project-1")))
+
+		Expect(projects.Delete(ctx, launch.ID)).To(Succeed())
+
+		found, err = cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.ProjectID).To(BeNil())
+	})
+
+	It("drops every workspace once a purge removed tasks", func() {
+		purger := cache.Trash(NewMemoryTrashRepository(NewMemoryStore()))
+		_, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.reads).To(Equal(1))
+
+		_, err = purger.Purge(context.Background(), now)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.reads).To(Equal(1))
+
+		purger = cache.Trash(purgingStore(1))
+		_, err = purger.Purge(context.Background(), now)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next.reads).To(Equal(2))
+	})
+
+	It("bypasses the cache inside transactions", func() {
+		tx := cache.Transactor(NewMemoryTransactor())
+		_, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tx.Within(ctx, func(ctx context.Context) error {
+			found, err := cache.FindByID(ctx, "task-1")
+			Expect(err).NotTo(HaveOccurred())
+			found.Title = "Renamed"
+			Expect(next.Update(ctx, found)).To(Succeed())
+			found, err = cache.FindByID(ctx, "task-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Title).To(Equal("Renamed"))
+			return nil
+		})).To(Succeed())
+
+		found, err := cache.FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Title).To(Equal("Renamed"))
+		Expect(cache.Stats()).To(Equal(CacheStats{Misses: 2, Size: 2}))
+	})
+
+	It("is safe for concurrent use", func() {
+		done := make(chan struct{})
+		for i := range 8 {
+			go func() {
+				defer GinkgoRecover()
+				defer func() { done <- struct{}{} }()
+				for range 50 {
+					if i%2 == 0 {
+						_, err := cache.FindAll(ctx, domain.ListFilter{})
+						Expect(err).NotTo(HaveOccurred())
+					} else {
+						cache.InvalidateWorkspace(workspaceA)
+					}
+				}
+			}()
+		}
+		for range 8 {
+			<-done
+		}
+	})
+})
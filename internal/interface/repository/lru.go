@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"container/list"
+	"time"
+)
+
+// lru is a size bounded map whose entries also expire after ttl. It is not
+// safe for concurrent use.
+type lru[K comparable, V any] struct {
+	capacity int
+	ttl      time.Duration
+	order    *list.List // front is most recently used
+	entries  map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+func newLRU[K comparable, V any](capacity int, ttl time.Duration) *lru[K, V] {
+	return &lru[K, V]{capacity: capacity, ttl: ttl, order: list.New(), entries: map[K]*list.Element{}}
+}
+
+// get returns the live value stored under key. Expired entries are dropped
+// on the way.
+func (c *lru[K, V]) get(key K, now time.Time) (V, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry := element.Value.(*lruEntry[K, V])
+	if !now.Before(entry.expiresAt) {
+		c.remove(element)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// put stores value under key and returns how many entries it evicted to stay
+// within capacity.
+func (c *lru[K, V]) put(key K, value V, now time.Time) int {
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry[K, V])
+		entry.value = value
+		entry.expiresAt = now.Add(c.ttl)
+		c.order.MoveToFront(element)
+		return 0
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value, expiresAt: now.Add(c.ttl)})
+	evicted := 0
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		evicted++
+	}
+	return evicted
+}
+
+func (c *lru[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry[K, V]).key)
+}
+
+func (c *lru[K, V]) len() int {
+	return c.order.Len()
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+)
+
+type memoryAuditRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryAuditRepository(store *MemoryStore) audit.Repository {
+	return &memoryAuditRepository{store: store}
+}
+
+func (r *memoryAuditRepository) Append(ctx context.Context, record *audit.Record) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save audit record %q: %w", record.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := copyRecord(record)
+	stored.WorkspaceID = workspaceID
+	r.store.audit = append(r.store.audit, stored)
+	record.WorkspaceID = workspaceID
+	return nil
+}
+
+func (r *memoryAuditRepository) FindByTask(ctx context.Context, taskID string) ([]*audit.Record, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list audit records of task %q: %w", taskID, err)
+	}
+	return r.find(func(record *audit.Record) bool {
+		return record.WorkspaceID == workspaceID && record.TaskID == taskID
+	}, -1), nil
+}
+
+func (r *memoryAuditRepository) Find(ctx context.Context, filter audit.Filter) ([]*audit.Record, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+	return r.find(func(record *audit.Record) bool {
+		return record.WorkspaceID == workspaceID &&
+			(filter.Actor == "" || record.Actor == filter.Actor) &&
+			(filter.Since.IsZero() || !record.CreatedAt.Before(filter.Since))
+	}, filter.Limit), nil
+}
+
+// find returns copies of the records keep accepts, oldest first, at most
+// limit of them unless limit is negative.
+func (r *memoryAuditRepository) find(keep func(*audit.Record) bool, limit int) []*audit.Record {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	records := []*audit.Record{}
+	for _, record := range r.store.audit {
+		if keep(record) {
+			records = append(records, copyRecord(record))
+		}
+	}
+	slices.SortFunc(records, func(a, b *audit.Record) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	if limit >= 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records
+}
+
+func copyRecord(record *audit.Record) *audit.Record {
+	c := *record
+	c.Changes = maps.Clone(record.Changes)
+	return &c
+}
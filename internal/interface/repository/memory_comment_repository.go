@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+)
+
+type memoryCommentRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryCommentRepository(store *MemoryStore) comment.Repository {
+	return &memoryCommentRepository{store: store}
+}
+
+func (r *memoryCommentRepository) FindByTask(ctx context.Context, taskID string, page comment.Page) ([]*comment.Comment, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list comments of task %q: %w", taskID, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	comments := []*comment.Comment{}
+	for _, c := range r.store.comments {
+		if c.TaskID == taskID && c.WorkspaceID == workspaceID && (page.After == nil || compareCursor(c, *page.After) > 0) {
+			comments = append(comments, copyComment(c))
+		}
+	}
+	slices.SortFunc(comments, func(a, b *comment.Comment) int {
+		return compareCursor(a, comment.Cursor{CreatedAt: b.CreatedAt, ID: b.ID})
+	})
+	if len(comments) > page.Limit {
+		comments = comments[:page.Limit]
+	}
+	return comments, nil
+}
+
+func (r *memoryCommentRepository) FindByID(ctx context.Context, id string) (*comment.Comment, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find comment by id %q: %w", id, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	c, ok := r.store.comments[id]
+	if !ok || c.WorkspaceID != workspaceID {
+		return nil, comment.ErrCommentNotFound
+	}
+	return copyComment(c), nil
+}
+
+func (r *memoryCommentRepository) Create(ctx context.Context, c *comment.Comment) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save comment %q: %w", c.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.comments[c.ID]; ok {
+		return fmt.Errorf("save comment %q: comment already exists", c.ID)
+	}
+	if t, ok := r.store.tasks[c.TaskID]; !ok || t.WorkspaceID != workspaceID {
+		return fmt.Errorf("save comment %q: task %q not found", c.ID, c.TaskID)
+	}
+	stored := copyComment(c)
+	stored.WorkspaceID = workspaceID
+	r.store.comments[c.ID] = stored
+	c.WorkspaceID = workspaceID
+	return nil
+}
+
+func (r *memoryCommentRepository) Update(ctx context.Context, c *comment.Comment) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save comment %q: %w", c.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.comments[c.ID]
+	if !ok || stored.WorkspaceID != workspaceID {
+		return comment.ErrCommentNotFound
+	}
+	stored.Body = c.Body
+	stored.EditedAt = copyPointer(c.EditedAt)
+	return nil
+}
+
+func (r *memoryCommentRepository) Delete(ctx context.Context, id string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("delete comment %q: %w", id, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	c, ok := r.store.comments[id]
+	if !ok || c.WorkspaceID != workspaceID {
+		return comment.ErrCommentNotFound
+	}
+	delete(r.store.comments, id)
+	return nil
+}
+
+// compareCursor orders c against a cursor position by creation time, then ID.
+func compareCursor(c *comment.Comment, cursor comment.Cursor) int {
+	return cmp.Or(c.CreatedAt.Compare(cursor.CreatedAt), cmp.Compare(c.ID, cursor.ID))
+}
+
+func copyComment(c *comment.Comment) *comment.Comment {
+	copied := *c
+	copied.EditedAt = copyPointer(c.EditedAt)
+	return &copied
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+type memoryDependencyRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryDependencyRepository(store *MemoryStore) domain.DependencyRepository {
+	return &memoryDependencyRepository{store: store}
+}
+
+// Lock has nothing to do: memory transactions isolate nothing, so the store
+// is only fit for a single client anyway.
+func (r *memoryDependencyRepository) Lock(context.Context) error {
+	return nil
+}
+
+func (r *memoryDependencyRepository) Add(ctx context.Context, d domain.Dependency) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("add dependency %q -> %q: %w", d.TaskID, d.BlockerID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, id := range []string{d.TaskID, d.BlockerID} {
+		if t, ok := r.store.tasks[id]; !ok || t.WorkspaceID != workspaceID {
+			return fmt.Errorf("add dependency %q -> %q: task %q not found", d.TaskID, d.BlockerID, id)
+		}
+	}
+	r.store.dependencies[d] = workspaceID
+	return nil
+}
+
+func (r *memoryDependencyRepository) Remove(ctx context.Context, d domain.Dependency) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("remove dependency %q -> %q: %w", d.TaskID, d.BlockerID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.dependencies[d] != workspaceID {
+		return domain.ErrDependencyNotFound
+	}
+	delete(r.store.dependencies, d)
+	return nil
+}
+
+func (r *memoryDependencyRepository) FindGraph(ctx context.Context, taskID string) ([]domain.Dependency, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find dependency graph of %q: %w", taskID, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	found := map[domain.Dependency]bool{}
+	// Walk blockers (upstream) and dependents (downstream) separately, as the
+	// recursive CTEs of the Postgres repository do.
+	for _, upstream := range []bool{true, false} {
+		queue := []string{taskID}
+		seen := map[string]bool{taskID: true}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for d, ws := range r.store.dependencies {
+				if ws != workspaceID {
+					continue
+				}
+				next := ""
+				if upstream && d.TaskID == id {
+					next = d.BlockerID
+				} else if !upstream && d.BlockerID == id {
+					next = d.TaskID
+				}
+				if next == "" {
+					continue
+				}
+				found[d] = true
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	deps := make([]domain.Dependency, 0, len(found))
+	for d := range found {
+		deps = append(deps, d)
+	}
+	slices.SortFunc(deps, compareDependencies)
+	return deps, nil
+}
+
+func (r *memoryDependencyRepository) FindOpenBlockers(ctx context.Context, taskIDs []string) ([]domain.Dependency, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find open blockers: %w", err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	deps := []domain.Dependency{}
+	for d, ws := range r.store.dependencies {
+		if ws != workspaceID || !slices.Contains(taskIDs, d.TaskID) {
+			continue
+		}
+		if b, ok := r.store.tasks[d.BlockerID]; ok && b.DeletedAt == nil && b.IsOpen() {
+			deps = append(deps, d)
+		}
+	}
+	slices.SortFunc(deps, compareDependencies)
+	return deps, nil
+}
+
+func compareDependencies(a, b domain.Dependency) int {
+	return cmp.Or(cmp.Compare(a.TaskID, b.TaskID), cmp.Compare(a.BlockerID, b.BlockerID))
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+)
+
+type memoryLabelRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryLabelRepository(store *MemoryStore) label.Repository {
+	return &memoryLabelRepository{store: store}
+}
+
+func (r *memoryLabelRepository) FindAll(ctx context.Context) ([]*label.Label, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list labels: %w", err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	labels := []*label.Label{}
+	for _, l := range r.store.labels {
+		if l.WorkspaceID == workspaceID {
+			c := *l
+			labels = append(labels, &c)
+		}
+	}
+	slices.SortFunc(labels, func(a, b *label.Label) int { return strings.Compare(a.Name, b.Name) })
+	return labels, nil
+}
+
+func (r *memoryLabelRepository) FindByID(ctx context.Context, id string) (*label.Label, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find label by id %q: %w", id, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	l, ok := r.store.labels[id]
+	if !ok || l.WorkspaceID != workspaceID {
+		return nil, label.ErrLabelNotFound
+	}
+	c := *l
+	return &c, nil
+}
+
+func (r *memoryLabelRepository) Create(ctx context.Context, l *label.Label) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save label %q: %w", l.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.labels[l.ID]; ok || r.nameTaken(workspaceID, l.ID, l.Name) {
+		return label.ErrLabelExists
+	}
+	stored := *l
+	stored.WorkspaceID = workspaceID
+	r.store.labels[l.ID] = &stored
+	l.WorkspaceID = workspaceID
+	return nil
+}
+
+func (r *memoryLabelRepository) Update(ctx context.Context, l *label.Label) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save label %q: %w", l.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.labels[l.ID]
+	if !ok || stored.WorkspaceID != workspaceID {
+		return label.ErrLabelNotFound
+	}
+	if r.nameTaken(workspaceID, l.ID, l.Name) {
+		return label.ErrLabelExists
+	}
+	stored.Name = l.Name
+	stored.Color = l.Color
+	stored.UpdatedAt = l.UpdatedAt
+	return nil
+}
+
+func (r *memoryLabelRepository) Delete(ctx context.Context, id string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("delete label %q: %w", id, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	l, ok := r.store.labels[id]
+	if !ok || l.WorkspaceID != workspaceID {
+		return label.ErrLabelNotFound
+	}
+	delete(r.store.labels, id)
+	for taskID, labelIDs := range r.store.taskLabels {
+		r.store.taskLabels[taskID] = slices.DeleteFunc(labelIDs, func(labelID string) bool { return labelID == id })
+	}
+	return nil
+}
+
+func (r *memoryLabelRepository) Attach(ctx context.Context, taskID string, labelID string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("attach label %q to task %q: %w", labelID, taskID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.tasks[taskID]
+	if !ok || t.WorkspaceID != workspaceID {
+		return fmt.Errorf("attach label %q to task %q: task not found", labelID, taskID)
+	}
+	l, ok := r.store.labels[labelID]
+	if !ok || l.WorkspaceID != workspaceID {
+		return fmt.Errorf("attach label %q to task %q: %w", labelID, taskID, label.ErrLabelNotFound)
+	}
+	if !slices.Contains(r.store.taskLabels[taskID], labelID) {
+		r.store.taskLabels[taskID] = append(r.store.taskLabels[taskID], labelID)
+	}
+	return nil
+}
+
+func (r *memoryLabelRepository) Detach(ctx context.Context, taskID string, labelID string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("detach label %q from task %q: %w", labelID, taskID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	l, ok := r.store.labels[labelID]
+	if !ok || l.WorkspaceID != workspaceID || !slices.Contains(r.store.taskLabels[taskID], labelID) {
+		return label.ErrLabelNotAttached
+	}
+	r.store.taskLabels[taskID] = slices.DeleteFunc(r.store.taskLabels[taskID], func(id string) bool { return id == labelID })
+	return nil
+}
+
+// nameTaken reports whether another label of the workspace uses name, which
+// the unique (workspace_id, name) index forbids in Postgres. The caller holds
+// the lock.
+func (r *memoryLabelRepository) nameTaken(workspaceID, id, name string) bool {
+	for _, l := range r.store.labels {
+		if l.WorkspaceID == workspaceID && l.ID != id && l.Name == name {
+			return true
+		}
+	}
+	return false
+}
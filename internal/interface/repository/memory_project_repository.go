@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+)
+
+type memoryProjectRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryProjectRepository(store *MemoryStore) project.Repository {
+	return &memoryProjectRepository{store: store}
+}
+
+func (r *memoryProjectRepository) FindAll(ctx context.Context) ([]*project.Project, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	projects := []*project.Project{}
+	for _, p := range r.store.projects {
+		if p.WorkspaceID == workspaceID {
+			c := *p
+			projects = append(projects, &c)
+		}
+	}
+	slices.SortFunc(projects, func(a, b *project.Project) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return projects, nil
+}
+
+func (r *memoryProjectRepository) FindByID(ctx context.Context, id string) (*project.Project, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find project by id %q: %w", id, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	p, ok := r.store.projects[id]
+	if !ok || p.WorkspaceID != workspaceID {
+		return nil, project.ErrProjectNotFound
+	}
+	c := *p
+	return &c, nil
+}
+
+func (r *memoryProjectRepository) Create(ctx context.Context, p *project.Project) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save project %q: %w", p.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.projects[p.ID]; ok {
+		return fmt.Errorf("save project %q: project already exists", p.ID)
+	}
+	stored := *p
+	stored.WorkspaceID = workspaceID
+	r.store.projects[p.ID] = &stored
+	p.WorkspaceID = workspaceID
+	return nil
+}
+
+func (r *memoryProjectRepository) Update(ctx context.Context, p *project.Project) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save project %q: %w", p.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.projects[p.ID]
+	if !ok || stored.WorkspaceID != workspaceID {
+		return project.ErrProjectNotFound
+	}
+	stored.Name = p.Name
+	stored.Description = p.Description
+	stored.Archived = p.Archived
+	stored.UpdatedAt = p.UpdatedAt
+	return nil
+}
+
+// Delete removes the project and detaches its tasks, like the ON DELETE SET
+// NULL foreign key in Postgres.
+func (r *memoryProjectRepository) Delete(ctx context.Context, id string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("delete project %q: %w", id, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	p, ok := r.store.projects[id]
+	if !ok || p.WorkspaceID != workspaceID {
+		return project.ErrProjectNotFound
+	}
+	delete(r.store.projects, id)
+	for _, t := range r.store.tasks {
+		if t.ProjectID != nil && *t.ProjectID == id {
+			t.ProjectID = nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit"
+)
+
+// MemoryRateLimitStore keeps token buckets in process, which suits a single
+// replica. It holds at most capacity buckets, dropping the least recently
+// used first, and forgets buckets idle for longer than idle. A dropped bucket
+// starts over full, so idle should be at least the longest limit period.
+type MemoryRateLimitStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets *lru[string, *ratelimit.Bucket]
+}
+
+func NewMemoryRateLimitStore(capacity int, idle time.Duration) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{now: time.Now, buckets: newLRU[string, *ratelimit.Bucket](capacity, idle)}
+}
+
+func (s *MemoryRateLimitStore) Take(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	bucket, ok := s.buckets.get(key, now)
+	if !ok {
+		bucket = &ratelimit.Bucket{}
+	}
+	decision := limit.Take(bucket, now)
+	s.buckets.put(key, bucket, now)
+	return decision, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+)
+
+type memoryReminderRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryReminderRepository(store *MemoryStore) reminder.Store {
+	return &memoryReminderRepository{store: store}
+}
+
+// DueWorkspaces mirrors the reminder_workspaces function.
+func (r *memoryReminderRepository) DueWorkspaces(_ context.Context, before time.Time) ([]string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	workspaces := []string{}
+	for _, t := range r.store.tasks {
+		if t.DeletedAt == nil && t.IsOpen() && t.DueAt != nil && t.DueAt.Before(before) &&
+			!slices.Contains(workspaces, t.WorkspaceID) {
+			workspaces = append(workspaces, t.WorkspaceID)
+		}
+	}
+	slices.Sort(workspaces)
+	return workspaces, nil
+}
+
+func (r *memoryReminderRepository) Claim(ctx context.Context, rem reminder.Reminder) (bool, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return false, fmt.Errorf("claim reminder: %w", err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := newReminderKey(workspaceID, rem)
+	if r.store.reminders[key] {
+		return false, nil
+	}
+	r.store.reminders[key] = true
+	return true, nil
+}
+
+func (r *memoryReminderRepository) Release(ctx context.Context, rem reminder.Reminder) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("release reminder: %w", err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.reminders, newReminderKey(workspaceID, rem))
+	return nil
+}
+
+func newReminderKey(workspaceID string, rem reminder.Reminder) reminderKey {
+	return reminderKey{workspaceID: workspaceID, taskID: rem.TaskID, kind: rem.Kind, dueAt: rem.DueAt.UnixNano()}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+)
+
+// MemoryStore holds the data of the in-memory repositories, which stand in
+// for the Postgres ones in local development and tests. The repositories
+// share one lock so that reads joining tasks with their labels and comments
+// see a consistent state, and they copy values in and out so that callers
+// never share memory with the store. Nothing is persisted.
+type MemoryStore struct {
+	mu           sync.RWMutex
+	tasks        map[string]*domain.Task
+	projects     map[string]*project.Project
+	labels       map[string]*label.Label
+	taskLabels   map[string][]string // task ID to label IDs
+	comments     map[string]*comment.Comment
+	dependencies map[domain.Dependency]string // edge to workspace ID
+	audit        []*audit.Record
+	reminders    map[reminderKey]bool
+}
+
+type reminderKey struct {
+	workspaceID string
+	taskID      string
+	kind        reminder.Kind
+	dueAt       int64 // UnixNano, as time.Time values are not comparable
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tasks:        map[string]*domain.Task{},
+		projects:     map[string]*project.Project{},
+		labels:       map[string]*label.Label{},
+		taskLabels:   map[string][]string{},
+		comments:     map[string]*comment.Comment{},
+		dependencies: map[domain.Dependency]string{},
+		reminders:    map[reminderKey]bool{},
+	}
+}
+
+// memoryWorkspace returns the workspace carried by ctx, which the in-memory
+// repositories filter by like the Postgres ones.
+func memoryWorkspace(ctx context.Context) (string, error) {
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok {
+		return "", workspace.ErrWorkspaceRequired
+	}
+	return workspaceID, nil
+}
+
+// removeTasks deletes the tasks, their subtasks and everything attached to
+// them, as the ON DELETE CASCADE foreign keys do in Postgres. The caller holds
+// the write lock.
+func (s *MemoryStore) removeTasks(ids []string) {
+	for len(ids) > 0 {
+		id := ids[0]
+		ids = ids[1:]
+		if _, ok := s.tasks[id]; !ok {
+			continue
+		}
+		delete(s.tasks, id)
+		delete(s.taskLabels, id)
+		for _, t := range s.tasks {
+			if t.ParentID != nil && *t.ParentID == id {
+				ids = append(ids, t.ID)
+			}
+		}
+		for commentID, c := range s.comments {
+			if c.TaskID == id {
+				delete(s.comments, commentID)
+			}
+		}
+		for d := range s.dependencies {
+			if d.TaskID == id || d.BlockerID == id {
+				delete(s.dependencies, d)
+			}
+		}
+		for key := range s.reminders {
+			if key.taskID == id {
+				delete(s.reminders, key)
+			}
+		}
+	}
+}
+
+type memoryTransactor struct{}
+
+// NewMemoryTransactor returns a transaction.Transactor for the in-memory
+// repositories. It runs fn without isolation and cannot roll back the writes
+// fn made before failing.
+func NewMemoryTransactor() transaction.Transactor {
+	return memoryTransactor{}
+}
+
+func (memoryTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// MemoryLock is a reminder.Locker for a single process.
+type MemoryLock struct {
+	mu sync.Mutex
+}
+
+func NewMemoryLock() *MemoryLock {
+	return &MemoryLock{}
+}
+
+func (l *MemoryLock) TryLock(context.Context) (func(), bool, error) {
+	if !l.mu.TryLock() {
+		return nil, false, nil
+	}
+	return l.mu.Unlock, true, nil
+}
+
+// copyTask returns a deep copy of t.
+func copyTask(t *domain.Task) *domain.Task {
+	c := t.Clone()
+	c.ProjectID = copyPointer(t.ProjectID)
+	c.ParentID = copyPointer(t.ParentID)
+	c.DueAt = copyPointer(t.DueAt)
+	c.SeriesID = copyPointer(t.SeriesID)
+	c.DeletedAt = copyPointer(t.DeletedAt)
+	if t.Recurrence != nil {
+		r := *t.Recurrence
+		r.Weekdays = slices.Clone(t.Recurrence.Weekdays)
+		r.Until = copyPointer(t.Recurrence.Until)
+		c.Recurrence = &r
+	}
+	return c
+}
+
+func copyPointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
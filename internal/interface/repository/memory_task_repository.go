@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/trash"
+)
+
+type memoryTaskRepository struct {
+	store *MemoryStore
+}
+
+func NewMemoryRepository(store *MemoryStore) domain.Repository {
+	return &memoryTaskRepository{store: store}
+}
+
+func (r *memoryTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find task by id %q: %w", id, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	t, ok := r.store.tasks[id]
+	if !ok || t.WorkspaceID != workspaceID || t.DeletedAt != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	return r.read(t), nil
+}
+
+func (r *memoryTaskRepository) FindAll(ctx context.Context, filter domain.ListFilter) ([]*domain.Task, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tasks := []*domain.Task{}
+	for _, t := range r.store.tasks {
+		if t.WorkspaceID != workspaceID || t.DeletedAt != nil {
+			continue
+		}
+		if read := r.read(t); matches(read, filter) {
+			tasks = append(tasks, read)
+		}
+	}
+	if filter.SortBy == domain.SortByDueAt {
+		slices.SortFunc(tasks, compareDueAt)
+	} else {
+		slices.SortFunc(tasks, compareCreatedAt)
+	}
+	return tasks, nil
+}
+
+func (r *memoryTaskRepository) FindTree(ctx context.Context, id string) ([]*domain.Task, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find task tree %q: %w", id, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tasks := tree(r.workspaceTasks(workspaceID), id, func(child, _ *domain.Task) bool { return child.DeletedAt == nil })
+	if len(tasks) == 0 || tasks[0].DeletedAt != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	for i, t := range tasks {
+		tasks[i] = r.read(t)
+	}
+	return tasks, nil
+}
+
+func (r *memoryTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save task %q: %w", task.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.tasks[task.ID]; ok {
+		return fmt.Errorf("save task %q: task already exists", task.ID)
+	}
+	if task.ParentID != nil {
+		if parent, ok := r.store.tasks[*task.ParentID]; !ok || parent.WorkspaceID != workspaceID {
+			return fmt.Errorf("save task %q: %w", task.ID, domain.ErrParentNotFound)
+		}
+	}
+	stored := copyTask(task)
+	stored.WorkspaceID = workspaceID
+	stored.Subtasks = domain.Progress{}
+	stored.Labels = nil
+	stored.CommentCount = 0
+	r.store.tasks[task.ID] = stored
+	task.WorkspaceID = workspaceID
+	return nil
+}
+
+// Update saves the fields postgresTaskRepository.Update saves; assignees go
+// through AddAssignee and RemoveAssignee.
+func (r *memoryTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("save task %q: %w", task.ID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.tasks[task.ID]
+	if !ok || stored.WorkspaceID != workspaceID || stored.DeletedAt != nil {
+		return domain.ErrTaskNotFound
+	}
+	updated := copyTask(task)
+	stored.ProjectID = updated.ProjectID
+	stored.ParentID = updated.ParentID
+	stored.Title = updated.Title
+	stored.Status = updated.Status
+	stored.Priority = updated.Priority
+	stored.DueAt = updated.DueAt
+	stored.Recurrence = updated.Recurrence
+	stored.SeriesID = updated.SeriesID
+	stored.Occurrence = updated.Occurrence
+	stored.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+func (r *memoryTaskRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("delete task %q: %w", id, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	tasks := tree(r.workspaceTasks(workspaceID), id, func(child, _ *domain.Task) bool { return child.DeletedAt == nil })
+	if len(tasks) == 0 || tasks[0].DeletedAt != nil {
+		return domain.ErrTaskNotFound
+	}
+	for _, t := range tasks {
+		t.DeletedAt = copyPointer(&deletedAt)
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) FindDeleted(ctx context.Context) ([]*domain.Task, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list deleted tasks: %w", err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tasks := []*domain.Task{}
+	for _, t := range r.store.tasks {
+		if t.WorkspaceID == workspaceID && t.DeletedAt != nil {
+			tasks = append(tasks, r.read(t))
+		}
+	}
+	slices.SortFunc(tasks, func(a, b *domain.Task) int {
+		return cmp.Or(b.DeletedAt.Compare(*a.DeletedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return tasks, nil
+}
+
+func (r *memoryTaskRepository) Restore(ctx context.Context, id string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("restore task %q: %w", id, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	root, ok := r.store.tasks[id]
+	if !ok || root.WorkspaceID != workspaceID || root.DeletedAt == nil {
+		return domain.ErrTaskNotFound
+	}
+	if root.ParentID != nil {
+		if parent, ok := r.store.tasks[*root.ParentID]; ok && parent.DeletedAt != nil {
+			return domain.ErrParentDeleted
+		}
+	}
+	sameDeletion := func(child, parent *domain.Task) bool {
+		return child.DeletedAt != nil && child.DeletedAt.Equal(*parent.DeletedAt)
+	}
+	// Collect the tree before clearing deleted_at, which sameDeletion compares.
+	tasks := tree(r.workspaceTasks(workspaceID), id, sameDeletion)
+	for _, t := range tasks {
+		t.DeletedAt = nil
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) AddAssignee(ctx context.Context, taskID string, userID string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("assign task %q to %q: %w", taskID, userID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.tasks[taskID]
+	if !ok || t.WorkspaceID != workspaceID {
+		return fmt.Errorf("assign task %q to %q: %w", taskID, userID, domain.ErrTaskNotFound)
+	}
+	if !slices.Contains(t.Assignees, userID) {
+		t.Assignees = append(t.Assignees, userID)
+		slices.Sort(t.Assignees)
+	}
+	return nil
+}
+
+func (r *memoryTaskRepository) RemoveAssignee(ctx context.Context, taskID string, userID string) error {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return fmt.Errorf("unassign %q from task %q: %w", userID, taskID, err)
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.tasks[taskID]
+	if !ok || t.WorkspaceID != workspaceID || !slices.Contains(t.Assignees, userID) {
+		return domain.ErrAssigneeNotFound
+	}
+	t.Assignees = slices.DeleteFunc(t.Assignees, func(id string) bool { return id == userID })
+	return nil
+}
+
+func (r *memoryTaskRepository) FindDueWithin(ctx context.Context, now time.Time, window time.Duration) ([]*domain.Task, error) {
+	workspaceID, err := memoryWorkspace(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks due within %s: %w", window, err)
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tasks := []*domain.Task{}
+	end := now.Add(window)
+	for _, t := range r.store.tasks {
+		if t.WorkspaceID == workspaceID && t.DeletedAt == nil && t.IsOpen() &&
+			t.DueAt != nil && !t.DueAt.Before(now) && t.DueAt.Before(end) {
+			tasks = append(tasks, r.read(t))
+		}
+	}
+	slices.SortFunc(tasks, func(a, b *domain.Task) int {
+		return cmp.Or(a.DueAt.Compare(*b.DueAt), cmp.Compare(a.ID, b.ID))
+	})
+	return tasks, nil
+}
+
+// NewMemoryTrashRepository returns the trash.Store of the in-memory task
+// repository.
+func NewMemoryTrashRepository(store *MemoryStore) trash.Store {
+	return &memoryTaskRepository{store: store}
+}
+
+func (r *memoryTaskRepository) Purge(_ context.Context, deletedBefore time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ids []string
+	for _, t := range r.store.tasks {
+		if t.DeletedAt != nil && t.DeletedAt.Before(deletedBefore) {
+			ids = append(ids, t.ID)
+		}
+	}
+	r.store.removeTasks(ids)
+	return int64(len(ids)), nil
+}
+
+// workspaceTasks returns the stored tasks of the workspace, not copies. The
+// caller holds the lock.
+func (r *memoryTaskRepository) workspaceTasks(workspaceID string) []*domain.Task {
+	var tasks []*domain.Task
+	for _, t := range r.store.tasks {
+		if t.WorkspaceID == workspaceID {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// read returns a copy of t with its assignees sorted and the subtask
+// progress, label names and comment count the Postgres repository selects
+// alongside it. The caller holds the lock.
+func (r *memoryTaskRepository) read(t *domain.Task) *domain.Task {
+	c := copyTask(t)
+	if c.Assignees == nil {
+		c.Assignees = []string{}
+	}
+	slices.Sort(c.Assignees)
+	for _, s := range r.store.tasks {
+		if s.ParentID != nil && *s.ParentID == t.ID && s.DeletedAt == nil && s.Status != domain.StatusCancelled {
+			c.Subtasks.Total++
+			if s.Status == domain.StatusComplete {
+				c.Subtasks.Completed++
+			}
+		}
+	}
+	c.Labels = []string{}
+	for _, labelID := range r.store.taskLabels[t.ID] {
+		if l, ok := r.store.labels[labelID]; ok {
+			c.Labels = append(c.Labels, l.Name)
+		}
+	}
+	slices.Sort(c.Labels)
+	for _, comment := range r.store.comments {
+		if comment.TaskID == t.ID {
+			c.CommentCount++
+		}
+	}
+	return c
+}
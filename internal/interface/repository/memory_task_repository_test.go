@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("memoryTaskRepository", func() {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var (
+		ctx   context.Context
+		store *MemoryStore
+		repo  domain.Repository
+	)
+
+	newTask := func(id string, createdAt time.Time) *domain.Task {
+		return &domain.Task{ID: id, Title: "Task " + id, Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+			CreatedAt: createdAt, UpdatedAt: createdAt}
+	}
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		store = NewMemoryStore()
+		repo = NewMemoryRepository(store)
+	})
+
+	It("requires a workspace", func() {
+		_, err := repo.FindByID(context.Background(), "task-1")
+
+		Expect(err).To(MatchError(workspace.ErrWorkspaceRequired))
+	})
+
+	Describe("Create and FindByID", func() {
+		It("stores a copy the caller cannot change", func() {
+			t := newTask("task-1", now)
+			t.Assignees = []string{"alice"}
+			Expect(repo.Create(ctx, t)).To(Succeed())
+			Expect(t.WorkspaceID).To(Equal(workspaceA))
+
+			t.Title = "Changed"
+			t.Assignees[0] = "mallory"
+			found, err := repo.FindByID(ctx, "task-1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Title).To(Equal("Task task-1"))
+			Expect(found.Assignees).To(Equal([]string{"alice"}))
+		})
+
+		It("returns copies the caller cannot change", func() {
+			dueAt := now.Add(time.Hour)
+			t := newTask("task-1", now)
+			t.DueAt = &dueAt
+			Expect(repo.Create(ctx, t)).To(Succeed())
+
+			found, _ := repo.FindByID(ctx, "task-1")
+			*found.DueAt = now.Add(48 * time.Hour)
+			found.Title = "Changed"
+
+			again, _ := repo.FindByID(ctx, "task-1")
+			Expect(again.Title).To(Equal("Task task-1"))
+			Expect(*again.DueAt).To(Equal(dueAt))
+		})
+
+		It("hides tasks of other workspaces", func() {
+			Expect(repo.Create(ctx, newTask("task-1", now))).To(Succeed())
+
+			_, err := repo.FindByID(workspace.WithID(context.Background(), workspaceB), "task-1")
+
+			Expect(err).To(Equal(domain.ErrTaskNotFound))
+		})
+
+		It("fills in progress, labels and comment count", func() {
+			parentID := "task-1"
+			Expect(repo.Create(ctx, newTask("task-1", now))).To(Succeed())
+			for i, status := range []domain.Status{domain.StatusComplete, domain.StatusTodo, domain.StatusCancelled} {
+				child := newTask("child-"+strconv.Itoa(i), now)
+				child.ParentID = &parentID
+				child.Status = status
+				Expect(repo.Create(ctx, child)).To(Succeed())
+			}
+			labels := NewMemoryLabelRepository(store)
+			Expect(labels.Create(ctx, &label.Label{ID: "label-1", Name: "bug"})).To(Succeed())
+			Expect(labels.Attach(ctx, "task-1", "label-1")).To(Succeed())
+			Expect(NewMemoryCommentRepository(store).Create(ctx, &comment.Comment{ID: "comment-1", TaskID: "task-1"})).To(Succeed())
+
+			found, err := repo.FindByID(ctx, "task-1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Subtasks).To(Equal(domain.Progress{Total: 2, Completed: 1}))
+			Expect(found.Labels).To(Equal([]string{"bug"}))
+			Expect(found.CommentCount).To(Equal(1))
+		})
+	})
+
+	Describe("Update", func() {
+		It("returns task not found for a missing task", func() {
+			Expect(repo.Update(ctx, newTask("task-1", now))).To(Equal(domain.ErrTaskNotFound))
+		})
+
+		It("saves the task fields but not the assignees", func() {
+			Expect(repo.Create(ctx, newTask("task-1", now))).To(Succeed())
+			t := newTask("task-1", now)
+			t.Title = "Renamed"
+			t.Assignees = []string{"alice"}
+
+			Expect(repo.Update(ctx, t)).To(Succeed())
+
+			found, _ := repo.FindByID(ctx, "task-1")
+			Expect(found.Title).To(Equal("Renamed"))
+			Expect(found.Assignees).To(BeEmpty())
+		})
+	})
+
+	Describe("FindAll", func() {
+		BeforeEach(func() {
+			later, earlier := now.Add(time.Hour), now.Add(-time.Hour)
+			first := newTask("task-1", now)
+			second := newTask("task-2", now.Add(time.Minute))
+			second.DueAt = &later
+			third := newTask("task-3", now.Add(2*time.Minute))
+			third.DueAt = &earlier
+			third.Priority = domain.PriorityHigh
+			for _, t := range []*domain.Task{third, first, second} {
+				Expect(repo.Create(ctx, t)).To(Succeed())
+			}
+			Expect(repo.AddAssignee(ctx, "task-2", "alice")).To(Succeed())
+		})
+
+		ids := func(tasks []*domain.Task) []string {
+			ids := make([]string, len(tasks))
+			for i, t := range tasks {
+				ids[i] = t.ID
+			}
+			return ids
+		}
+
+		It("orders by creation by default and by due date on request", func() {
+			byCreation, err := repo.FindAll(ctx, domain.ListFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(byCreation)).To(Equal([]string{"task-1", "task-2", "task-3"}))
+
+			byDueDate, err := repo.FindAll(ctx, domain.ListFilter{SortBy: domain.SortByDueAt})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(byDueDate)).To(Equal([]string{"task-3", "task-2", "task-1"}))
+		})
+
+		It("applies the filters", func() {
+			overdue, _ := repo.FindAll(ctx, domain.ListFilter{OverdueAt: now})
+			Expect(ids(overdue)).To(Equal([]string{"task-3"}))
+
+			high, _ := repo.FindAll(ctx, domain.ListFilter{Priority: domain.PriorityHigh})
+			Expect(ids(high)).To(Equal([]string{"task-3"}))
+
+			assigned, _ := repo.FindAll(ctx, domain.ListFilter{Assignee: "alice"})
+			Expect(ids(assigned)).To(Equal([]string{"task-2"}))
+
+			none, _ := repo.FindAll(ctx, domain.ListFilter{IDs: []string{}})
+			Expect(none).To(BeEmpty())
+		})
+	})
+
+	Describe("Delete and Restore", func() {
+		var parentID string
+
+		BeforeEach(func() {
+			parentID = "task-1"
+			Expect(repo.Create(ctx, newTask("task-1", now))).To(Succeed())
+			child := newTask("child-1", now)
+			child.ParentID = &parentID
+			Expect(repo.Create(ctx, child)).To(Succeed())
+		})
+
+		It("moves the task with its subtasks to the trash and back", func() {
+			Expect(repo.Delete(ctx, "task-1", now)).To(Succeed())
+
+			_, err := repo.FindByID(ctx, "child-1")
+			Expect(err).To(Equal(domain.ErrTaskNotFound))
+			trash, _ := repo.FindDeleted(ctx)
+			Expect(trash).To(HaveLen(2))
+
+			Expect(repo.Restore(ctx, "child-1")).To(Equal(domain.ErrParentDeleted))
+			Expect(repo.Restore(ctx, "task-1")).To(Succeed())
+
+			tree, err := repo.FindTree(ctx, "task-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tree).To(HaveLen(2))
+		})
+
+		It("purges tasks deleted before the cutoff together with their subtasks", func() {
+			Expect(repo.Delete(ctx, "task-1", now)).To(Succeed())
+
+			purged, err := NewMemoryTrashRepository(store).Purge(context.Background(), now.Add(time.Second))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(purged).To(Equal(int64(2)))
+			Expect(store.tasks).To(BeEmpty())
+		})
+	})
+
+	Describe("RemoveAssignee", func() {
+		It("reports users who are not assigned", func() {
+			Expect(repo.Create(ctx, newTask("task-1", now))).To(Succeed())
+
+			Expect(repo.RemoveAssignee(ctx, "task-1", "alice")).To(Equal(domain.ErrAssigneeNotFound))
+		})
+	})
+
+	It("is safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				t := newTask("task-"+strconv.Itoa(i), now)
+				Expect(repo.Create(ctx, t)).To(Succeed())
+				t.Title = "Renamed"
+				Expect(repo.Update(ctx, t)).To(Succeed())
+				_, err := repo.FindAll(ctx, domain.ListFilter{})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		tasks, err := repo.FindAll(ctx, domain.ListFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(20))
+	})
+})
+
+var _ = Describe("memory repositories", func() {
+	var (
+		ctx   context.Context
+		store *MemoryStore
+	)
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		store = NewMemoryStore()
+	})
+
+	It("rejects a second label with the same name in a workspace", func() {
+		labels := NewMemoryLabelRepository(store)
+		Expect(labels.Create(ctx, &label.Label{ID: "label-1", Name: "bug"})).To(Succeed())
+
+		Expect(labels.Create(ctx, &label.Label{ID: "label-2", Name: "bug"})).To(Equal(label.ErrLabelExists))
+		Expect(labels.Create(workspace.WithID(context.Background(), workspaceB),
+			&label.Label{ID: "label-3", Name: "bug"})).To(Succeed())
+	})
+
+	It("detaches tasks from a deleted project", func() {
+		projects := NewMemoryProjectRepository(store)
+		tasks := NewMemoryRepository(store)
+		projectID := "project-1"
+		Expect(projects.Create(ctx, &project.Project{ID: projectID, Name: "Launch"})).To(Succeed())
+		Expect(tasks.Create(ctx, &domain.Task{ID: "task-1", Title: "Plan", ProjectID: &projectID})).To(Succeed())
+
+		Expect(projects.Delete(ctx, projectID)).To(Succeed())
+
+		found, _ := tasks.FindByID(ctx, "task-1")
+		Expect(found.ProjectID).To(BeNil())
+	})
+
+	It("walks the dependency graph in both directions", func() {
+		tasks := NewMemoryRepository(store)
+		deps := NewMemoryDependencyRepository(store)
+		for _, id := range []string{"a", "b", "c", "d"} {
+			Expect(tasks.Create(ctx, &domain.Task{ID: id, Title: id, Status: domain.StatusTodo})).To(Succeed())
+		}
+		Expect(deps.Add(ctx, domain.Dependency{TaskID: "a", BlockerID: "b"})).To(Succeed())
+		Expect(deps.Add(ctx, domain.Dependency{TaskID: "b", BlockerID: "c"})).To(Succeed())
+		Expect(deps.Add(ctx, domain.Dependency{TaskID: "d", BlockerID: "a"})).To(Succeed())
+
+		graph, err := deps.FindGraph(ctx, "b")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(graph).To(Equal([]domain.Dependency{
+			{TaskID: "a", BlockerID: "b"}, {TaskID: "b", BlockerID: "c"}, {TaskID: "d", BlockerID: "a"},
+		}))
+		Expect(deps.Remove(ctx, domain.Dependency{TaskID: "c", BlockerID: "a"})).To(Equal(domain.ErrDependencyNotFound))
+	})
+})
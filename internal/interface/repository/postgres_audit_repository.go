@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+)
+
+const auditColumns = `id, workspace_id, task_id, actor, action, changes, request_id, created_at`
+
+type postgresAuditRepository struct {
+	db queryExecutor
+}
+
+func NewAuditRepository(db DB) audit.Repository {
+	return &postgresAuditRepository{db: db}
+}
+
+// change is the JSON form of audit.Change stored in the changes column.
+type change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+func (r *postgresAuditRepository) Append(ctx context.Context, record *audit.Record) error {
+	changes := make(map[string]change, len(record.Changes))
+	for field, c := range record.Changes {
+		changes[field] = change{Before: c.Before, After: c.After}
+	}
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("encode audit record %q: %w", record.ID, err)
+	}
+
+	err = inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO audit_log (`+auditColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			record.ID, workspaceID, record.TaskID, record.Actor, record.Action, encoded, record.RequestID, record.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		record.WorkspaceID = workspaceID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save audit record %q: %w", record.ID, err)
+	}
+	return nil
+}
+
+func (r *postgresAuditRepository) FindByTask(ctx context.Context, taskID string) ([]*audit.Record, error) {
+	var records []*audit.Record
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		var err error
+		records, err = queryRecords(ctx, q,
+			`SELECT `+auditColumns+` FROM audit_log WHERE task_id = $1 AND workspace_id = $2 ORDER BY created_at, id`,
+			taskID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list audit records of task %q: %w", taskID, err)
+	}
+	return records, nil
+}
+
+func (r *postgresAuditRepository) Find(ctx context.Context, filter audit.Filter) ([]*audit.Record, error) {
+	var records []*audit.Record
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		conditions := []string{"workspace_id = $1"}
+		args := []any{workspaceID}
+		if filter.Actor != "" {
+			args = append(args, filter.Actor)
+			conditions = append(conditions, "actor = $"+strconv.Itoa(len(args)))
+		}
+		if !filter.Since.IsZero() {
+			args = append(args, filter.Since)
+			conditions = append(conditions, "created_at >= $"+strconv.Itoa(len(args)))
+		}
+		args = append(args, filter.Limit)
+
+		var err error
+		records, err = queryRecords(ctx, q,
+			`SELECT `+auditColumns+` FROM audit_log WHERE `+strings.Join(conditions, " AND ")+
+				` ORDER BY created_at, id LIMIT $`+strconv.Itoa(len(args)),
+			args...,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+	return records, nil
+}
+
+func queryRecords(ctx context.Context, q queryExecutor, sql string, args ...any) ([]*audit.Record, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []*audit.Record{}
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+func scanRecord(row pgx.Row) (*audit.Record, error) {
+	record := &audit.Record{}
+	var encoded []byte
+	if err := row.Scan(&record.ID, &record.WorkspaceID, &record.TaskID, &record.Actor, &record.Action,
+		&encoded, &record.RequestID, &record.CreatedAt); err != nil {
+		return nil, err
+	}
+	var changes map[string]change
+	if err := json.Unmarshal(encoded, &changes); err != nil {
+		return nil, fmt.Errorf("decode changes of audit record %q: %w", record.ID, err)
+	}
+	record.Changes = make(map[string]audit.Change, len(changes))
+	for field, c := range changes {
+		record.Changes[field] = audit.Change{Before: c.Before, After: c.After}
+	}
+	return record, nil
+}
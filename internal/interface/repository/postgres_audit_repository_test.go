@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("postgresAuditRepository", func() {
+	var (
+		ctx       context.Context
+		repo      *postgresAuditRepository
+		execState *stubExecState
+	)
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		execState = &stubExecState{}
+		repo = &postgresAuditRepository{db: &stubQueryExecutor{execState: execState}}
+	})
+
+	Describe("Append", func() {
+		It("stores the changes as JSON", func() {
+			record := &audit.Record{
+				ID:      "record-1",
+				TaskID:  "task-1",
+				Actor:   "user-1",
+				Action:  audit.ActionUpdate,
+				Changes: map[string]audit.Change{"title": {Before: "Old", After: "New"}},
+			}
+
+			err := repo.Append(ctx, record)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.WorkspaceID).To(Equal(workspaceA))
+			Expect(execState.lastCall().sql).To(ContainSubstring("INSERT INTO audit_log"))
+			Expect(execState.lastCall().args[5]).To(MatchJSON(`{"title": {"before": "Old", "after": "New"}}`))
+		})
+	})
+
+	Describe("Find", func() {
+		It("filters by actor and time in SQL", func() {
+			since := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			_, _ = repo.Find(ctx, audit.Filter{Actor: "user-1", Since: since, Limit: 10})
+
+			call := execState.lastCall()
+			Expect(call.sql).To(ContainSubstring("actor = $2 AND created_at >= $3"))
+			Expect(call.sql).To(ContainSubstring("ORDER BY created_at, id LIMIT $4"))
+			Expect(call.args).To(Equal([]any{workspaceA, "user-1", since, 10}))
+		})
+	})
+})
+
+var _ = Describe("postgresTransactor", func() {
+	It("runs the repository calls in one transaction", func() {
+		ctx := workspace.WithID(context.Background(), workspaceA)
+		execState := &stubExecState{rowsAffected: 1}
+		db := &stubQueryExecutor{execState: execState}
+		tasks := &postgresTaskRepository{db: db}
+		audits := &postgresAuditRepository{db: db}
+
+		err := (&postgresTransactor{db: db}).Within(ctx, func(ctx context.Context) error {
+			if err := tasks.Update(ctx, &domain.Task{ID: "task-1"}); err != nil {
+				return err
+			}
+			return audits.Append(ctx, &audit.Record{ID: "record-1", TaskID: "task-1"})
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(execState.committed).To(BeTrue())
+		Expect(execState.calls).To(HaveLen(3))
+		Expect(execState.calls[0].sql).To(ContainSubstring("set_config"))
+	})
+
+	It("rolls back when fn fails", func() {
+		ctx := workspace.WithID(context.Background(), workspaceA)
+		execState := &stubExecState{}
+		db := &stubQueryExecutor{execState: execState}
+
+		err := (&postgresTransactor{db: db}).Within(ctx, func(ctx context.Context) error {
+			return domain.ErrTaskNotFound
+		})
+
+		Expect(err).To(Equal(domain.ErrTaskNotFound))
+		Expect(execState.committed).To(BeFalse())
+		Expect(execState.rolledBack).To(BeTrue())
+	})
+})
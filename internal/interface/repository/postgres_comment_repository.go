@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+)
+
+const commentColumns = `id, workspace_id, task_id, author_id, body, created_at, edited_at`
+
+type postgresCommentRepository struct {
+	db queryExecutor
+}
+
+func NewCommentRepository(db DB) comment.Repository {
+	return &postgresCommentRepository{db: db}
+}
+
+func (r *postgresCommentRepository) FindByTask(ctx context.Context, taskID string, page comment.Page) ([]*comment.Comment, error) {
+	comments := []*comment.Comment{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		sql := `SELECT ` + commentColumns + ` FROM comments WHERE task_id = $1 AND workspace_id = $2`
+		args := []any{taskID, workspaceID}
+		if page.After != nil {
+			// Row comparison keeps the keyset walk on the (task_id,
+			// created_at, id) index.
+			sql += ` AND (created_at, id) > ($3, $4)`
+			args = append(args, page.After.CreatedAt, page.After.ID)
+		}
+		args = append(args, page.Limit)
+		sql += fmt.Sprintf(` ORDER BY created_at, id LIMIT $%d`, len(args))
+
+		rows, err := q.Query(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			c, err := scanComment(rows)
+			if err != nil {
+				return err
+			}
+			comments = append(comments, c)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list comments of task %q: %w", taskID, err)
+	}
+	return comments, nil
+}
+
+func (r *postgresCommentRepository) FindByID(ctx context.Context, id string) (*comment.Comment, error) {
+	var c *comment.Comment
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		row := q.QueryRow(ctx,
+			`SELECT `+commentColumns+` FROM comments WHERE id = $1 AND workspace_id = $2`,
+			id, workspaceID,
+		)
+		var err error
+		c, err = scanComment(row)
+		return err
+	})
+	if err == pgx.ErrNoRows {
+		return nil, comment.ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find comment by id %q: %w", id, err)
+	}
+	return c, nil
+}
+
+func (r *postgresCommentRepository) Create(ctx context.Context, c *comment.Comment) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO comments (`+commentColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			c.ID, workspaceID, c.TaskID, c.AuthorID, c.Body, c.CreatedAt, c.EditedAt,
+		)
+		if err != nil {
+			return err
+		}
+		c.WorkspaceID = workspaceID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save comment %q: %w", c.ID, err)
+	}
+	return nil
+}
+
+func (r *postgresCommentRepository) Update(ctx context.Context, c *comment.Comment) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`UPDATE comments SET body = $1, edited_at = $2 WHERE id = $3 AND workspace_id = $4`,
+			c.Body, c.EditedAt, c.ID, workspaceID,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save comment %q: %w", c.ID, err)
+	}
+
+	if rowsAffected == 0 {
+		return comment.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresCommentRepository) Delete(ctx context.Context, id string) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx, `DELETE FROM comments WHERE id = $1 AND workspace_id = $2`, id, workspaceID)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete comment %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return comment.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func scanComment(row pgx.Row) (*comment.Comment, error) {
+	c := &comment.Comment{}
+	if err := row.Scan(&c.ID, &c.WorkspaceID, &c.TaskID, &c.AuthorID, &c.Body, &c.CreatedAt, &c.EditedAt); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
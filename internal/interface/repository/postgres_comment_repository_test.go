@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("postgresCommentRepository", func() {
+	var (
+		ctx       context.Context
+		repo      *postgresCommentRepository
+		execState *stubExecState
+	)
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		execState = &stubExecState{}
+		repo = &postgresCommentRepository{db: &stubQueryExecutor{execState: execState}}
+	})
+
+	Describe("FindByTask", func() {
+		It("reads the first page without a keyset condition", func() {
+			_, _ = repo.FindByTask(ctx, "task-1", comment.Page{Limit: 21})
+
+			call := execState.lastCall()
+			Expect(call.sql).NotTo(ContainSubstring("(created_at, id) >"))
+			Expect(call.sql).To(ContainSubstring("ORDER BY created_at, id LIMIT $3"))
+			Expect(call.args).To(Equal([]any{"task-1", workspaceA, 21}))
+		})
+
+		It("continues after the cursor", func() {
+			after := comment.Cursor{CreatedAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), ID: "comment-9"}
+
+			_, _ = repo.FindByTask(ctx, "task-1", comment.Page{After: &after, Limit: 21})
+
+			call := execState.lastCall()
+			Expect(call.sql).To(ContainSubstring("(created_at, id) > ($3, $4)"))
+			Expect(call.sql).To(ContainSubstring("LIMIT $5"))
+			Expect(call.args).To(Equal([]any{"task-1", workspaceA, after.CreatedAt, "comment-9", 21}))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("returns comment not found", func() {
+			execState.rowsAffected = 0
+
+			Expect(repo.Delete(ctx, "comment-1")).To(Equal(comment.ErrCommentNotFound))
+		})
+	})
+})
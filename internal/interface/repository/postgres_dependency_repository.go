@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+type postgresDependencyRepository struct {
+	db queryExecutor
+}
+
+func NewDependencyRepository(db DB) domain.DependencyRepository {
+	return &postgresDependencyRepository{db: db}
+}
+
+// Lock takes a transaction-level advisory lock per workspace. Two requests
+// adding A -> B and B -> A start from disjoint graphs, so locking rows of
+// either graph would not serialise them.
+func (r *postgresDependencyRepository) Lock(ctx context.Context) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('task_dependencies:' || $1, 0))`, workspaceID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("lock dependencies: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresDependencyRepository) Add(ctx context.Context, d domain.Dependency) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO task_dependencies (task_id, blocker_id, workspace_id) VALUES ($1, $2, $3)
+			 ON CONFLICT DO NOTHING`,
+			d.TaskID, d.BlockerID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("add dependency %q -> %q: %w", d.TaskID, d.BlockerID, err)
+	}
+	return nil
+}
+
+func (r *postgresDependencyRepository) Remove(ctx context.Context, d domain.Dependency) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`DELETE FROM task_dependencies WHERE task_id = $1 AND blocker_id = $2 AND workspace_id = $3`,
+			d.TaskID, d.BlockerID, workspaceID,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("remove dependency %q -> %q: %w", d.TaskID, d.BlockerID, err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrDependencyNotFound
+	}
+	return nil
+}
+
+func (r *postgresDependencyRepository) FindGraph(ctx context.Context, taskID string) ([]domain.Dependency, error) {
+	var deps []domain.Dependency
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		// UNION rather than UNION ALL drops edges already visited, which also
+		// ends the recursion on cycles.
+		var err error
+		deps, err = queryDependencies(ctx, q,
+			`WITH RECURSIVE upstream AS (
+				SELECT task_id, blocker_id FROM task_dependencies WHERE task_id = $1 AND workspace_id = $2
+				UNION
+				SELECT d.task_id, d.blocker_id FROM task_dependencies d
+				JOIN upstream u ON d.task_id = u.blocker_id WHERE d.workspace_id = $2
+			), downstream AS (
+				SELECT task_id, blocker_id FROM task_dependencies WHERE blocker_id = $1 AND workspace_id = $2
+				UNION
+				SELECT d.task_id, d.blocker_id FROM task_dependencies d
+				JOIN downstream w ON d.blocker_id = w.task_id WHERE d.workspace_id = $2
+			)
+			SELECT task_id, blocker_id FROM upstream
+			UNION
+			SELECT task_id, blocker_id FROM downstream
+			ORDER BY task_id, blocker_id`,
+			taskID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find dependency graph of %q: %w", taskID, err)
+	}
+	return deps, nil
+}
+
+func (r *postgresDependencyRepository) FindOpenBlockers(ctx context.Context, taskIDs []string) ([]domain.Dependency, error) {
+	var deps []domain.Dependency
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		var err error
+		deps, err = queryDependencies(ctx, q,
+			`SELECT d.task_id, d.blocker_id FROM task_dependencies d
+			 JOIN tasks b ON b.id = d.blocker_id
+			 WHERE d.task_id = ANY($1) AND d.workspace_id = $2 AND b.deleted_at IS NULL AND b.status IN `+openStatuses+`
+			 ORDER BY d.task_id, d.blocker_id`,
+			taskIDs, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find open blockers: %w", err)
+	}
+	return deps, nil
+}
+
+func queryDependencies(ctx context.Context, q queryExecutor, sql string, args ...any) ([]domain.Dependency, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deps := []domain.Dependency{}
+	for rows.Next() {
+		var d domain.Dependency
+		if err := rows.Scan(&d.TaskID, &d.BlockerID); err != nil {
+			return nil, err
+		}
+		deps = append(deps, d)
+	}
+	return deps, rows.Err()
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("postgresDependencyRepository", func() {
+	var (
+		ctx        context.Context
+		repo       *postgresDependencyRepository
+		execState  *stubExecState
+		dependency task.Dependency
+	)
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		execState = &stubExecState{}
+		repo = &postgresDependencyRepository{db: &stubQueryExecutor{execState: execState}}
+		dependency = task.Dependency{TaskID: "task-1", BlockerID: "task-2"}
+	})
+
+	Describe("Add", func() {
+		It("ignores dependencies that already exist", func() {
+			Expect(repo.Add(ctx, dependency)).To(Succeed())
+			Expect(execState.committed).To(BeTrue())
+			Expect(execState.lastCall().sql).To(ContainSubstring("ON CONFLICT DO NOTHING"))
+			Expect(execState.lastCall().args).To(Equal([]any{"task-1", "task-2", workspaceA}))
+		})
+	})
+
+	Describe("Remove", func() {
+		It("reports a missing dependency", func() {
+			execState.rowsAffected = 0
+
+			err := repo.Remove(ctx, dependency)
+
+			Expect(err).To(Equal(task.ErrDependencyNotFound))
+		})
+
+		It("wraps database errors", func() {
+			execState.execErr = errors.New("connection lost")
+
+			err := repo.Remove(ctx, dependency)
+
+			Expect(err).To(MatchError(ContainSubstring("connection lost")))
+			Expect(execState.rolledBack).To(BeTrue())
+		})
+	})
+
+	Describe("FindGraph", func() {
+		It("walks blockers and dependents in the caller's workspace", func() {
+			_, _ = repo.FindGraph(ctx, "task-1")
+
+			call := execState.lastCall()
+			Expect(call.sql).To(ContainSubstring("WITH RECURSIVE upstream"))
+			Expect(call.sql).To(ContainSubstring("downstream"))
+			Expect(call.args).To(Equal([]any{"task-1", workspaceA}))
+		})
+	})
+
+	Describe("FindOpenBlockers", func() {
+		It("only considers blockers that are still open", func() {
+			_, _ = repo.FindOpenBlockers(ctx, []string{"task-1"})
+
+			call := execState.lastCall()
+			Expect(call.sql).To(ContainSubstring("b.status IN " + openStatuses))
+			Expect(call.args).To(Equal([]any{[]string{"task-1"}, workspaceA}))
+		})
+	})
+})
@@ -0,0 +1,668 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+// DefaultSnapshotInterval is the number of events between two snapshots of a
+// task stream.
+const DefaultSnapshotInterval = 50
+
+// postgresEventTaskRepository stores tasks as streams of domain.Event in
+// task_events and rebuilds them by replaying the stream from the latest
+// snapshot in task_snapshots. Reads replay every stream of the workspace and
+// filter in memory, so it suits experiments rather than large workspaces.
+//
+// Every append also projects the resulting task onto its row in tasks, in
+// the same transaction, so that labels, comments, dependencies, reminders
+// and the trash purger, which all work on that table, see event-sourced
+// tasks too. Reads take what only the row knows from it: the labels and
+// comments, a project_id cleared by the deletion of the project, and whether
+// the task was purged at all.
+type postgresEventTaskRepository struct {
+	db            queryExecutor
+	snapshotEvery int
+	now           func() time.Time
+}
+
+// NewEventRepository returns an event-sourced domain.Repository that writes a
+// snapshot every snapshotEvery events of a stream.
+func NewEventRepository(db DB, snapshotEvery int) domain.Repository {
+	if snapshotEvery <= 0 {
+		snapshotEvery = DefaultSnapshotInterval
+	}
+	return &postgresEventTaskRepository{db: db, snapshotEvery: snapshotEvery, now: time.Now}
+}
+
+// stream is a replayed task and the version of its last event.
+type stream struct {
+	task    *domain.Task
+	version int
+}
+
+// eventState is the JSON form of a task in event payloads and snapshots.
+// Payloads other than TaskCreated only hold the keys listed in payloadKeys.
+type eventState struct {
+	ID         string          `json:"id"`
+	ProjectID  *string         `json:"project_id"`
+	ParentID   *string         `json:"parent_id"`
+	Title      string          `json:"title"`
+	Status     domain.Status   `json:"status"`
+	Priority   domain.Priority `json:"priority"`
+	DueAt      *time.Time      `json:"due_at"`
+	Recurrence *string         `json:"recurrence"`
+	SeriesID   *string         `json:"series_id"`
+	Occurrence int             `json:"occurrence"`
+	Assignees  []string        `json:"assignees"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	DeletedAt  *time.Time      `json:"deleted_at"`
+	UserID     string          `json:"user_id"`
+}
+
+var payloadKeys = map[domain.EventType][]string{
+	domain.EventTaskRenamed:       {"title"},
+	domain.EventTaskCompleted:     {"status"},
+	domain.EventTaskStatusChanged: {"status"},
+	domain.EventTaskRescheduled:   {"priority", "due_at", "recurrence", "series_id", "occurrence"},
+	domain.EventTaskMoved:         {"project_id", "parent_id"},
+	domain.EventTaskAssigned:      {"user_id"},
+	domain.EventTaskUnassigned:    {"user_id"},
+	domain.EventTaskDeleted:       {"deleted_at"},
+	domain.EventTaskRestored:      {},
+}
+
+func (r *postgresEventTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	var task *domain.Task
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		streams, err := r.loadWorkspace(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		if s, ok := streams[id]; ok && s.task.DeletedAt == nil {
+			task = s.task
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find task by id %q: %w", id, err)
+	}
+	if task == nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (r *postgresEventTaskRepository) FindAll(ctx context.Context, filter domain.ListFilter) ([]*domain.Task, error) {
+	tasks := []*domain.Task{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		streams, err := r.loadWorkspace(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		for _, s := range streams {
+			if s.task.DeletedAt == nil && matches(s.task, filter) {
+				tasks = append(tasks, s.task)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	if filter.SortBy == domain.SortByDueAt {
+		slices.SortFunc(tasks, compareDueAt)
+	} else {
+		slices.SortFunc(tasks, compareCreatedAt)
+	}
+	return tasks, nil
+}
+
+func (r *postgresEventTaskRepository) FindTree(ctx context.Context, id string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		streams, err := r.loadWorkspace(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		tasks = tree(streamTasks(streams), id, func(child, _ *domain.Task) bool { return child.DeletedAt == nil })
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find task tree %q: %w", id, err)
+	}
+	if len(tasks) == 0 || tasks[0].DeletedAt != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	return tasks, nil
+}
+
+func (r *postgresEventTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		state := task.Clone()
+		state.WorkspaceID = workspaceID
+		created := domain.Event{TaskID: task.ID, Type: domain.EventTaskCreated, OccurredAt: task.CreatedAt, State: state}
+		if err := r.append(ctx, q, workspaceID, stream{}, created); err != nil {
+			return err
+		}
+		task.WorkspaceID = workspaceID
+		return nil
+	})
+	if err == domain.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("save task %q: %w", task.ID, err)
+	}
+	return nil
+}
+
+// Update appends the events that turn the stored task into task. The stream
+// version read here is the expected version of the append: a concurrent
+// writer appending first makes Update fail with ErrVersionConflict.
+func (r *postgresEventTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		s, err := r.loadLive(ctx, q, workspaceID, task.ID)
+		if err != nil {
+			return err
+		}
+		return r.append(ctx, q, workspaceID, s, domain.Changes(s.task, task)...)
+	})
+	if err == domain.ErrTaskNotFound || err == domain.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("save task %q: %w", task.ID, err)
+	}
+	return nil
+}
+
+func (r *postgresEventTaskRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		streams, err := r.loadWorkspace(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		tasks := tree(streamTasks(streams), id, func(child, _ *domain.Task) bool { return child.DeletedAt == nil })
+		if len(tasks) == 0 || tasks[0].DeletedAt != nil {
+			return domain.ErrTaskNotFound
+		}
+		for _, t := range tasks {
+			deleted := domain.Event{TaskID: t.ID, Type: domain.EventTaskDeleted, OccurredAt: deletedAt,
+				State: &domain.Task{DeletedAt: &deletedAt}}
+			if err := r.append(ctx, q, workspaceID, *streams[t.ID], deleted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == domain.ErrTaskNotFound || err == domain.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("delete task %q: %w", id, err)
+	}
+	return nil
+}
+
+func (r *postgresEventTaskRepository) FindDeleted(ctx context.Context) ([]*domain.Task, error) {
+	tasks := []*domain.Task{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		streams, err := r.loadWorkspace(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		for _, s := range streams {
+			if s.task.DeletedAt != nil {
+				tasks = append(tasks, s.task)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list deleted tasks: %w", err)
+	}
+	slices.SortFunc(tasks, func(a, b *domain.Task) int {
+		return cmp.Or(b.DeletedAt.Compare(*a.DeletedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return tasks, nil
+}
+
+func (r *postgresEventTaskRepository) Restore(ctx context.Context, id string) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		streams, err := r.loadWorkspace(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		root, ok := streams[id]
+		if !ok || root.task.DeletedAt == nil {
+			return domain.ErrTaskNotFound
+		}
+		if root.task.ParentID != nil {
+			if parent, ok := streams[*root.task.ParentID]; ok && parent.task.DeletedAt != nil {
+				return domain.ErrParentDeleted
+			}
+		}
+		sameDeletion := func(child, parent *domain.Task) bool {
+			return child.DeletedAt != nil && child.DeletedAt.Equal(*parent.DeletedAt)
+		}
+		for _, t := range tree(streamTasks(streams), id, sameDeletion) {
+			restored := domain.Event{TaskID: t.ID, Type: domain.EventTaskRestored, OccurredAt: r.now().UTC()}
+			if err := r.append(ctx, q, workspaceID, *streams[t.ID], restored); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == domain.ErrTaskNotFound || err == domain.ErrParentDeleted || err == domain.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("restore task %q: %w", id, err)
+	}
+	return nil
+}
+
+func (r *postgresEventTaskRepository) AddAssignee(ctx context.Context, taskID string, userID string) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		s, err := r.loadLive(ctx, q, workspaceID, taskID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(s.task.Assignees, userID) {
+			return nil
+		}
+		assigned := domain.Event{TaskID: taskID, Type: domain.EventTaskAssigned, OccurredAt: r.now().UTC(), UserID: userID}
+		return r.append(ctx, q, workspaceID, s, assigned)
+	})
+	if err == domain.ErrTaskNotFound || err == domain.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("assign task %q to %q: %w", taskID, userID, err)
+	}
+	return nil
+}
+
+func (r *postgresEventTaskRepository) RemoveAssignee(ctx context.Context, taskID string, userID string) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		s, err := r.loadLive(ctx, q, workspaceID, taskID)
+		if err == domain.ErrTaskNotFound {
+			return domain.ErrAssigneeNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(s.task.Assignees, userID) {
+			return domain.ErrAssigneeNotFound
+		}
+		unassigned := domain.Event{TaskID: taskID, Type: domain.EventTaskUnassigned, OccurredAt: r.now().UTC(), UserID: userID}
+		return r.append(ctx, q, workspaceID, s, unassigned)
+	})
+	if err == domain.ErrAssigneeNotFound || err == domain.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("unassign %q from task %q: %w", userID, taskID, err)
+	}
+	return nil
+}
+
+func (r *postgresEventTaskRepository) FindDueWithin(ctx context.Context, now time.Time, window time.Duration) ([]*domain.Task, error) {
+	tasks := []*domain.Task{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		streams, err := r.loadWorkspace(ctx, q, workspaceID)
+		if err != nil {
+			return err
+		}
+		end := now.Add(window)
+		for _, s := range streams {
+			t := s.task
+			if t.DeletedAt == nil && t.IsOpen() && t.DueAt != nil && !t.DueAt.Before(now) && t.DueAt.Before(end) {
+				tasks = append(tasks, t)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tasks due within %s: %w", window, err)
+	}
+	slices.SortFunc(tasks, compareDueAt)
+	return tasks, nil
+}
+
+// loadLive replays the stream of one task, which must not be in the trash.
+func (r *postgresEventTaskRepository) loadLive(ctx context.Context, q queryExecutor, workspaceID, id string) (stream, error) {
+	streams, err := r.loadStreams(ctx, q, workspaceID, &id)
+	if err != nil {
+		return stream{}, err
+	}
+	s, ok := streams[id]
+	if !ok || s.task.DeletedAt != nil {
+		return stream{}, domain.ErrTaskNotFound
+	}
+	return *s, nil
+}
+
+// loadWorkspace replays every stream of the workspace and fills in the
+// subtask progress of each task.
+func (r *postgresEventTaskRepository) loadWorkspace(ctx context.Context, q queryExecutor, workspaceID string) (map[string]*stream, error) {
+	streams, err := r.loadStreams(ctx, q, workspaceID, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range streams {
+		child := s.task
+		if child.DeletedAt != nil || child.ParentID == nil || child.Status == domain.StatusCancelled {
+			continue
+		}
+		if parent, ok := streams[*child.ParentID]; ok {
+			parent.task.Subtasks.Total++
+			if child.Status == domain.StatusComplete {
+				parent.task.Subtasks.Completed++
+			}
+		}
+	}
+	return streams, nil
+}
+
+// loadStreams reads the latest snapshots and the events after them, of one
+// task when id is set and of the whole workspace otherwise.
+func (r *postgresEventTaskRepository) loadStreams(ctx context.Context, q queryExecutor, workspaceID string, id *string) (map[string]*stream, error) {
+	streams := map[string]*stream{}
+	rows, err := q.Query(ctx,
+		`SELECT task_id, version, state FROM task_snapshots
+		 WHERE workspace_id = $1 AND ($2::uuid IS NULL OR task_id = $2)`,
+		workspaceID, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var taskID string
+		var version int
+		var state []byte
+		if err := rows.Scan(&taskID, &version, &state); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		var payload eventState
+		if err := json.Unmarshal(state, &payload); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("decode snapshot of task %q: %w", taskID, err)
+		}
+		task, err := payload.task()
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		streams[taskID] = &stream{task: task, version: version}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = q.Query(ctx,
+		`SELECT e.task_id, e.version, e.type, e.payload, e.occurred_at
+		 FROM task_events e LEFT JOIN task_snapshots s ON s.task_id = e.task_id
+		 WHERE e.workspace_id = $1 AND ($2::uuid IS NULL OR e.task_id = $2) AND e.version > COALESCE(s.version, 0)
+		 ORDER BY e.task_id, e.version`,
+		workspaceID, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e domain.Event
+		var encoded []byte
+		if err := rows.Scan(&e.TaskID, &e.Version, &e.Type, &encoded, &e.OccurredAt); err != nil {
+			return nil, err
+		}
+		var payload eventState
+		if err := json.Unmarshal(encoded, &payload); err != nil {
+			return nil, fmt.Errorf("decode event %d of task %q: %w", e.Version, e.TaskID, err)
+		}
+		if e.State, err = payload.task(); err != nil {
+			return nil, err
+		}
+		e.UserID = payload.UserID
+
+		s, ok := streams[e.TaskID]
+		if !ok {
+			s = &stream{}
+			if s.task, err = domain.Replay(nil, []domain.Event{e}); err != nil {
+				return nil, err
+			}
+			streams[e.TaskID] = s
+		} else if err := s.task.Apply(e); err != nil {
+			return nil, err
+		}
+		s.version = e.Version
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := annotate(ctx, q, workspaceID, id, streams); err != nil {
+		return nil, err
+	}
+	for _, s := range streams {
+		s.task.WorkspaceID = workspaceID
+	}
+	return streams, nil
+}
+
+// annotate fills in the labels, comment counts and project IDs of the
+// projected rows, and drops the streams whose row was purged.
+func annotate(ctx context.Context, q queryExecutor, workspaceID string, id *string, streams map[string]*stream) error {
+	rows, err := q.Query(ctx,
+		`SELECT t.id, t.project_id,
+		 ARRAY(SELECT l.name FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+		 WHERE tl.task_id = t.id ORDER BY l.name),
+		 (SELECT count(*) FROM comments c WHERE c.task_id = t.id)
+		 FROM tasks t WHERE t.workspace_id = $1 AND ($2::uuid IS NULL OR t.id = $2)`,
+		workspaceID, id,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	projected := map[string]bool{}
+	for rows.Next() {
+		var taskID string
+		var projectID *string
+		var labels []string
+		var comments int
+		if err := rows.Scan(&taskID, &projectID, &labels, &comments); err != nil {
+			return err
+		}
+		s, ok := streams[taskID]
+		if !ok {
+			continue
+		}
+		projected[taskID] = true
+		s.task.ProjectID = projectID
+		s.task.Labels = labels
+		s.task.CommentCount = comments
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for taskID := range streams {
+		if !projected[taskID] {
+			delete(streams, taskID)
+		}
+	}
+	return nil
+}
+
+// append stores events after the version of s and writes a snapshot when the
+// stream passes a multiple of snapshotEvery.
+func (r *postgresEventTaskRepository) append(ctx context.Context, q queryExecutor, workspaceID string, s stream, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	task := s.task
+	for i, e := range events {
+		e.Version = s.version + i + 1
+		encoded, err := encodePayload(e)
+		if err != nil {
+			return err
+		}
+		_, err = q.Exec(ctx,
+			`INSERT INTO task_events (task_id, version, workspace_id, type, payload, occurred_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+			e.TaskID, e.Version, workspaceID, e.Type, encoded, e.OccurredAt,
+		)
+		if isUniqueViolation(err) {
+			return domain.ErrVersionConflict
+		}
+		if err != nil {
+			return err
+		}
+		if task, err = domain.Replay(task, []domain.Event{e}); err != nil {
+			return err
+		}
+	}
+
+	if err := projectTask(ctx, q, workspaceID, task); err != nil {
+		return err
+	}
+
+	version := s.version + len(events)
+	if version/r.snapshotEvery == s.version/r.snapshotEvery {
+		return nil
+	}
+	state, err := json.Marshal(toEventState(task))
+	if err != nil {
+		return fmt.Errorf("encode snapshot of task %q: %w", task.ID, err)
+	}
+	_, err = q.Exec(ctx,
+		`INSERT INTO task_snapshots (task_id, workspace_id, version, state) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (task_id) DO UPDATE SET version = EXCLUDED.version, state = EXCLUDED.state`,
+		task.ID, workspaceID, version, state,
+	)
+	return err
+}
+
+// projectTask writes task to its row in tasks and its assignees to
+// task_assignees.
+func projectTask(ctx context.Context, q queryExecutor, workspaceID string, task *domain.Task) error {
+	_, err := q.Exec(ctx,
+		`INSERT INTO tasks (`+taskColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		 ON CONFLICT (id) DO UPDATE SET project_id = EXCLUDED.project_id, parent_id = EXCLUDED.parent_id,
+		 title = EXCLUDED.title, status = EXCLUDED.status, priority = EXCLUDED.priority, due_at = EXCLUDED.due_at,
+		 recurrence = EXCLUDED.recurrence, series_id = EXCLUDED.series_id, occurrence = EXCLUDED.occurrence,
+		 updated_at = EXCLUDED.updated_at, deleted_at = EXCLUDED.deleted_at`,
+		task.ID, workspaceID, task.ProjectID, task.ParentID, task.Title, task.Status, task.Priority, task.DueAt,
+		recurrenceRule(task.Recurrence), task.SeriesID, task.Occurrence, task.CreatedAt, task.UpdatedAt, task.DeletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("project task %q: %w", task.ID, err)
+	}
+	assignees := task.Assignees
+	if assignees == nil {
+		assignees = []string{}
+	}
+	_, err = q.Exec(ctx,
+		`DELETE FROM task_assignees WHERE task_id = $1 AND workspace_id = $2 AND user_id <> ALL($3::text[])`,
+		task.ID, workspaceID, assignees,
+	)
+	if err != nil {
+		return fmt.Errorf("project assignees of task %q: %w", task.ID, err)
+	}
+	_, err = q.Exec(ctx,
+		`INSERT INTO task_assignees (task_id, user_id, workspace_id) SELECT $1, unnest($2::text[]), $3
+		 ON CONFLICT DO NOTHING`,
+		task.ID, assignees, workspaceID,
+	)
+	if err != nil {
+		return fmt.Errorf("project assignees of task %q: %w", task.ID, err)
+	}
+	return nil
+}
+
+// encodePayload keeps the keys of the state e carries; TaskCreated carries
+// the whole task.
+func encodePayload(e domain.Event) ([]byte, error) {
+	state := eventState{UserID: e.UserID}
+	if e.State != nil {
+		state = toEventState(e.State)
+		state.UserID = e.UserID
+	}
+	encoded, err := json.Marshal(state)
+	if err != nil || e.Type == domain.EventTaskCreated {
+		return encoded, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	payload := map[string]json.RawMessage{}
+	for _, key := range payloadKeys[e.Type] {
+		payload[key] = fields[key]
+	}
+	return json.Marshal(payload)
+}
+
+func toEventState(t *domain.Task) eventState {
+	return eventState{
+		ID:         t.ID,
+		ProjectID:  t.ProjectID,
+		ParentID:   t.ParentID,
+		Title:      t.Title,
+		Status:     t.Status,
+		Priority:   t.Priority,
+		DueAt:      t.DueAt,
+		Recurrence: recurrenceRule(t.Recurrence),
+		SeriesID:   t.SeriesID,
+		Occurrence: t.Occurrence,
+		Assignees:  t.Assignees,
+		CreatedAt:  t.CreatedAt,
+		UpdatedAt:  t.UpdatedAt,
+		DeletedAt:  t.DeletedAt,
+	}
+}
+
+func (s eventState) task() (*domain.Task, error) {
+	t := &domain.Task{
+		ID:         s.ID,
+		ProjectID:  s.ProjectID,
+		ParentID:   s.ParentID,
+		Title:      s.Title,
+		Status:     s.Status,
+		Priority:   s.Priority,
+		DueAt:      s.DueAt,
+		SeriesID:   s.SeriesID,
+		Occurrence: s.Occurrence,
+		Assignees:  s.Assignees,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+		DeletedAt:  s.DeletedAt,
+	}
+	if s.Recurrence != nil {
+		recurrence, err := domain.ParseRecurrence(*s.Recurrence)
+		if err != nil {
+			return nil, fmt.Errorf("task %q: %w", s.ID, err)
+		}
+		t.Recurrence = recurrence
+	}
+	return t, nil
+}
+
+func streamTasks(streams map[string]*stream) []*domain.Task {
+	tasks := make([]*domain.Task, 0, len(streams))
+	for _, s := range streams {
+		tasks = append(tasks, s.task)
+	}
+	return tasks
+}
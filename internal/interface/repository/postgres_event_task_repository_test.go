@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("postgresEventTaskRepository", func() {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var (
+		ctx       context.Context
+		repo      *postgresEventTaskRepository
+		execState *stubExecState
+		current   *domain.Task
+	)
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		execState = &stubExecState{}
+		repo = &postgresEventTaskRepository{db: &stubQueryExecutor{execState: execState}, snapshotEvery: 3, now: func() time.Time { return now }}
+		current = &domain.Task{ID: "task-1", Title: "Write report", Status: domain.StatusTodo,
+			Priority: domain.PriorityMedium, CreatedAt: now, UpdatedAt: now}
+	})
+
+	inserts := func(table string) []stubCall {
+		var calls []stubCall
+		for _, call := range execState.calls {
+			if strings.Contains(call.sql, "INSERT INTO "+table) {
+				calls = append(calls, call)
+			}
+		}
+		return calls
+	}
+
+	Describe("Create", func() {
+		It("appends TaskCreated with the whole task as version 1", func() {
+			current.Assignees = []string{"alice"}
+
+			Expect(repo.Create(ctx, current)).To(Succeed())
+
+			events := inserts("task_events")
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].args[:4]).To(Equal([]any{"task-1", 1, workspaceA, domain.EventTaskCreated}))
+			var payload eventState
+			Expect(json.Unmarshal(events[0].args[4].([]byte), &payload)).To(Succeed())
+			Expect(payload.Title).To(Equal("Write report"))
+			Expect(payload.Assignees).To(Equal([]string{"alice"}))
+			Expect(current.WorkspaceID).To(Equal(workspaceA))
+			Expect(execState.committed).To(BeTrue())
+		})
+
+		It("reports an existing stream as a version conflict", func() {
+			execState.execErr = fmt.Errorf("insert: %w", &pgconn.PgError{Code: uniqueViolation})
+
+			Expect(repo.Create(ctx, current)).To(Equal(domain.ErrVersionConflict))
+			Expect(execState.rolledBack).To(BeTrue())
+		})
+	})
+
+	Describe("append", func() {
+		It("numbers events after the stream version and stores only their own fields", func() {
+			after := current.Clone()
+			Expect(after.Rename("Write summary", now.Add(time.Hour))).To(Succeed())
+
+			err := repo.append(ctx, &stubQueryExecutor{execState: execState}, workspaceA,
+				stream{task: current, version: 4}, domain.Changes(current, after)...)
+
+			Expect(err).NotTo(HaveOccurred())
+			events := inserts("task_events")
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].args[1]).To(Equal(5))
+			Expect(events[0].args[3]).To(Equal(domain.EventTaskRenamed))
+			Expect(string(events[0].args[4].([]byte))).To(Equal(`{"title":"Write summary"}`))
+			Expect(inserts("task_snapshots")).To(BeEmpty())
+		})
+
+		It("writes a snapshot of the new state when the stream passes the interval", func() {
+			assigned := domain.Event{TaskID: "task-1", Type: domain.EventTaskAssigned, OccurredAt: now, UserID: "bob"}
+
+			err := repo.append(ctx, &stubQueryExecutor{execState: execState}, workspaceA,
+				stream{task: current, version: 2}, assigned)
+
+			Expect(err).NotTo(HaveOccurred())
+			snapshots := inserts("task_snapshots")
+			Expect(snapshots).To(HaveLen(1))
+			Expect(snapshots[0].args[:3]).To(Equal([]any{"task-1", workspaceA, 3}))
+			var state eventState
+			Expect(json.Unmarshal(snapshots[0].args[3].([]byte), &state)).To(Succeed())
+			Expect(state.Assignees).To(Equal([]string{"bob"}))
+			Expect(current.Assignees).To(BeEmpty())
+		})
+
+		It("projects the new state onto tasks and task_assignees", func() {
+			assigned := domain.Event{TaskID: "task-1", Type: domain.EventTaskAssigned, OccurredAt: now, UserID: "bob"}
+
+			err := repo.append(ctx, &stubQueryExecutor{execState: execState}, workspaceA,
+				stream{task: current, version: 1}, assigned)
+
+			Expect(err).NotTo(HaveOccurred())
+			rows := inserts("tasks")
+			Expect(rows).To(HaveLen(1))
+			Expect(rows[0].sql).To(ContainSubstring("ON CONFLICT (id) DO UPDATE"))
+			Expect(rows[0].args[:2]).To(Equal([]any{"task-1", workspaceA}))
+			assignees := inserts("task_assignees")
+			Expect(assignees).To(HaveLen(1))
+			Expect(assignees[0].args[1]).To(Equal([]string{"bob"}))
+		})
+
+		It("reports a version taken by a concurrent writer as a conflict", func() {
+			execState.execErr = fmt.Errorf("insert: %w", &pgconn.PgError{Code: uniqueViolation})
+			assigned := domain.Event{TaskID: "task-1", Type: domain.EventTaskAssigned, OccurredAt: now, UserID: "bob"}
+
+			err := repo.append(ctx, &stubQueryExecutor{execState: execState}, workspaceA,
+				stream{task: current, version: 1}, assigned)
+
+			Expect(err).To(Equal(domain.ErrVersionConflict))
+		})
+	})
+
+	Describe("tree", func() {
+		It("orders descendants by depth and creation, skipping those keep rejects", func() {
+			parentID, childID := "task-1", "child-1"
+			deletedAt := now
+			child := &domain.Task{ID: childID, ParentID: &parentID, CreatedAt: now.Add(2 * time.Minute)}
+			older := &domain.Task{ID: "child-0", ParentID: &parentID, CreatedAt: now.Add(time.Minute)}
+			deleted := &domain.Task{ID: "child-2", ParentID: &parentID, CreatedAt: now, DeletedAt: &deletedAt}
+			grandchild := &domain.Task{ID: "grandchild", ParentID: &childID, CreatedAt: now}
+			all := []*domain.Task{grandchild, current, child, older, deleted}
+
+			tasks := tree(all, "task-1", func(c, _ *domain.Task) bool { return c.DeletedAt == nil })
+
+			ids := make([]string, len(tasks))
+			for i, t := range tasks {
+				ids[i] = t.ID
+			}
+			Expect(ids).To(Equal([]string{"task-1", "child-0", "child-1", "grandchild"}))
+		})
+	})
+
+	Describe("matches", func() {
+		It("matches labels by name against those read from the projected row", func() {
+			current.Labels = []string{"backend"}
+
+			Expect(matches(current, domain.ListFilter{})).To(BeTrue())
+			Expect(matches(current, domain.ListFilter{Labels: []string{"backend", "bug"}})).To(BeTrue())
+			Expect(matches(current, domain.ListFilter{Labels: []string{"bug"}})).To(BeFalse())
+		})
+	})
+})
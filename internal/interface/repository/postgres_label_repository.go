@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+)
+
+// uniqueViolation is the SQLSTATE Postgres reports for duplicate keys.
+const uniqueViolation = "23505"
+
+type postgresLabelRepository struct {
+	db queryExecutor
+}
+
+func NewLabelRepository(db DB) label.Repository {
+	return &postgresLabelRepository{db: db}
+}
+
+func (r *postgresLabelRepository) FindAll(ctx context.Context) ([]*label.Label, error) {
+	labels := []*label.Label{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		rows, err := q.Query(ctx,
+			`SELECT id, workspace_id, name, color, created_at, updated_at
+			 FROM labels WHERE workspace_id = $1 ORDER BY name`,
+			workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			l := &label.Label{}
+			if err := rows.Scan(&l.ID, &l.WorkspaceID, &l.Name, &l.Color, &l.CreatedAt, &l.UpdatedAt); err != nil {
+				return err
+			}
+			labels = append(labels, l)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list labels: %w", err)
+	}
+	return labels, nil
+}
+
+func (r *postgresLabelRepository) FindByID(ctx context.Context, id string) (*label.Label, error) {
+	var l label.Label
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		row := q.QueryRow(ctx,
+			`SELECT id, workspace_id, name, color, created_at, updated_at
+			 FROM labels WHERE id = $1 AND workspace_id = $2`,
+			id, workspaceID,
+		)
+		return row.Scan(&l.ID, &l.WorkspaceID, &l.Name, &l.Color, &l.CreatedAt, &l.UpdatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return nil, label.ErrLabelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find label by id %q: %w", id, err)
+	}
+	return &l, nil
+}
+
+func (r *postgresLabelRepository) Create(ctx context.Context, l *label.Label) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO labels (id, workspace_id, name, color, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6)`,
+			l.ID, workspaceID, l.Name, l.Color, l.CreatedAt, l.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		l.WorkspaceID = workspaceID
+		return nil
+	})
+	if isUniqueViolation(err) {
+		return label.ErrLabelExists
+	}
+	if err != nil {
+		return fmt.Errorf("save label %q: %w", l.ID, err)
+	}
+	return nil
+}
+
+func (r *postgresLabelRepository) Update(ctx context.Context, l *label.Label) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`UPDATE labels SET name = $1, color = $2, updated_at = $3 WHERE id = $4 AND workspace_id = $5`,
+			l.Name, l.Color, l.UpdatedAt, l.ID, workspaceID,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if isUniqueViolation(err) {
+		return label.ErrLabelExists
+	}
+	if err != nil {
+		return fmt.Errorf("save label %q: %w", l.ID, err)
+	}
+
+	if rowsAffected == 0 {
+		return label.ErrLabelNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresLabelRepository) Delete(ctx context.Context, id string) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx, `DELETE FROM labels WHERE id = $1 AND workspace_id = $2`, id, workspaceID)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete label %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return label.ErrLabelNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresLabelRepository) Attach(ctx context.Context, taskID string, labelID string) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO task_labels (task_id, label_id, workspace_id) VALUES ($1, $2, $3)
+			 ON CONFLICT DO NOTHING`,
+			taskID, labelID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("attach label %q to task %q: %w", labelID, taskID, err)
+	}
+	return nil
+}
+
+func (r *postgresLabelRepository) Detach(ctx context.Context, taskID string, labelID string) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`DELETE FROM task_labels WHERE task_id = $1 AND label_id = $2 AND workspace_id = $3`,
+			taskID, labelID, workspaceID,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("detach label %q from task %q: %w", labelID, taskID, err)
+	}
+
+	if rowsAffected == 0 {
+		return label.ErrLabelNotAttached
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
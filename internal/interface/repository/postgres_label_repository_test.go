@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("postgresLabelRepository", func() {
+	var (
+		ctx       context.Context
+		repo      *postgresLabelRepository
+		execState *stubExecState
+		bug       *label.Label
+	)
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		execState = &stubExecState{}
+		repo = &postgresLabelRepository{db: &stubQueryExecutor{execState: execState}}
+		bug = &label.Label{ID: "label-1", Name: "bug", Color: "#d73a4a", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	})
+
+	Describe("Create", func() {
+		It("stamps the label with the current workspace", func() {
+			Expect(repo.Create(ctx, bug)).To(Succeed())
+			Expect(bug.WorkspaceID).To(Equal(workspaceA))
+		})
+
+		It("reports a duplicate name", func() {
+			execState.execErr = fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505"})
+
+			err := repo.Create(ctx, bug)
+
+			Expect(err).To(Equal(label.ErrLabelExists))
+		})
+	})
+
+	Describe("Update", func() {
+		It("returns label not found", func() {
+			execState.rowsAffected = 0
+
+			err := repo.Update(ctx, bug)
+
+			Expect(err).To(Equal(label.ErrLabelNotFound))
+		})
+	})
+
+	Describe("Attach", func() {
+		It("ignores labels that are already attached", func() {
+			Expect(repo.Attach(ctx, "task-1", "label-1")).To(Succeed())
+			Expect(execState.lastCall().sql).To(ContainSubstring("ON CONFLICT DO NOTHING"))
+			Expect(execState.lastCall().args).To(Equal([]any{"task-1", "label-1", workspaceA}))
+		})
+	})
+
+	Describe("Detach", func() {
+		It("reports a label that is not attached", func() {
+			execState.rowsAffected = 0
+
+			err := repo.Detach(ctx, "task-1", "label-1")
+
+			Expect(err).To(Equal(label.ErrLabelNotAttached))
+		})
+	})
+})
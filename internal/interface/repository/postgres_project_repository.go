@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+)
+
+type postgresProjectRepository struct {
+	db queryExecutor
+}
+
+func NewProjectRepository(db DB) project.Repository {
+	return &postgresProjectRepository{db: db}
+}
+
+func (r *postgresProjectRepository) FindAll(ctx context.Context) ([]*project.Project, error) {
+	projects := []*project.Project{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		rows, err := q.Query(ctx,
+			`SELECT id, workspace_id, name, description, archived, created_at, updated_at
+			 FROM projects WHERE workspace_id = $1 ORDER BY created_at, id`,
+			workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			p := &project.Project{}
+			if err := rows.Scan(&p.ID, &p.WorkspaceID, &p.Name, &p.Description, &p.Archived, &p.CreatedAt, &p.UpdatedAt); err != nil {
+				return err
+			}
+			projects = append(projects, p)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (r *postgresProjectRepository) FindByID(ctx context.Context, id string) (*project.Project, error) {
+	var p project.Project
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		row := q.QueryRow(ctx,
+			`SELECT id, workspace_id, name, description, archived, created_at, updated_at
+			 FROM projects WHERE id = $1 AND workspace_id = $2`,
+			id, workspaceID,
+		)
+		return row.Scan(&p.ID, &p.WorkspaceID, &p.Name, &p.Description, &p.Archived, &p.CreatedAt, &p.UpdatedAt)
+	})
+	if err == pgx.ErrNoRows {
+		return nil, project.ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find project by id %q: %w", id, err)
+	}
+	return &p, nil
+}
+
+func (r *postgresProjectRepository) Create(ctx context.Context, p *project.Project) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO projects (id, workspace_id, name, description, archived, created_at, updated_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			p.ID, workspaceID, p.Name, p.Description, p.Archived, p.CreatedAt, p.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		p.WorkspaceID = workspaceID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save project %q: %w", p.ID, err)
+	}
+	return nil
+}
+
+func (r *postgresProjectRepository) Update(ctx context.Context, p *project.Project) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`UPDATE projects SET name = $1, description = $2, archived = $3, updated_at = $4
+			 WHERE id = $5 AND workspace_id = $6`,
+			p.Name, p.Description, p.Archived, p.UpdatedAt, p.ID, workspaceID,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save project %q: %w", p.ID, err)
+	}
+
+	if rowsAffected == 0 {
+		return project.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresProjectRepository) Delete(ctx context.Context, id string) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx, `DELETE FROM projects WHERE id = $1 AND workspace_id = $2`, id, workspaceID)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete project %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return project.ErrProjectNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit"
+)
+
+// PostgresRateLimitStore keeps token buckets in the rate_limit_buckets table,
+// so every replica draws from the same bucket of a client.
+type PostgresRateLimitStore struct {
+	db queryExecutor
+}
+
+func NewPostgresRateLimitStore(db *pgxpool.Pool) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{db: db}
+}
+
+func (s *PostgresRateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	var allowed bool
+	var tokens float64
+	err := s.db.QueryRow(ctx,
+		`SELECT allowed, tokens FROM take_rate_limit_token($1, $2, $3)`,
+		key, limit.Burst, limit.Period.Seconds(),
+	).Scan(&allowed, &tokens)
+	if err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("take rate limit token %q: %w", key, err)
+	}
+	return limit.Decide(allowed, tokens), nil
+}
+
+// Run deletes the buckets idle for longer than idle every interval until ctx
+// is done. They are full again by then, so dropping them changes nothing but
+// the size of the table.
+func (s *PostgresRateLimitStore) Run(ctx context.Context, interval time.Duration, idle time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.db.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < $1`, time.Now().Add(-idle)); err != nil {
+				log.Printf("repository: delete idle rate limit buckets: %v", err)
+			}
+		}
+	}
+}
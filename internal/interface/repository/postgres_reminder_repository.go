@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+)
+
+type postgresReminderRepository struct {
+	db queryExecutor
+}
+
+func NewReminderRepository(db DB) reminder.Store {
+	return &postgresReminderRepository{db: db}
+}
+
+func (r *postgresReminderRepository) DueWorkspaces(ctx context.Context, before time.Time) ([]string, error) {
+	var workspaces []string
+	err := retrying(ctx, r.db, true, func(q queryExecutor) error {
+		rows, err := q.Query(ctx, `SELECT id::text FROM reminder_workspaces($1) AS id ORDER BY id`, before)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		workspaces = []string{}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			workspaces = append(workspaces, id)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list reminder workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+func (r *postgresReminderRepository) Claim(ctx context.Context, rem reminder.Reminder) (bool, error) {
+	var claimed bool
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		tag, err := q.Exec(ctx,
+			`INSERT INTO reminders_sent (task_id, workspace_id, kind, due_at)
+			 VALUES ($1, $2, $3, $4)
+			 ON CONFLICT DO NOTHING`,
+			rem.TaskID, workspaceID, string(rem.Kind), rem.DueAt,
+		)
+		if err != nil {
+			return err
+		}
+		claimed = tag.RowsAffected() == 1
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("claim reminder: %w", err)
+	}
+	return claimed, nil
+}
+
+func (r *postgresReminderRepository) Release(ctx context.Context, rem reminder.Reminder) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`DELETE FROM reminders_sent
+			 WHERE task_id = $1 AND kind = $2 AND due_at = $3 AND workspace_id = $4`,
+			rem.TaskID, string(rem.Kind), rem.DueAt, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("release reminder: %w", err)
+	}
+	return nil
+}
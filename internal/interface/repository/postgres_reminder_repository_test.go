@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("postgresReminderRepository", func() {
+	var (
+		ctx       context.Context
+		repo      *postgresReminderRepository
+		execState *stubExecState
+		rem       reminder.Reminder
+	)
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		execState = &stubExecState{}
+		repo = &postgresReminderRepository{db: &stubQueryExecutor{execState: execState}}
+		rem = reminder.Reminder{
+			Kind:   reminder.KindOverdue,
+			TaskID: "task-1",
+			DueAt:  time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	})
+
+	Describe("Claim", func() {
+		It("claims a reminder that was not sent yet", func() {
+			execState.rowsAffected = 1
+
+			claimed, err := repo.Claim(ctx, rem)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+			Expect(execState.committed).To(BeTrue())
+			Expect(execState.lastCall().args).To(Equal([]any{"task-1", workspaceA, "overdue", rem.DueAt}))
+		})
+
+		It("reports reminders that were already sent", func() {
+			execState.rowsAffected = 0
+
+			claimed, err := repo.Claim(ctx, rem)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimed).To(BeFalse())
+			Expect(execState.lastCall().sql).To(ContainSubstring("ON CONFLICT DO NOTHING"))
+		})
+
+		It("requires a workspace", func() {
+			_, err := repo.Claim(context.Background(), rem)
+
+			Expect(err).To(MatchError(workspace.ErrWorkspaceRequired))
+		})
+	})
+})
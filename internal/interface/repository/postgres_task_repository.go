@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -14,72 +17,442 @@ type queryExecutor interface {
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
+// DB is what the Postgres adapters run their statements on: a *pgxpool.Pool,
+// or one wrapped by NewResilientDB.
+type DB interface {
+	queryExecutor
+}
+
+const taskColumns = `id, workspace_id, project_id, parent_id, title, status, priority, due_at, recurrence, series_id, occurrence, created_at, updated_at, deleted_at`
+
+// progressColumns count the live direct subtasks of the row aliased as t.
+// Reads select them after taskColumns; see scanTask.
+const progressColumns = `(SELECT count(*) FROM tasks s WHERE s.parent_id = t.id AND s.deleted_at IS NULL AND s.status <> 'cancelled'),
+	(SELECT count(*) FROM tasks s WHERE s.parent_id = t.id AND s.deleted_at IS NULL AND s.status = 'complete')`
+
+// annotationColumns list the assignees and label names and count the comments
+// of the row aliased as t; reads select them after progressColumns.
+const annotationColumns = `ARRAY(SELECT a.user_id FROM task_assignees a WHERE a.task_id = t.id ORDER BY a.user_id),
+	ARRAY(SELECT l.name FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+	WHERE tl.task_id = t.id ORDER BY l.name),
+	(SELECT count(*) FROM comments c WHERE c.task_id = t.id)`
+
+const selectTasks = `SELECT ` + taskColumns + `, ` + progressColumns + `, ` + annotationColumns + ` FROM tasks t`
+
+// openStatuses must match domain.Task.IsOpen and the partial index on due_at.
+const openStatuses = `('todo', 'in_progress', 'blocked')`
+
 type postgresTaskRepository struct {
 	db queryExecutor
+	// replica serves FindByID and FindAll when set.
+	replica *readReplica
 }
 
 func (r *postgresTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
-	row := r.db.QueryRow(ctx, `SELECT id, title, status, created_at, updated_at FROM tasks WHERE id = $1`, id)
-
-	var task domain.Task
-	err := row.Scan(&task.ID, &task.Title, &task.Status, &task.CreatedAt, &task.UpdatedAt)
+	var task *domain.Task
+	err := inWorkspace(ctx, r.replica.reader(ctx, r.db), func(q queryExecutor, workspaceID string) error {
+		row := q.QueryRow(ctx,
+			selectTasks+` WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL`,
+			id, workspaceID,
+		)
+		var err error
+		task, err = scanTask(row)
+		return err
+	})
 	if err == pgx.ErrNoRows {
 		return nil, domain.ErrTaskNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("find task by id %q: %w", id, err)
 	}
-	return &task, nil
+	return task, nil
 }
 
 func (r *postgresTaskRepository) Update(ctx context.Context, task *domain.Task) error {
-	result, err := r.db.Exec(ctx, `UPDATE tasks SET title = $1, status = $2, updated_at = $3 WHERE id = $4`,
-		task.Title, task.Status, task.UpdatedAt, task.ID)
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`UPDATE tasks SET project_id = $1, parent_id = $2, title = $3, status = $4, priority = $5, due_at = $6,
+			 recurrence = $7, series_id = $8, occurrence = $9, updated_at = $10
+			 WHERE id = $11 AND workspace_id = $12 AND deleted_at IS NULL`,
+			task.ProjectID, task.ParentID, task.Title, task.Status, task.Priority, task.DueAt,
+			recurrenceRule(task.Recurrence), task.SeriesID, task.Occurrence, task.UpdatedAt, task.ID, workspaceID,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("save task %q: %w", task.ID, err)
 	}
 
-	if result.RowsAffected() == 0 {
+	if rowsAffected == 0 {
 		return domain.ErrTaskNotFound
 	}
 
 	return nil
 }
 
-func New(db *pgxpool.Pool) domain.Repository {
+func New(db DB) domain.Repository {
 	return &postgresTaskRepository{db: db}
 }
 
-func (r *postgresTaskRepository) FindAll(ctx context.Context) ([]*domain.Task, error) {
-	rows, err := r.db.Query(ctx, `SELECT id, title, status, created_at, updated_at FROM tasks`)
-	if err != nil {
-		return nil, fmt.Errorf("list tasks: %w", err)
-	}
-	defer rows.Close()
+// NewWithReplica returns a repository that writes to primary and sends
+// FindByID and FindAll to replica while healthy reports it up. Replicas lag
+// behind the primary, so reads that must see earlier writes mark their ctx
+// with transaction.WithReadYourWrites.
+func NewWithReplica(primary DB, replica *pgxpool.Pool, healthy func() bool) domain.Repository {
+	return &postgresTaskRepository{db: primary, replica: &readReplica{db: replica, healthy: healthy}}
+}
 
+func (r *postgresTaskRepository) FindAll(ctx context.Context, filter domain.ListFilter) ([]*domain.Task, error) {
 	tasks := []*domain.Task{}
-	for rows.Next() {
-		t := &domain.Task{}
-		if err := rows.Scan(&t.ID, &t.Title, &t.Status, &t.CreatedAt, &t.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("list tasks: %w", err)
+	err := inWorkspace(ctx, r.replica.reader(ctx, r.db), func(q queryExecutor, workspaceID string) error {
+		conditions := []string{"workspace_id = $1", "deleted_at IS NULL"}
+		args := []any{workspaceID}
+		if filter.IDs != nil {
+			args = append(args, filter.IDs)
+			conditions = append(conditions, "id = ANY($"+strconv.Itoa(len(args))+")")
 		}
-		tasks = append(tasks, t)
-	}
-	if err := rows.Err(); err != nil {
+		if filter.ProjectID != "" {
+			args = append(args, filter.ProjectID)
+			conditions = append(conditions, "project_id = $"+strconv.Itoa(len(args)))
+		}
+		if filter.Priority != "" {
+			args = append(args, filter.Priority)
+			conditions = append(conditions, "priority = $"+strconv.Itoa(len(args)))
+		}
+		if !filter.OverdueAt.IsZero() {
+			args = append(args, filter.OverdueAt)
+			conditions = append(conditions, "status IN "+openStatuses+" AND due_at < $"+strconv.Itoa(len(args)))
+		}
+		if filter.Assignee != "" {
+			args = append(args, filter.Assignee)
+			conditions = append(conditions,
+				"EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = t.id AND a.user_id = $"+strconv.Itoa(len(args))+")")
+		}
+		if filter.SeriesID != "" {
+			args = append(args, filter.SeriesID)
+			conditions = append(conditions, "series_id = $"+strconv.Itoa(len(args)))
+		}
+		if filter.MinOccurrence > 0 {
+			args = append(args, filter.MinOccurrence)
+			conditions = append(conditions, "occurrence >= $"+strconv.Itoa(len(args)))
+		}
+		if len(filter.Labels) > 0 {
+			args = append(args, filter.Labels)
+			conditions = append(conditions, labelCondition(filter.LabelMatch, len(args)))
+		}
+
+		rows, err := q.Query(ctx,
+			selectTasks+` WHERE `+strings.Join(conditions, " AND ")+` ORDER BY `+orderBy(filter.SortBy),
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			t, err := scanTask(rows)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
 		return nil, fmt.Errorf("list tasks: %w", err)
 	}
 	return tasks, nil
 }
 
 func (r *postgresTaskRepository) Create(ctx context.Context, task *domain.Task) error {
-	_, err := r.db.Exec(ctx,
-		`INSERT INTO tasks (id, title, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`,
-		task.ID, task.Title, task.Status, task.CreatedAt, task.UpdatedAt,
-	)
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO tasks (`+taskColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+			task.ID, workspaceID, task.ProjectID, task.ParentID, task.Title, task.Status, task.Priority, task.DueAt,
+			recurrenceRule(task.Recurrence), task.SeriesID, task.Occurrence, task.CreatedAt, task.UpdatedAt, task.DeletedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if len(task.Assignees) > 0 {
+			_, err = q.Exec(ctx,
+				`INSERT INTO task_assignees (task_id, user_id, workspace_id) SELECT $1, unnest($2::text[]), $3`,
+				task.ID, task.Assignees, workspaceID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		task.WorkspaceID = workspaceID
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("save task %q: %w", task.ID, err)
 	}
 	return nil
 }
+
+// Delete stamps the task and its live descendants with the same deletedAt,
+// which lets Restore tell the subtasks deleted along with the task from those
+// deleted before.
+func (r *postgresTaskRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`WITH RECURSIVE tree AS (
+				SELECT id, 0 AS depth FROM tasks WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.id, tree.depth + 1 FROM tasks c JOIN tree ON c.parent_id = tree.id
+				WHERE c.workspace_id = $2 AND c.deleted_at IS NULL AND tree.depth < $4
+			)
+			UPDATE tasks SET deleted_at = $3 WHERE workspace_id = $2 AND id IN (SELECT id FROM tree)`,
+			id, workspaceID, deletedAt, domain.MaxDepth,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete task %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresTaskRepository) FindDeleted(ctx context.Context) ([]*domain.Task, error) {
+	tasks := []*domain.Task{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		rows, err := q.Query(ctx,
+			selectTasks+` WHERE workspace_id = $1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id`,
+			workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			t, err := scanTask(rows)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list deleted tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *postgresTaskRepository) Restore(ctx context.Context, id string) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		var parentDeleted bool
+		err := q.QueryRow(ctx,
+			`SELECT p.deleted_at IS NOT NULL FROM tasks t LEFT JOIN tasks p ON p.id = t.parent_id
+			 WHERE t.id = $1 AND t.workspace_id = $2 AND t.deleted_at IS NOT NULL`,
+			id, workspaceID,
+		).Scan(&parentDeleted)
+		if err != nil {
+			return err
+		}
+		if parentDeleted {
+			return domain.ErrParentDeleted
+		}
+		_, err = q.Exec(ctx,
+			`WITH RECURSIVE tree AS (
+				SELECT id, deleted_at, 0 AS depth FROM tasks WHERE id = $1 AND workspace_id = $2
+				UNION ALL
+				SELECT c.id, c.deleted_at, tree.depth + 1 FROM tasks c JOIN tree ON c.parent_id = tree.id
+				WHERE c.workspace_id = $2 AND c.deleted_at = tree.deleted_at AND tree.depth < $3
+			)
+			UPDATE tasks SET deleted_at = NULL WHERE workspace_id = $2 AND id IN (SELECT id FROM tree)`,
+			id, workspaceID, domain.MaxDepth,
+		)
+		return err
+	})
+	if err == pgx.ErrNoRows {
+		return domain.ErrTaskNotFound
+	}
+	if err == domain.ErrParentDeleted {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("restore task %q: %w", id, err)
+	}
+	return nil
+}
+
+func (r *postgresTaskRepository) AddAssignee(ctx context.Context, taskID string, userID string) error {
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		_, err := q.Exec(ctx,
+			`INSERT INTO task_assignees (task_id, user_id, workspace_id) VALUES ($1, $2, $3)
+			 ON CONFLICT DO NOTHING`,
+			taskID, userID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("assign task %q to %q: %w", taskID, userID, err)
+	}
+	return nil
+}
+
+func (r *postgresTaskRepository) RemoveAssignee(ctx context.Context, taskID string, userID string) error {
+	var rowsAffected int64
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		result, err := q.Exec(ctx,
+			`DELETE FROM task_assignees WHERE task_id = $1 AND user_id = $2 AND workspace_id = $3`,
+			taskID, userID, workspaceID,
+		)
+		rowsAffected = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unassign %q from task %q: %w", userID, taskID, err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAssigneeNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresTaskRepository) FindDueWithin(ctx context.Context, now time.Time, window time.Duration) ([]*domain.Task, error) {
+	tasks := []*domain.Task{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		rows, err := q.Query(ctx,
+			selectTasks+`
+			 WHERE workspace_id = $1 AND deleted_at IS NULL AND status IN `+openStatuses+` AND due_at >= $2 AND due_at < $3
+			 ORDER BY due_at, id`,
+			workspaceID, now, now.Add(window),
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			t, err := scanTask(rows)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tasks due within %s: %w", window, err)
+	}
+	return tasks, nil
+}
+
+func (r *postgresTaskRepository) FindTree(ctx context.Context, id string) ([]*domain.Task, error) {
+	tasks := []*domain.Task{}
+	err := inWorkspace(ctx, r.db, func(q queryExecutor, workspaceID string) error {
+		// The depth bound keeps the walk finite even if a cycle slipped past
+		// the domain checks.
+		rows, err := q.Query(ctx,
+			`WITH RECURSIVE tree AS (
+				SELECT `+qualifiedTaskColumns("r")+`, 0 AS depth
+				FROM tasks r WHERE r.id = $1 AND r.workspace_id = $2 AND r.deleted_at IS NULL
+				UNION ALL
+				SELECT `+qualifiedTaskColumns("c")+`, tree.depth + 1
+				FROM tasks c JOIN tree ON c.parent_id = tree.id
+				WHERE c.workspace_id = $2 AND c.deleted_at IS NULL AND tree.depth < $3
+			)
+			SELECT `+taskColumns+`, `+progressColumns+`, `+annotationColumns+` FROM tree t ORDER BY depth, created_at, id`,
+			id, workspaceID, domain.MaxDepth,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			t, err := scanTask(rows)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find task tree %q: %w", id, err)
+	}
+	if len(tasks) == 0 {
+		return nil, domain.ErrTaskNotFound
+	}
+	return tasks, nil
+}
+
+func qualifiedTaskColumns(alias string) string {
+	columns := strings.Split(taskColumns, ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// labelCondition keeps tasks carrying any, or all, of the label names bound to
+// parameter n, which must not repeat a name. Both forms are answered from the
+// unique (workspace_id, name) index on labels and the task_labels keys.
+func labelCondition(match domain.LabelMatch, n int) string {
+	param := "$" + strconv.Itoa(n)
+	if match == domain.LabelMatchAll {
+		return `id IN (SELECT tl.task_id FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE l.workspace_id = $1 AND l.name = ANY(` + param + `)
+			GROUP BY tl.task_id HAVING count(*) = cardinality(` + param + `))`
+	}
+	return `EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = t.id AND l.workspace_id = $1 AND l.name = ANY(` + param + `))`
+}
+
+func orderBy(sort domain.SortOrder) string {
+	if sort == domain.SortByDueAt {
+		return "due_at ASC NULLS LAST, created_at, id"
+	}
+	return "created_at, id"
+}
+
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	t := &domain.Task{}
+	var rule *string
+	if err := row.Scan(
+		&t.ID, &t.WorkspaceID, &t.ProjectID, &t.ParentID, &t.Title, &t.Status, &t.Priority, &t.DueAt,
+		&rule, &t.SeriesID, &t.Occurrence, &t.CreatedAt, &t.UpdatedAt, &t.DeletedAt,
+		&t.Subtasks.Total, &t.Subtasks.Completed, &t.Assignees, &t.Labels, &t.CommentCount,
+	); err != nil {
+		return nil, err
+	}
+	if rule != nil {
+		recurrence, err := domain.ParseRecurrence(*rule)
+		if err != nil {
+			return nil, fmt.Errorf("task %q: %w", t.ID, err)
+		}
+		t.Recurrence = recurrence
+	}
+	return t, nil
+}
+
+// recurrenceRule stores recurrences in their RRULE form, NULL for one-off
+// tasks.
+func recurrenceRule(r *domain.Recurrence) *string {
+	if r == nil {
+		return nil
+	}
+	rule := r.String()
+	return &rule
+}
@@ -2,14 +2,16 @@ package repository
 
 import (
 	"context"
-	"errors"
-	"strconv"
 	"testing"
 	"time"
 
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
 	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/db"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -19,88 +21,250 @@ func TestPostgresTaskRepository(t *testing.T) {
 	RunSpecs(t, "Postgres Task Repository Suite")
 }
 
+const (
+	workspaceA = "11111111-1111-1111-1111-111111111111"
+	workspaceB = "22222222-2222-2222-2222-222222222222"
+)
+
+// These specs cover what the contract specs in
+// task_repository_contract_test.go cannot observe through domain.Repository:
+// label filters, which need the label repository, row level security,
+// deadlines and replica routing. Like the contract, they run against the
+// database named by TEST_POSTGRES_DSN.
 var _ = Describe("postgresTaskRepository", func() {
-	Describe("Update", func() {
-		var (
-			ctx       context.Context
-			repo      *postgresTaskRepository
-			testTask  *domain.Task
-			execState *stubExecState
-		)
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var (
+		ctx         context.Context
+		workspaceID string
+		pools       *db.Pools
+		repo        *postgresTaskRepository
+	)
+
+	BeforeEach(func() {
+		pools = postgresPools("")
+		workspaceID = uuid.NewString()
+		ctx = workspace.WithID(context.Background(), workspaceID)
+		repo = &postgresTaskRepository{db: pools.Primary}
+	})
+
+	create := func(ctx context.Context) *domain.Task {
+		t, err := domain.New(uuid.NewString(), "Write report", now, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(repo.Create(ctx, t)).To(Succeed())
+		return t
+	}
+
+	ids := func(tasks []*domain.Task) []string {
+		ids := make([]string, len(tasks))
+		for i, t := range tasks {
+			ids[i] = t.ID
+		}
+		return ids
+	}
+
+	// within runs fn inside a transaction of the repository and hands it the
+	// transaction, for statements the repository does not make itself.
+	within := func(ctx context.Context, fn func(ctx context.Context, tx queryExecutor)) {
+		Expect((&postgresTransactor{db: repo.db}).Within(ctx, func(ctx context.Context) error {
+			fn(ctx, ctx.Value(txKey{}).(queryExecutor))
+			return nil
+		})).To(Succeed())
+	}
+
+	Describe("FindAll", func() {
+		var both, bugOnly *domain.Task
 
 		BeforeEach(func() {
-			ctx = context.Background()
-			execState = &stubExecState{}
-			repo = &postgresTaskRepository{db: &stubQueryExecutor{execState: execState}}
-			testTask = &domain.Task{
-				ID:        "task-1",
-				Title:     "Test Task",
-				Status:    domain.StatusComplete,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			}
+			labels := NewLabelRepository(pools.Primary)
+			backend, err := label.New(uuid.NewString(), "backend", "", now, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labels.Create(ctx, backend)).To(Succeed())
+			bug, err := label.New(uuid.NewString(), "bug", "", now, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labels.Create(ctx, bug)).To(Succeed())
+
+			both = create(ctx)
+			Expect(labels.Attach(ctx, both.ID, backend.ID)).To(Succeed())
+			Expect(labels.Attach(ctx, both.ID, bug.ID)).To(Succeed())
+			bugOnly = create(ctx)
+			Expect(labels.Attach(ctx, bugOnly.ID, bug.ID)).To(Succeed())
+			create(ctx)
 		})
 
-		Context("when the task exists", func() {
-			It("returns nil", func() {
-				execState.rowsAffected = 1
+		It("keeps tasks carrying any of the labels", func() {
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{Labels: []string{"backend", "bug"}})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(ConsistOf(both.ID, bugOnly.ID))
+		})
 
-				err := repo.Update(ctx, testTask)
+		It("keeps tasks carrying all of the labels", func() {
+			tasks, err := repo.FindAll(ctx, domain.ListFilter{Labels: []string{"backend", "bug"}, LabelMatch: domain.LabelMatchAll})
 
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(tasks)).To(ConsistOf(both.ID))
+		})
+	})
+
+	Describe("workspace scoping", func() {
+		It("publishes the workspace to Postgres", func() {
+			within(ctx, func(ctx context.Context, tx queryExecutor) {
+				_, err := repo.FindAll(ctx, domain.ListFilter{})
 				Expect(err).NotTo(HaveOccurred())
+
+				var published string
+				Expect(tx.QueryRow(ctx, `SELECT current_setting('app.workspace_id', true)`).Scan(&published)).To(Succeed())
+				Expect(published).To(Equal(workspaceID))
 			})
 		})
 
-		Context("when the task does not exist", func() {
-			It("returns task not found", func() {
-				execState.rowsAffected = 0
-
-				err := repo.Update(ctx, testTask)
+		It("lets row level security hide the rows of other workspaces", func() {
+			var bypasses bool
+			Expect(pools.Primary.QueryRow(context.Background(),
+				`SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`,
+			).Scan(&bypasses)).To(Succeed())
+			if bypasses {
+				Skip("the role of " + postgresDSNEnv + " bypasses row level security")
+			}
+			other := create(workspace.WithID(context.Background(), uuid.NewString()))
 
-				Expect(err).To(Equal(domain.ErrTaskNotFound))
+			within(ctx, func(ctx context.Context, tx queryExecutor) {
+				var count int
+				Expect(tx.QueryRow(ctx, `SELECT count(*) FROM tasks WHERE id = $1`, other.ID).Scan(&count)).To(Succeed())
+				Expect(count).To(BeZero())
 			})
 		})
+	})
 
-		Context("when ExecContext fails", func() {
-			It("returns the execution error", func() {
-				expectedErr := errors.New("exec failed")
-				execState.execErr = expectedErr
+	Describe("deadlines", func() {
+		It("gives up on statements once the deadline of ctx passes", func() {
+			t := create(ctx)
+			// Another transaction holding the row makes the update wait.
+			locker, err := pools.Primary.Begin(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = locker.Rollback(context.Background()) }()
+			_, err = locker.Exec(context.Background(), `SELECT set_config('app.workspace_id', $1, true)`, workspaceID)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = locker.Exec(context.Background(), `SELECT 1 FROM tasks WHERE id = $1 FOR UPDATE`, t.ID)
+			Expect(err).NotTo(HaveOccurred())
 
-				err := repo.Update(ctx, testTask)
+			deadline, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			err = repo.Update(deadline, t)
+
+			Expect(err).To(HaveOccurred())
+			Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+		})
 
-				Expect(err).To(MatchError(MatchRegexp("save task")))
-				Expect(err).To(MatchError(expectedErr))
+		It("limits statements to the time left", func() {
+			deadline, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+
+			within(deadline, func(ctx context.Context, tx queryExecutor) {
+				var setting string
+				Expect(tx.QueryRow(ctx, `SELECT current_setting('statement_timeout')`).Scan(&setting)).To(Succeed())
+				timeout, err := time.ParseDuration(setting)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(timeout).To(BeNumerically("~", time.Second, 100*time.Millisecond))
+			})
+		})
+
+		It("leaves statement_timeout alone without a deadline", func() {
+			var session string
+			Expect(pools.Primary.QueryRow(context.Background(), `SELECT current_setting('statement_timeout')`).Scan(&session)).To(Succeed())
+
+			within(ctx, func(ctx context.Context, tx queryExecutor) {
+				var setting string
+				Expect(tx.QueryRow(ctx, `SELECT current_setting('statement_timeout')`).Scan(&setting)).To(Succeed())
+				Expect(setting).To(Equal(session))
 			})
 		})
 	})
-})
 
-type stubExecState struct {
-	rowsAffected int64
-	execErr      error
-}
+	Describe("read replica", func() {
+		var (
+			replica *pgxpool.Pool
+			healthy bool
+		)
 
-type stubQueryExecutor struct {
-	execState *stubExecState
-}
+		// acquired counts the connections taken from pool so far.
+		acquired := func(pool *pgxpool.Pool) int64 {
+			return pool.Stat().AcquireCount()
+		}
 
-func (s *stubQueryExecutor) Exec(_ context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
-	if s.execState.execErr != nil {
-		return pgconn.CommandTag{}, s.execState.execErr
-	}
-	return pgconn.NewCommandTag("UPDATE " + strconv.FormatInt(s.execState.rowsAffected, 10)), nil
-}
+		BeforeEach(func() {
+			// The replica is a second pool on the same database, so which
+			// pool served a read shows in their statistics.
+			replica = postgresPools("").Primary
+			healthy = true
+			repo.replica = &readReplica{db: replica, healthy: func() bool { return healthy }}
+		})
 
-func (s *stubQueryExecutor) Query(context.Context, string, ...any) (pgx.Rows, error) {
-	return nil, errors.New("not implemented")
-}
+		It("reads from the replica", func() {
+			t := create(ctx)
+			primaryBefore, replicaBefore := acquired(pools.Primary), acquired(replica)
 
-func (s *stubQueryExecutor) QueryRow(context.Context, string, ...any) pgx.Row {
-	return stubRow{}
-}
+			_, err := repo.FindByID(ctx, t.ID)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.FindAll(ctx, domain.ListFilter{})
+			Expect(err).NotTo(HaveOccurred())
 
-type stubRow struct{}
+			Expect(acquired(replica) - replicaBefore).To(BeEquivalentTo(2))
+			Expect(acquired(pools.Primary)).To(Equal(primaryBefore))
+		})
 
-func (stubRow) Scan(...any) error {
-	return errors.New("not implemented")
-}
+		It("writes to the primary", func() {
+			t := create(ctx)
+			replicaBefore := acquired(replica)
+
+			Expect(t.Rename("Write summary", now.Add(time.Hour))).To(Succeed())
+			Expect(repo.Update(ctx, t)).To(Succeed())
+
+			Expect(acquired(replica)).To(Equal(replicaBefore))
+		})
+
+		It("reads from the primary while the replica is unhealthy", func() {
+			t := create(ctx)
+			healthy = false
+			replicaBefore := acquired(replica)
+
+			_, err := repo.FindByID(ctx, t.ID)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired(replica)).To(Equal(replicaBefore))
+		})
+
+		It("reads from the primary when the replica cannot begin", func() {
+			t := create(ctx)
+			replica.Close()
+
+			found, err := repo.FindByID(ctx, t.ID)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.ID).To(Equal(t.ID))
+		})
+
+		It("reads from the primary when asked to read its own writes", func() {
+			replicaBefore := acquired(replica)
+
+			_, err := repo.FindAll(transaction.WithReadYourWrites(ctx), domain.ListFilter{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acquired(replica)).To(Equal(replicaBefore))
+		})
+
+		It("reads inside a transaction from the transaction", func() {
+			t := create(ctx)
+			replicaBefore := acquired(replica)
+
+			within(ctx, func(ctx context.Context, _ queryExecutor) {
+				_, err := repo.FindByID(ctx, t.ID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Expect(acquired(replica)).To(Equal(replicaBefore))
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/trash"
+)
+
+type postgresTrashRepository struct {
+	db queryExecutor
+}
+
+func NewTrashRepository(db DB) trash.Store {
+	return &postgresTrashRepository{db: db}
+}
+
+// Purge goes through purge_deleted_tasks because row level security hides
+// the tasks of other workspaces from the application role.
+func (r *postgresTrashRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := retrying(ctx, r.db, false, func(q queryExecutor) error {
+		return q.QueryRow(ctx, `SELECT purge_deleted_tasks($1)`, deletedBefore).Scan(&purged)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("purge tasks deleted before %s: %w", deletedBefore.Format(time.RFC3339), err)
+	}
+	return purged, nil
+}
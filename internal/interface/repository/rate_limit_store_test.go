@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/db"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rate limit stores", func() {
+	describeStore := func(newStore func() ratelimit.Store) {
+		var (
+			ctx   context.Context
+			store ratelimit.Store
+			key   string
+		)
+
+		// The period is long enough for the buckets not to refill while the
+		// specs run.
+		limit := ratelimit.Limit{Burst: 3, Period: time.Hour}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			store = newStore()
+			key = "user:" + uuid.NewString()
+		})
+
+		It("takes tokens until the bucket is empty", func() {
+			for remaining := 2; remaining >= 0; remaining-- {
+				decision, err := store.Take(ctx, key, limit)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decision.Allowed).To(BeTrue())
+				Expect(decision.Remaining).To(Equal(remaining))
+			}
+
+			decision, err := store.Take(ctx, key, limit)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decision.Allowed).To(BeFalse())
+			Expect(decision.RetryAfter).To(BeNumerically("~", 20*time.Minute, time.Second))
+		})
+
+		It("keeps a bucket per key", func() {
+			for range 3 {
+				_, err := store.Take(ctx, key, limit)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			decision, err := store.Take(ctx, key+"-other", limit)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decision.Allowed).To(BeTrue())
+		})
+
+		It("never allows more than the burst to concurrent requests", func() {
+			var (
+				wg      sync.WaitGroup
+				mu      sync.Mutex
+				allowed int
+			)
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					decision, err := store.Take(ctx, key, limit)
+					Expect(err).NotTo(HaveOccurred())
+					if decision.Allowed {
+						mu.Lock()
+						allowed++
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			Expect(allowed).To(Equal(3))
+		})
+	}
+
+	Describe("MemoryRateLimitStore", func() {
+		describeStore(func() ratelimit.Store {
+			return NewMemoryRateLimitStore(100, time.Hour)
+		})
+
+		It("stays within its capacity", func() {
+			store := NewMemoryRateLimitStore(2, time.Hour)
+			for _, key := range []string{"a", "b", "c"} {
+				_, err := store.Take(context.Background(), key, ratelimit.Limit{Burst: 1, Period: time.Hour})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(store.buckets.len()).To(Equal(2))
+		})
+	})
+
+	Describe("PostgresRateLimitStore", func() {
+		describeStore(func() ratelimit.Store {
+			dsn := os.Getenv(postgresDSNEnv)
+			if dsn == "" {
+				Skip(postgresDSNEnv + " is not set")
+			}
+			pools, err := db.New(dsn, "", db.Options{})
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(pools.Close)
+			return NewPostgresRateLimitStore(pools.Primary)
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+)
+
+// readReplica is a replica that repositories may send reads to while healthy
+// reports it up.
+type readReplica struct {
+	db      queryExecutor
+	healthy func() bool
+}
+
+// reader returns where a read should go: the replica, unless there is none,
+// it is unhealthy or ctx asks to read its own writes. Reads inside a
+// transaction use the transaction whatever reader returns; see inWorkspace.
+// Reads from the replica keep the policy of a primary from NewResilientDB.
+func (r *readReplica) reader(ctx context.Context, primary queryExecutor) queryExecutor {
+	if r == nil || transaction.ReadYourWrites(ctx) || !r.healthy() {
+		return primary
+	}
+	if resilient, ok := primary.(*resilientDB); ok {
+		return &resilientDB{
+			queryExecutor: &replicaExecutor{queryExecutor: r.db, primary: resilient.queryExecutor},
+			policy:        resilient.policy,
+		}
+	}
+	return &replicaExecutor{queryExecutor: r.db, primary: primary}
+}
+
+// replicaExecutor begins transactions on the replica, and on the primary when
+// the replica fails to, so a replica going down between health checks does
+// not fail reads.
+type replicaExecutor struct {
+	queryExecutor
+	primary queryExecutor
+}
+
+func (e *replicaExecutor) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := e.queryExecutor.Begin(ctx)
+	if err != nil && ctx.Err() == nil {
+		log.Printf("repository: begin on replica, reading from the primary: %v", err)
+		return e.primary.Begin(ctx)
+	}
+	return tx, err
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+)
+
+const (
+	// retryBackoffMin and retryBackoffMax bound the wait before a retry; each
+	// wait is drawn at random below a bound that doubles per attempt.
+	retryBackoffMin = 50 * time.Millisecond
+	retryBackoffMax = time.Second
+	// transientRetryAfter is suggested to callers once retries are exhausted.
+	transientRetryAfter = time.Second
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// Resilience retries operations that failed for transient reasons and stops
+// calling the database once it keeps failing to answer.
+//
+// Serialization failures and deadlocks roll the whole transaction back, so
+// any operation can run again. Lost connections are only retried for
+// idempotent operations, or when pgconn reports that nothing reached the
+// server, since a write may have committed before its answer got lost.
+// Retries stop early when the next one would not fit before the deadline of
+// ctx.
+//
+// After threshold operations in a row failed to reach the database, the
+// breaker opens and operations fail with a transaction.UnavailableError,
+// without calling it, for cooldown. Then a single operation is let through:
+// if it reaches the database the breaker closes, otherwise it opens again.
+type Resilience struct {
+	attempts  int
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	sleep     func(ctx context.Context, d time.Duration) error
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func NewResilience(attempts int, threshold int, cooldown time.Duration) *Resilience {
+	return &Resilience{
+		attempts:  max(attempts, 1),
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		now:       time.Now,
+		sleep:     sleepContext,
+	}
+}
+
+func (r *Resilience) do(ctx context.Context, idempotent bool, fn func() error) error {
+	backoff := retryBackoffMin
+	for attempt := 1; ; attempt++ {
+		if err := r.allow(); err != nil {
+			return err
+		}
+		err := fn()
+		r.record(err)
+		if err == nil || ctx.Err() != nil || !isTransient(err, idempotent) {
+			return err
+		}
+		if attempt >= r.attempts {
+			return &transaction.UnavailableError{RetryAfter: transientRetryAfter, Err: err}
+		}
+
+		wait := rand.N(backoff) + 1
+		backoff = min(2*backoff, retryBackoffMax)
+		if deadline, ok := ctx.Deadline(); ok && r.now().Add(wait).After(deadline) {
+			return &transaction.UnavailableError{RetryAfter: transientRetryAfter, Err: err}
+		}
+		if sleepErr := r.sleep(ctx, wait); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// allow returns an error while the breaker keeps calls away, and lets one
+// probe through once cooldown is over.
+func (r *Resilience) allow() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures < r.threshold {
+		return nil
+	}
+	now := r.now()
+	if now.Before(r.openUntil) {
+		return &transaction.UnavailableError{RetryAfter: r.openUntil.Sub(now), Err: errCircuitOpen}
+	}
+	if r.probing {
+		return &transaction.UnavailableError{RetryAfter: transientRetryAfter, Err: errCircuitOpen}
+	}
+	r.probing = true
+	return nil
+}
+
+func (r *Resilience) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !isConnectionError(err) {
+		if r.failures >= r.threshold {
+			log.Printf("repository: database answers again, closing the circuit breaker")
+		}
+		r.failures = 0
+		r.probing = false
+		return
+	}
+	r.failures++
+	if r.failures >= r.threshold {
+		if !r.probing {
+			log.Printf("repository: %d database calls in a row failed, opening the circuit breaker for %s: %v",
+				r.failures, r.cooldown, err)
+		}
+		r.openUntil = r.now().Add(r.cooldown)
+		r.probing = false
+	}
+}
+
+// isTransient reports whether running the failed operation again may
+// succeed. A lost connection during COMMIT is treated as a lost write, since
+// the transaction may have committed.
+func isTransient(err error, idempotent bool) bool {
+	var commitErr *commitError
+	if errors.As(err, &commitErr) {
+		idempotent = false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", "40P01": // serialization_failure, deadlock_detected
+			return true
+		}
+	}
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+	return idempotent && isConnectionError(err)
+}
+
+// isConnectionError reports whether err means the database could not be
+// reached or the connection broke, as opposed to the database answering with
+// an error.
+func isConnectionError(err error) bool {
+	// Cancelled and timed out calls say nothing about the database.
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if len(pgErr.Code) < 2 {
+			return false
+		}
+		switch pgErr.Code[:2] {
+		case "08": // connection_exception
+			return true
+		case "57": // operator_intervention: admin_shutdown, crash_shutdown, cannot_connect_now
+			return pgErr.Code != "57014" // query_canceled
+		}
+		return false
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// resilientDB is a DB whose transactions run under policy; see inWorkspace.
+type resilientDB struct {
+	queryExecutor
+	policy *Resilience
+}
+
+// NewResilientDB wraps db so that the transactions the repositories open on
+// it are retried and kept away from a database that stopped answering, under
+// policy. Every repository given the result shares the same circuit breaker.
+func NewResilientDB(db DB, policy *Resilience) DB {
+	return &resilientDB{queryExecutor: db, policy: policy}
+}
+
+// retrying runs fn, which makes statements on db outside inWorkspace, under
+// the policy of db if it has one.
+func retrying(ctx context.Context, db queryExecutor, idempotent bool, fn func(q queryExecutor) error) error {
+	if r, ok := db.(*resilientDB); ok {
+		return r.policy.do(ctx, idempotent, func() error { return fn(r.queryExecutor) })
+	}
+	return fn(db)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
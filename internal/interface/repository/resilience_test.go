@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resilience", func() {
+	var (
+		ctx    context.Context
+		policy *Resilience
+		clock  time.Time
+		slept  []time.Duration
+	)
+
+	serializationFailure := fmt.Errorf("save task: %w", &pgconn.PgError{Code: "40001"})
+	connectionReset := fmt.Errorf("find task: %w", io.ErrUnexpectedEOF)
+
+	// failing returns an operation that fails with errs in turn, then
+	// succeeds, counting its calls.
+	failing := func(calls *int, errs ...error) func() error {
+		return func() error {
+			*calls++
+			if *calls <= len(errs) {
+				return errs[*calls-1]
+			}
+			return nil
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clock = time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+		slept = nil
+		policy = NewResilience(3, 2, 10*time.Second)
+		policy.now = func() time.Time { return clock }
+		policy.sleep = func(_ context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			clock = clock.Add(d)
+			return nil
+		}
+	})
+
+	It("retries serialization failures and deadlocks", func() {
+		calls := 0
+		err := policy.do(ctx, false, failing(&calls, serializationFailure, &pgconn.PgError{Code: "40P01"}))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal(3))
+		Expect(slept).To(HaveLen(2))
+		Expect(slept[0]).To(BeNumerically("<=", retryBackoffMin))
+		Expect(slept[1]).To(BeNumerically("<=", 2*retryBackoffMin))
+	})
+
+	It("gives up after the last attempt with an unavailable error", func() {
+		calls := 0
+		err := policy.do(ctx, false, failing(&calls, serializationFailure, serializationFailure, serializationFailure))
+
+		var unavailable *transaction.UnavailableError
+		Expect(errors.As(err, &unavailable)).To(BeTrue())
+		Expect(unavailable.RetryAfter).To(Equal(transientRetryAfter))
+		Expect(calls).To(Equal(3))
+	})
+
+	It("retries lost connections of idempotent operations only", func() {
+		calls := 0
+		Expect(policy.do(ctx, true, failing(&calls, connectionReset))).To(Succeed())
+		Expect(calls).To(Equal(2))
+
+		calls = 0
+		Expect(policy.do(ctx, false, failing(&calls, connectionReset))).To(MatchError(connectionReset))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("does not retry other errors", func() {
+		for _, err := range []error{&pgconn.PgError{Code: "23505"}, workspace.ErrWorkspaceRequired} {
+			calls := 0
+			Expect(policy.do(ctx, true, failing(&calls, err))).To(MatchError(err))
+			Expect(calls).To(Equal(1))
+		}
+	})
+
+	It("does not retry past the deadline of ctx", func() {
+		ctx, cancel := context.WithDeadline(ctx, clock.Add(time.Millisecond))
+		DeferCleanup(cancel)
+		calls := 0
+		policy.attempts = 10
+		policy.sleep = func(context.Context, time.Duration) error { return nil }
+		policy.now = func() time.Time { return clock.Add(time.Millisecond) }
+
+		err := policy.do(ctx, false, failing(&calls, serializationFailure, serializationFailure))
+
+		Expect(err).To(MatchError(serializationFailure))
+		Expect(calls).To(Equal(1))
+	})
+
+	Describe("circuit breaker", func() {
+		down := func() error { return connectionReset }
+
+		It("opens after failures in a row and fails fast", func() {
+			policy.attempts = 1
+			_ = policy.do(ctx, true, down)
+			_ = policy.do(ctx, true, down)
+
+			calls := 0
+			err := policy.do(ctx, true, failing(&calls))
+
+			var unavailable *transaction.UnavailableError
+			Expect(errors.As(err, &unavailable)).To(BeTrue())
+			Expect(unavailable.Err).To(MatchError(errCircuitOpen))
+			Expect(unavailable.RetryAfter).To(Equal(10 * time.Second))
+			Expect(calls).To(BeZero())
+		})
+
+		It("does not count errors the database answered with", func() {
+			policy.attempts = 1
+			_ = policy.do(ctx, true, down)
+			_ = policy.do(ctx, true, func() error { return &pgconn.PgError{Code: "23505"} })
+			_ = policy.do(ctx, true, down)
+
+			calls := 0
+			Expect(policy.do(ctx, true, failing(&calls))).To(Succeed())
+			Expect(calls).To(Equal(1))
+		})
+
+		It("lets a single probe through after the cooldown", func() {
+			policy.attempts = 1
+			_ = policy.do(ctx, true, down)
+			_ = policy.do(ctx, true, down)
+			clock = clock.Add(10 * time.Second)
+
+			probed := false
+			err := policy.do(ctx, true, func() error {
+				probed = true
+				Expect(policy.do(ctx, true, func() error { return nil })).To(MatchError(errCircuitOpen))
+				return nil
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(probed).To(BeTrue())
+			Expect(policy.do(ctx, true, func() error { return nil })).To(Succeed())
+		})
+
+		It("opens again when the probe fails", func() {
+			policy.attempts = 1
+			_ = policy.do(ctx, true, down)
+			_ = policy.do(ctx, true, down)
+			clock = clock.Add(10 * time.Second)
+
+			Expect(policy.do(ctx, true, down)).To(MatchError(connectionReset))
+
+			Expect(policy.do(ctx, true, func() error { return nil })).To(MatchError(errCircuitOpen))
+		})
+	})
+})
+
+var _ = Describe("resilientDB", func() {
+	var (
+		execState *stubExecState
+		db        DB
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		execState = &stubExecState{rowsAffected: 1}
+		policy := NewResilience(3, 5, time.Second)
+		policy.sleep = func(context.Context, time.Duration) error { return nil }
+		db = &resilientDB{queryExecutor: &stubQueryExecutor{execState: execState}, policy: policy}
+		ctx = workspace.WithID(context.Background(), workspaceA)
+	})
+
+	It("retries the transaction as a whole rather than the calls inside it", func() {
+		execState.execErr = &pgconn.PgError{Code: "40001"}
+		repo := &postgresTaskRepository{db: db}
+		attempts := 0
+
+		err := NewTransactor(db).Within(ctx, func(ctx context.Context) error {
+			attempts++
+			return repo.Delete(ctx, "task-1", time.Now())
+		})
+
+		var unavailable *transaction.UnavailableError
+		Expect(errors.As(err, &unavailable)).To(BeTrue())
+		Expect(attempts).To(Equal(3))
+		Expect(execState.begun).To(Equal(3))
+	})
+
+	It("retries the other adapters sharing it", func() {
+		execState.execErr = &pgconn.PgError{Code: "40P01"}
+
+		err := NewProjectRepository(db).Delete(ctx, "project-1")
+
+		var unavailable *transaction.UnavailableError
+		Expect(errors.As(err, &unavailable)).To(BeTrue())
+		Expect(execState.begun).To(Equal(3))
+	})
+
+	It("does not retry a transaction whose COMMIT failed on a broken connection", func() {
+		execState.commitErr = io.ErrUnexpectedEOF
+
+		err := NewProjectRepository(db).Delete(ctx, "project-1")
+
+		Expect(err).To(MatchError(io.ErrUnexpectedEOF))
+		Expect(execState.begun).To(Equal(1))
+	})
+})
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+)
+
+type sqliteAuditRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteAuditRepository(db *sql.DB) audit.Repository {
+	return &sqliteAuditRepository{db: db}
+}
+
+func (r *sqliteAuditRepository) Append(ctx context.Context, record *audit.Record) error {
+	changes := make(map[string]change, len(record.Changes))
+	for field, c := range record.Changes {
+		changes[field] = change{Before: c.Before, After: c.After}
+	}
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("encode audit record %q: %w", record.ID, err)
+	}
+
+	err = withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO audit_log (`+auditColumns+`) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)`,
+			record.ID, workspaceID, record.TaskID, record.Actor, record.Action, string(encoded), record.RequestID,
+			sqliteTime(record.CreatedAt),
+		)
+		if err != nil {
+			return err
+		}
+		record.WorkspaceID = workspaceID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save audit record %q: %w", record.ID, err)
+	}
+	return nil
+}
+
+func (r *sqliteAuditRepository) FindByTask(ctx context.Context, taskID string) ([]*audit.Record, error) {
+	var records []*audit.Record
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		var err error
+		records, err = querySQLiteRecords(ctx, q,
+			`SELECT `+auditColumns+` FROM audit_log WHERE task_id = ?1 AND workspace_id = ?2 ORDER BY created_at, id`,
+			taskID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list audit records of task %q: %w", taskID, err)
+	}
+	return records, nil
+}
+
+func (r *sqliteAuditRepository) Find(ctx context.Context, filter audit.Filter) ([]*audit.Record, error) {
+	var records []*audit.Record
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		conditions := []string{"workspace_id = ?1"}
+		args := []any{workspaceID}
+		if filter.Actor != "" {
+			args = append(args, filter.Actor)
+			conditions = append(conditions, "actor = ?"+strconv.Itoa(len(args)))
+		}
+		if !filter.Since.IsZero() {
+			args = append(args, sqliteTime(filter.Since))
+			conditions = append(conditions, "created_at >= ?"+strconv.Itoa(len(args)))
+		}
+		args = append(args, filter.Limit)
+
+		var err error
+		records, err = querySQLiteRecords(ctx, q,
+			`SELECT `+auditColumns+` FROM audit_log WHERE `+strings.Join(conditions, " AND ")+
+				` ORDER BY created_at, id LIMIT ?`+strconv.Itoa(len(args)),
+			args...,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list audit records: %w", err)
+	}
+	return records, nil
+}
+
+func querySQLiteRecords(ctx context.Context, q sqliteExecutor, query string, args ...any) ([]*audit.Record, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := []*audit.Record{}
+	for rows.Next() {
+		record := &audit.Record{}
+		var encoded string
+		if err := rows.Scan(&record.ID, &record.WorkspaceID, &record.TaskID, &record.Actor, &record.Action,
+			&encoded, &record.RequestID, sqliteTimestamp{&record.CreatedAt}); err != nil {
+			return nil, err
+		}
+		var changes map[string]change
+		if err := json.Unmarshal([]byte(encoded), &changes); err != nil {
+			return nil, fmt.Errorf("decode changes of audit record %q: %w", record.ID, err)
+		}
+		record.Changes = make(map[string]audit.Change, len(changes))
+		for field, c := range changes {
+			record.Changes[field] = audit.Change{Before: c.Before, After: c.After}
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
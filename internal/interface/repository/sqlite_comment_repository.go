@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+)
+
+type sqliteCommentRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteCommentRepository(db *sql.DB) comment.Repository {
+	return &sqliteCommentRepository{db: db}
+}
+
+func (r *sqliteCommentRepository) FindByTask(ctx context.Context, taskID string, page comment.Page) ([]*comment.Comment, error) {
+	comments := []*comment.Comment{}
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		query := `SELECT ` + commentColumns + ` FROM comments WHERE task_id = ?1 AND workspace_id = ?2`
+		args := []any{taskID, workspaceID}
+		if page.After != nil {
+			query += ` AND (created_at, id) > (?3, ?4)`
+			args = append(args, sqliteTime(page.After.CreatedAt), page.After.ID)
+		}
+		args = append(args, page.Limit)
+		query += fmt.Sprintf(` ORDER BY created_at, id LIMIT ?%d`, len(args))
+
+		rows, err := q.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			c, err := scanSQLiteComment(rows)
+			if err != nil {
+				return err
+			}
+			comments = append(comments, c)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list comments of task %q: %w", taskID, err)
+	}
+	return comments, nil
+}
+
+func (r *sqliteCommentRepository) FindByID(ctx context.Context, id string) (*comment.Comment, error) {
+	var c *comment.Comment
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		row := q.QueryRowContext(ctx,
+			`SELECT `+commentColumns+` FROM comments WHERE id = ?1 AND workspace_id = ?2`,
+			id, workspaceID,
+		)
+		var err error
+		c, err = scanSQLiteComment(row)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return nil, comment.ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find comment by id %q: %w", id, err)
+	}
+	return c, nil
+}
+
+func (r *sqliteCommentRepository) Create(ctx context.Context, c *comment.Comment) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO comments (`+commentColumns+`) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)`,
+			c.ID, workspaceID, c.TaskID, c.AuthorID, c.Body, sqliteTime(c.CreatedAt), sqliteNullTime(c.EditedAt),
+		)
+		if err != nil {
+			return err
+		}
+		c.WorkspaceID = workspaceID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save comment %q: %w", c.ID, err)
+	}
+	return nil
+}
+
+func (r *sqliteCommentRepository) Update(ctx context.Context, c *comment.Comment) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`UPDATE comments SET body = ?1, edited_at = ?2 WHERE id = ?3 AND workspace_id = ?4`,
+			c.Body, sqliteNullTime(c.EditedAt), c.ID, workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save comment %q: %w", c.ID, err)
+	}
+
+	if rowsAffected == 0 {
+		return comment.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteCommentRepository) Delete(ctx context.Context, id string) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx, `DELETE FROM comments WHERE id = ?1 AND workspace_id = ?2`, id, workspaceID)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete comment %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return comment.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func scanSQLiteComment(row sqliteRow) (*comment.Comment, error) {
+	c := &comment.Comment{}
+	if err := row.Scan(&c.ID, &c.WorkspaceID, &c.TaskID, &c.AuthorID, &c.Body,
+		sqliteTimestamp{&c.CreatedAt}, sqliteNullTimestamp{&c.EditedAt}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
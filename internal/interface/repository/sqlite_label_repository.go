@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+)
+
+type sqliteLabelRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteLabelRepository(db *sql.DB) label.Repository {
+	return &sqliteLabelRepository{db: db}
+}
+
+func (r *sqliteLabelRepository) FindAll(ctx context.Context) ([]*label.Label, error) {
+	labels := []*label.Label{}
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		rows, err := q.QueryContext(ctx,
+			`SELECT id, workspace_id, name, color, created_at, updated_at
+			 FROM labels WHERE workspace_id = ?1 ORDER BY name`,
+			workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			l, err := scanSQLiteLabel(rows)
+			if err != nil {
+				return err
+			}
+			labels = append(labels, l)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list labels: %w", err)
+	}
+	return labels, nil
+}
+
+func (r *sqliteLabelRepository) FindByID(ctx context.Context, id string) (*label.Label, error) {
+	var l *label.Label
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		row := q.QueryRowContext(ctx,
+			`SELECT id, workspace_id, name, color, created_at, updated_at
+			 FROM labels WHERE id = ?1 AND workspace_id = ?2`,
+			id, workspaceID,
+		)
+		var err error
+		l, err = scanSQLiteLabel(row)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return nil, label.ErrLabelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find label by id %q: %w", id, err)
+	}
+	return l, nil
+}
+
+func (r *sqliteLabelRepository) Create(ctx context.Context, l *label.Label) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO labels (id, workspace_id, name, color, created_at, updated_at)
+			 VALUES (?1, ?2, ?3, ?4, ?5, ?6)`,
+			l.ID, workspaceID, l.Name, l.Color, sqliteTime(l.CreatedAt), sqliteTime(l.UpdatedAt),
+		)
+		if err != nil {
+			return err
+		}
+		l.WorkspaceID = workspaceID
+		return nil
+	})
+	if isSQLiteUniqueViolation(err) {
+		return label.ErrLabelExists
+	}
+	if err != nil {
+		return fmt.Errorf("save label %q: %w", l.ID, err)
+	}
+	return nil
+}
+
+func (r *sqliteLabelRepository) Update(ctx context.Context, l *label.Label) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`UPDATE labels SET name = ?1, color = ?2, updated_at = ?3 WHERE id = ?4 AND workspace_id = ?5`,
+			l.Name, l.Color, sqliteTime(l.UpdatedAt), l.ID, workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if isSQLiteUniqueViolation(err) {
+		return label.ErrLabelExists
+	}
+	if err != nil {
+		return fmt.Errorf("save label %q: %w", l.ID, err)
+	}
+
+	if rowsAffected == 0 {
+		return label.ErrLabelNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteLabelRepository) Delete(ctx context.Context, id string) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx, `DELETE FROM labels WHERE id = ?1 AND workspace_id = ?2`, id, workspaceID)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete label %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return label.ErrLabelNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteLabelRepository) Attach(ctx context.Context, taskID string, labelID string) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO task_labels (task_id, label_id, workspace_id) VALUES (?1, ?2, ?3)
+			 ON CONFLICT DO NOTHING`,
+			taskID, labelID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("attach label %q to task %q: %w", labelID, taskID, err)
+	}
+	return nil
+}
+
+func (r *sqliteLabelRepository) Detach(ctx context.Context, taskID string, labelID string) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`DELETE FROM task_labels WHERE task_id = ?1 AND label_id = ?2 AND workspace_id = ?3`,
+			taskID, labelID, workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("detach label %q from task %q: %w", labelID, taskID, err)
+	}
+
+	if rowsAffected == 0 {
+		return label.ErrLabelNotAttached
+	}
+
+	return nil
+}
+
+func scanSQLiteLabel(row sqliteRow) (*label.Label, error) {
+	l := &label.Label{}
+	if err := row.Scan(&l.ID, &l.WorkspaceID, &l.Name, &l.Color,
+		sqliteTimestamp{&l.CreatedAt}, sqliteTimestamp{&l.UpdatedAt}); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
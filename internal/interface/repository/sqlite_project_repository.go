@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+)
+
+type sqliteProjectRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteProjectRepository(db *sql.DB) project.Repository {
+	return &sqliteProjectRepository{db: db}
+}
+
+func (r *sqliteProjectRepository) FindAll(ctx context.Context) ([]*project.Project, error) {
+	projects := []*project.Project{}
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		rows, err := q.QueryContext(ctx,
+			`SELECT id, workspace_id, name, description, archived, created_at, updated_at
+			 FROM projects WHERE workspace_id = ?1 ORDER BY created_at, id`,
+			workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			p, err := scanSQLiteProject(rows)
+			if err != nil {
+				return err
+			}
+			projects = append(projects, p)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (r *sqliteProjectRepository) FindByID(ctx context.Context, id string) (*project.Project, error) {
+	var p *project.Project
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		row := q.QueryRowContext(ctx,
+			`SELECT id, workspace_id, name, description, archived, created_at, updated_at
+			 FROM projects WHERE id = ?1 AND workspace_id = ?2`,
+			id, workspaceID,
+		)
+		var err error
+		p, err = scanSQLiteProject(row)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return nil, project.ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find project by id %q: %w", id, err)
+	}
+	return p, nil
+}
+
+func (r *sqliteProjectRepository) Create(ctx context.Context, p *project.Project) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO projects (id, workspace_id, name, description, archived, created_at, updated_at)
+			 VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)`,
+			p.ID, workspaceID, p.Name, p.Description, p.Archived, sqliteTime(p.CreatedAt), sqliteTime(p.UpdatedAt),
+		)
+		if err != nil {
+			return err
+		}
+		p.WorkspaceID = workspaceID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save project %q: %w", p.ID, err)
+	}
+	return nil
+}
+
+func (r *sqliteProjectRepository) Update(ctx context.Context, p *project.Project) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`UPDATE projects SET name = ?1, description = ?2, archived = ?3, updated_at = ?4
+			 WHERE id = ?5 AND workspace_id = ?6`,
+			p.Name, p.Description, p.Archived, sqliteTime(p.UpdatedAt), p.ID, workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save project %q: %w", p.ID, err)
+	}
+
+	if rowsAffected == 0 {
+		return project.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteProjectRepository) Delete(ctx context.Context, id string) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx, `DELETE FROM projects WHERE id = ?1 AND workspace_id = ?2`, id, workspaceID)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete project %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return project.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+func scanSQLiteProject(row sqliteRow) (*project.Project, error) {
+	p := &project.Project{}
+	if err := row.Scan(&p.ID, &p.WorkspaceID, &p.Name, &p.Description, &p.Archived,
+		sqliteTimestamp{&p.CreatedAt}, sqliteTimestamp{&p.UpdatedAt}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+)
+
+type sqliteReminderRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteReminderRepository(db *sql.DB) reminder.Store {
+	return &sqliteReminderRepository{db: db}
+}
+
+func (r *sqliteReminderRepository) DueWorkspaces(ctx context.Context, before time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT DISTINCT workspace_id FROM tasks
+		 WHERE due_at IS NOT NULL AND due_at < ?1 AND status IN `+openStatuses+` AND deleted_at IS NULL
+		 ORDER BY workspace_id`,
+		sqliteTime(before),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list reminder workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	workspaces := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("list reminder workspaces: %w", err)
+		}
+		workspaces = append(workspaces, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list reminder workspaces: %w", err)
+	}
+	return workspaces, nil
+}
+
+func (r *sqliteReminderRepository) Claim(ctx context.Context, rem reminder.Reminder) (bool, error) {
+	var claimed bool
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`INSERT INTO reminders_sent (task_id, workspace_id, kind, due_at)
+			 VALUES (?1, ?2, ?3, ?4)
+			 ON CONFLICT DO NOTHING`,
+			rem.TaskID, workspaceID, string(rem.Kind), sqliteTime(rem.DueAt),
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		claimed = rowsAffected == 1
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("claim reminder: %w", err)
+	}
+	return claimed, nil
+}
+
+func (r *sqliteReminderRepository) Release(ctx context.Context, rem reminder.Reminder) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`DELETE FROM reminders_sent
+			 WHERE task_id = ?1 AND kind = ?2 AND due_at = ?3 AND workspace_id = ?4`,
+			rem.TaskID, string(rem.Kind), sqliteTime(rem.DueAt), workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("release reminder: %w", err)
+	}
+	return nil
+}
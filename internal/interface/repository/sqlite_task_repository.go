@@ -0,0 +1,398 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+// sqliteAnnotationColumns are the SQLite form of annotationColumns, with the
+// arrays built as JSON; see scanSQLiteTask.
+const sqliteAnnotationColumns = `(SELECT json_group_array(a.user_id ORDER BY a.user_id) FROM task_assignees a WHERE a.task_id = t.id),
+	(SELECT json_group_array(l.name ORDER BY l.name) FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+	WHERE tl.task_id = t.id),
+	(SELECT count(*) FROM comments c WHERE c.task_id = t.id)`
+
+const selectSQLiteTasks = `SELECT ` + taskColumns + `, ` + progressColumns + `, ` + sqliteAnnotationColumns + ` FROM tasks t`
+
+type sqliteTaskRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(db *sql.DB) domain.Repository {
+	return &sqliteTaskRepository{db: db}
+}
+
+func (r *sqliteTaskRepository) FindByID(ctx context.Context, id string) (*domain.Task, error) {
+	var task *domain.Task
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		row := q.QueryRowContext(ctx,
+			selectSQLiteTasks+` WHERE id = ?1 AND workspace_id = ?2 AND deleted_at IS NULL`,
+			id, workspaceID,
+		)
+		var err error
+		task, err = scanSQLiteTask(row)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find task by id %q: %w", id, err)
+	}
+	return task, nil
+}
+
+func (r *sqliteTaskRepository) FindAll(ctx context.Context, filter domain.ListFilter) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		conditions := []string{"workspace_id = ?1", "deleted_at IS NULL"}
+		args := []any{workspaceID}
+		if filter.IDs != nil {
+			ids, err := sqliteArray(filter.IDs)
+			if err != nil {
+				return err
+			}
+			args = append(args, ids)
+			conditions = append(conditions, "id IN (SELECT value FROM json_each(?"+strconv.Itoa(len(args))+"))")
+		}
+		if filter.ProjectID != "" {
+			args = append(args, filter.ProjectID)
+			conditions = append(conditions, "project_id = ?"+strconv.Itoa(len(args)))
+		}
+		if filter.Priority != "" {
+			args = append(args, filter.Priority)
+			conditions = append(conditions, "priority = ?"+strconv.Itoa(len(args)))
+		}
+		if !filter.OverdueAt.IsZero() {
+			args = append(args, sqliteTime(filter.OverdueAt))
+			conditions = append(conditions, "status IN "+openStatuses+" AND due_at < ?"+strconv.Itoa(len(args)))
+		}
+		if filter.Assignee != "" {
+			args = append(args, filter.Assignee)
+			conditions = append(conditions,
+				"EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = t.id AND a.user_id = ?"+strconv.Itoa(len(args))+")")
+		}
+		if filter.SeriesID != "" {
+			args = append(args, filter.SeriesID)
+			conditions = append(conditions, "series_id = ?"+strconv.Itoa(len(args)))
+		}
+		if filter.MinOccurrence > 0 {
+			args = append(args, filter.MinOccurrence)
+			conditions = append(conditions, "occurrence >= ?"+strconv.Itoa(len(args)))
+		}
+		if len(filter.Labels) > 0 {
+			names, err := sqliteArray(filter.Labels)
+			if err != nil {
+				return err
+			}
+			args = append(args, names)
+			conditions = append(conditions, sqliteLabelCondition(filter.LabelMatch, len(args)))
+		}
+
+		var err error
+		tasks, err = querySQLiteTasks(ctx, q,
+			selectSQLiteTasks+` WHERE `+strings.Join(conditions, " AND ")+` ORDER BY `+orderBy(filter.SortBy),
+			args...,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *sqliteTaskRepository) FindTree(ctx context.Context, id string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		// The depth bound keeps the walk finite even if a cycle slipped past
+		// the domain checks.
+		var err error
+		tasks, err = querySQLiteTasks(ctx, q,
+			`WITH RECURSIVE tree AS (
+				SELECT `+qualifiedTaskColumns("r")+`, 0 AS depth
+				FROM tasks r WHERE r.id = ?1 AND r.workspace_id = ?2 AND r.deleted_at IS NULL
+				UNION ALL
+				SELECT `+qualifiedTaskColumns("c")+`, tree.depth + 1
+				FROM tasks c JOIN tree ON c.parent_id = tree.id
+				WHERE c.workspace_id = ?2 AND c.deleted_at IS NULL AND tree.depth < ?3
+			)
+			SELECT `+taskColumns+`, `+progressColumns+`, `+sqliteAnnotationColumns+` FROM tree t ORDER BY depth, created_at, id`,
+			id, workspaceID, domain.MaxDepth,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find task tree %q: %w", id, err)
+	}
+	if len(tasks) == 0 {
+		return nil, domain.ErrTaskNotFound
+	}
+	return tasks, nil
+}
+
+func (r *sqliteTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO tasks (`+taskColumns+`) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13, ?14)`,
+			task.ID, workspaceID, task.ProjectID, task.ParentID, task.Title, task.Status, task.Priority,
+			sqliteNullTime(task.DueAt), recurrenceRule(task.Recurrence), task.SeriesID, task.Occurrence,
+			sqliteTime(task.CreatedAt), sqliteTime(task.UpdatedAt), sqliteNullTime(task.DeletedAt),
+		)
+		if err != nil {
+			return err
+		}
+		if len(task.Assignees) > 0 {
+			assignees, err := sqliteArray(task.Assignees)
+			if err != nil {
+				return err
+			}
+			_, err = q.ExecContext(ctx,
+				`INSERT INTO task_assignees (task_id, user_id, workspace_id) SELECT ?1, value, ?3 FROM json_each(?2)`,
+				task.ID, assignees, workspaceID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		task.WorkspaceID = workspaceID
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("save task %q: %w", task.ID, err)
+	}
+	return nil
+}
+
+func (r *sqliteTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`UPDATE tasks SET project_id = ?1, parent_id = ?2, title = ?3, status = ?4, priority = ?5, due_at = ?6,
+			 recurrence = ?7, series_id = ?8, occurrence = ?9, updated_at = ?10
+			 WHERE id = ?11 AND workspace_id = ?12 AND deleted_at IS NULL`,
+			task.ProjectID, task.ParentID, task.Title, task.Status, task.Priority, sqliteNullTime(task.DueAt),
+			recurrenceRule(task.Recurrence), task.SeriesID, task.Occurrence, sqliteTime(task.UpdatedAt), task.ID, workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("save task %q: %w", task.ID, err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// Delete stamps the task and its live descendants with the same deletedAt,
+// like postgresTaskRepository.Delete.
+func (r *sqliteTaskRepository) Delete(ctx context.Context, id string, deletedAt time.Time) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`WITH RECURSIVE tree AS (
+				SELECT id, 0 AS depth FROM tasks WHERE id = ?1 AND workspace_id = ?2 AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.id, tree.depth + 1 FROM tasks c JOIN tree ON c.parent_id = tree.id
+				WHERE c.workspace_id = ?2 AND c.deleted_at IS NULL AND tree.depth < ?4
+			)
+			UPDATE tasks SET deleted_at = ?3 WHERE workspace_id = ?2 AND id IN (SELECT id FROM tree)`,
+			id, workspaceID, sqliteTime(deletedAt), domain.MaxDepth,
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete task %q: %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteTaskRepository) FindDeleted(ctx context.Context) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		var err error
+		tasks, err = querySQLiteTasks(ctx, q,
+			selectSQLiteTasks+` WHERE workspace_id = ?1 AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id`,
+			workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list deleted tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+func (r *sqliteTaskRepository) Restore(ctx context.Context, id string) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		var parentDeleted bool
+		err := q.QueryRowContext(ctx,
+			`SELECT p.deleted_at IS NOT NULL FROM tasks t LEFT JOIN tasks p ON p.id = t.parent_id
+			 WHERE t.id = ?1 AND t.workspace_id = ?2 AND t.deleted_at IS NOT NULL`,
+			id, workspaceID,
+		).Scan(&parentDeleted)
+		if err != nil {
+			return err
+		}
+		if parentDeleted {
+			return domain.ErrParentDeleted
+		}
+		_, err = q.ExecContext(ctx,
+			`WITH RECURSIVE tree AS (
+				SELECT id, deleted_at, 0 AS depth FROM tasks WHERE id = ?1 AND workspace_id = ?2
+				UNION ALL
+				SELECT c.id, c.deleted_at, tree.depth + 1 FROM tasks c JOIN tree ON c.parent_id = tree.id
+				WHERE c.workspace_id = ?2 AND c.deleted_at = tree.deleted_at AND tree.depth < ?3
+			)
+			UPDATE tasks SET deleted_at = NULL WHERE workspace_id = ?2 AND id IN (SELECT id FROM tree)`,
+			id, workspaceID, domain.MaxDepth,
+		)
+		return err
+	})
+	if err == sql.ErrNoRows {
+		return domain.ErrTaskNotFound
+	}
+	if err == domain.ErrParentDeleted {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("restore task %q: %w", id, err)
+	}
+	return nil
+}
+
+func (r *sqliteTaskRepository) AddAssignee(ctx context.Context, taskID string, userID string) error {
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		_, err := q.ExecContext(ctx,
+			`INSERT INTO task_assignees (task_id, user_id, workspace_id) VALUES (?1, ?2, ?3)
+			 ON CONFLICT DO NOTHING`,
+			taskID, userID, workspaceID,
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("assign task %q to %q: %w", taskID, userID, err)
+	}
+	return nil
+}
+
+func (r *sqliteTaskRepository) RemoveAssignee(ctx context.Context, taskID string, userID string) error {
+	var rowsAffected int64
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		result, err := q.ExecContext(ctx,
+			`DELETE FROM task_assignees WHERE task_id = ?1 AND user_id = ?2 AND workspace_id = ?3`,
+			taskID, userID, workspaceID,
+		)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unassign %q from task %q: %w", userID, taskID, err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAssigneeNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteTaskRepository) FindDueWithin(ctx context.Context, now time.Time, window time.Duration) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := withSQLite(ctx, r.db, func(q sqliteExecutor, workspaceID string) error {
+		var err error
+		tasks, err = querySQLiteTasks(ctx, q,
+			selectSQLiteTasks+`
+			 WHERE workspace_id = ?1 AND deleted_at IS NULL AND status IN `+openStatuses+` AND due_at >= ?2 AND due_at < ?3
+			 ORDER BY due_at, id`,
+			workspaceID, sqliteTime(now), sqliteTime(now.Add(window)),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tasks due within %s: %w", window, err)
+	}
+	return tasks, nil
+}
+
+// sqliteLabelCondition is the SQLite form of labelCondition, with the names
+// bound to parameter n as a JSON array.
+func sqliteLabelCondition(match domain.LabelMatch, n int) string {
+	param := "?" + strconv.Itoa(n)
+	if match == domain.LabelMatchAll {
+		return `id IN (SELECT tl.task_id FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE l.workspace_id = ?1 AND l.name IN (SELECT value FROM json_each(` + param + `))
+			GROUP BY tl.task_id HAVING count(*) = json_array_length(` + param + `))`
+	}
+	return `EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = t.id AND l.workspace_id = ?1 AND l.name IN (SELECT value FROM json_each(` + param + `)))`
+}
+
+func querySQLiteTasks(ctx context.Context, q sqliteExecutor, query string, args ...any) ([]*domain.Task, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []*domain.Task{}
+	for rows.Next() {
+		t, err := scanSQLiteTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+type sqliteRow interface {
+	Scan(dest ...any) error
+}
+
+func scanSQLiteTask(row sqliteRow) (*domain.Task, error) {
+	t := &domain.Task{}
+	var rule *string
+	if err := row.Scan(
+		&t.ID, &t.WorkspaceID, &t.ProjectID, &t.ParentID, &t.Title, &t.Status, &t.Priority,
+		sqliteNullTimestamp{&t.DueAt}, &rule, &t.SeriesID, &t.Occurrence,
+		sqliteTimestamp{&t.CreatedAt}, sqliteTimestamp{&t.UpdatedAt}, sqliteNullTimestamp{&t.DeletedAt},
+		&t.Subtasks.Total, &t.Subtasks.Completed,
+		sqliteList{&t.Assignees}, sqliteList{&t.Labels}, &t.CommentCount,
+	); err != nil {
+		return nil, err
+	}
+	if rule != nil {
+		recurrence, err := domain.ParseRecurrence(*rule)
+		if err != nil {
+			return nil, fmt.Errorf("task %q: %w", t.ID, err)
+		}
+		t.Recurrence = recurrence
+	}
+	return t, nil
+}
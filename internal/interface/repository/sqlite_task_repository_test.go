@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/db"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sqlite repositories", func() {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	var (
+		ctx    context.Context
+		path   string
+		sqlDB  *sql.DB
+		tasks  domain.Repository
+		labels label.Repository
+	)
+
+	newTask := func(id string, createdAt time.Time) *domain.Task {
+		return &domain.Task{ID: id, Title: "Task " + id, Status: domain.StatusTodo, Priority: domain.PriorityMedium,
+			CreatedAt: createdAt, UpdatedAt: createdAt}
+	}
+
+	ids := func(tasks []*domain.Task) []string {
+		ids := make([]string, len(tasks))
+		for i, t := range tasks {
+			ids[i] = t.ID
+		}
+		return ids
+	}
+
+	BeforeEach(func() {
+		ctx = workspace.WithID(context.Background(), workspaceA)
+		path = filepath.Join(GinkgoT().TempDir(), "tasks.db")
+		var err error
+		sqlDB, err = db.NewSQLite(path)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sqlDB.Close)
+		tasks = NewSQLiteRepository(sqlDB)
+		labels = NewSQLiteLabelRepository(sqlDB)
+	})
+
+	It("opens an existing database without applying the migrations again", func() {
+		Expect(tasks.Create(ctx, newTask("task-1", now))).To(Succeed())
+
+		again, err := db.NewSQLite(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer again.Close()
+
+		var journalMode string
+		Expect(again.QueryRow(`PRAGMA journal_mode`).Scan(&journalMode)).To(Succeed())
+		Expect(journalMode).To(Equal("wal"))
+		_, err = NewSQLiteRepository(again).FindByID(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("FindByID", func() {
+		It("fills in progress, labels and comment count", func() {
+			parentID := "task-1"
+			Expect(tasks.Create(ctx, newTask("task-1", now))).To(Succeed())
+			for i, status := range []domain.Status{domain.StatusComplete, domain.StatusTodo, domain.StatusCancelled} {
+				child := newTask("child-"+strconv.Itoa(i), now)
+				child.ParentID = &parentID
+				child.Status = status
+				Expect(tasks.Create(ctx, child)).To(Succeed())
+			}
+			Expect(labels.Create(ctx, &label.Label{ID: "label-1", Name: "bug", Color: "#ff0000"})).To(Succeed())
+			Expect(labels.Attach(ctx, "task-1", "label-1")).To(Succeed())
+			Expect(NewSQLiteCommentRepository(sqlDB).Create(ctx,
+				&comment.Comment{ID: "comment-1", TaskID: "task-1", AuthorID: "alice", Body: "Looks good", CreatedAt: now})).To(Succeed())
+
+			found, err := tasks.FindByID(ctx, "task-1")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Subtasks).To(Equal(domain.Progress{Total: 2, Completed: 1}))
+			Expect(found.Labels).To(Equal([]string{"bug"}))
+			Expect(found.CommentCount).To(Equal(1))
+		})
+	})
+
+	Describe("FindAll", func() {
+		BeforeEach(func() {
+			for _, id := range []string{"task-1", "task-2", "task-3"} {
+				Expect(tasks.Create(ctx, newTask(id, now))).To(Succeed())
+			}
+			Expect(labels.Create(ctx, &label.Label{ID: "label-1", Name: "bug", Color: "#ff0000"})).To(Succeed())
+			Expect(labels.Create(ctx, &label.Label{ID: "label-2", Name: "ui", Color: "#00ff00"})).To(Succeed())
+			Expect(labels.Attach(ctx, "task-1", "label-1")).To(Succeed())
+			Expect(labels.Attach(ctx, "task-2", "label-1")).To(Succeed())
+			Expect(labels.Attach(ctx, "task-2", "label-2")).To(Succeed())
+		})
+
+		It("matches any or all of the labels", func() {
+			anyLabel, err := tasks.FindAll(ctx, domain.ListFilter{Labels: []string{"bug", "ui"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(anyLabel)).To(Equal([]string{"task-1", "task-2"}))
+
+			allLabels, err := tasks.FindAll(ctx, domain.ListFilter{Labels: []string{"bug", "ui"}, LabelMatch: domain.LabelMatchAll})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(allLabels)).To(Equal([]string{"task-2"}))
+		})
+	})
+
+	Describe("Purge", func() {
+		BeforeEach(func() {
+			parentID := "task-1"
+			Expect(tasks.Create(ctx, newTask("task-1", now))).To(Succeed())
+			child := newTask("child-1", now)
+			child.ParentID = &parentID
+			Expect(tasks.Create(ctx, child)).To(Succeed())
+		})
+
+		It("purges tasks deleted before the cutoff together with their subtasks", func() {
+			Expect(tasks.Delete(ctx, "task-1", now)).To(Succeed())
+
+			purged, err := NewSQLiteTrashRepository(sqlDB).Purge(context.Background(), now.Add(time.Second))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(purged).To(Equal(int64(2)))
+			trash, _ := tasks.FindDeleted(ctx)
+			Expect(trash).To(BeEmpty())
+		})
+	})
+
+	It("rejects a second label with the same name in a workspace", func() {
+		Expect(labels.Create(ctx, &label.Label{ID: "label-1", Name: "bug", Color: "#ff0000"})).To(Succeed())
+
+		Expect(labels.Create(ctx, &label.Label{ID: "label-2", Name: "bug", Color: "#ff0000"})).To(Equal(label.ErrLabelExists))
+		Expect(labels.Create(workspace.WithID(context.Background(), workspaceB),
+			&label.Label{ID: "label-3", Name: "bug", Color: "#ff0000"})).To(Succeed())
+	})
+
+	It("detaches tasks from a deleted project", func() {
+		projects := NewSQLiteProjectRepository(sqlDB)
+		projectID := "project-1"
+		Expect(projects.Create(ctx, &project.Project{ID: projectID, Name: "Launch", CreatedAt: now, UpdatedAt: now})).To(Succeed())
+		t := newTask("task-1", now)
+		t.ProjectID = &projectID
+		Expect(tasks.Create(ctx, t)).To(Succeed())
+
+		Expect(projects.Delete(ctx, projectID)).To(Succeed())
+
+		found, _ := tasks.FindByID(ctx, "task-1")
+		Expect(found.ProjectID).To(BeNil())
+	})
+
+	It("walks the dependency graph in both directions", func() {
+		deps := NewSQLiteDependencyRepository(sqlDB)
+		for _, id := range []string{"a", "b", "c", "d"} {
+			Expect(tasks.Create(ctx, newTask(id, now))).To(Succeed())
+		}
+		Expect(deps.Add(ctx, domain.Dependency{TaskID: "a", BlockerID: "b"})).To(Succeed())
+		Expect(deps.Add(ctx, domain.Dependency{TaskID: "b", BlockerID: "c"})).To(Succeed())
+		Expect(deps.Add(ctx, domain.Dependency{TaskID: "d", BlockerID: "a"})).To(Succeed())
+
+		graph, err := deps.FindGraph(ctx, "b")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(graph).To(Equal([]domain.Dependency{
+			{TaskID: "a", BlockerID: "b"}, {TaskID: "b", BlockerID: "c"}, {TaskID: "d", BlockerID: "a"},
+		}))
+		blockers, err := deps.FindOpenBlockers(ctx, []string{"a", "d"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blockers).To(Equal([]domain.Dependency{{TaskID: "a", BlockerID: "b"}, {TaskID: "d", BlockerID: "a"}}))
+	})
+
+	It("pages comments after the last one seen", func() {
+		comments := NewSQLiteCommentRepository(sqlDB)
+		Expect(tasks.Create(ctx, newTask("task-1", now))).To(Succeed())
+		for i := range 3 {
+			Expect(comments.Create(ctx, &comment.Comment{ID: "comment-" + strconv.Itoa(i), TaskID: "task-1",
+				AuthorID: "alice", Body: "Note", CreatedAt: now.Add(time.Duration(i) * time.Second)})).To(Succeed())
+		}
+
+		first, err := comments.FindByTask(ctx, "task-1", comment.Page{Limit: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(HaveLen(2))
+		rest, err := comments.FindByTask(ctx, "task-1", comment.Page{Limit: 2, After: &comment.Cursor{CreatedAt: first[1].CreatedAt, ID: first[1].ID}})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rest).To(HaveLen(1))
+		Expect(rest[0].ID).To(Equal("comment-2"))
+	})
+
+	It("keeps the audit log append-only", func() {
+		records := NewSQLiteAuditRepository(sqlDB)
+		Expect(records.Append(ctx, &audit.Record{ID: "record-1", TaskID: "task-1", Actor: "alice",
+			Action: audit.ActionCreate, Changes: map[string]audit.Change{"title": {After: "Plan"}}, CreatedAt: now})).To(Succeed())
+
+		_, err := sqlDB.Exec(`DELETE FROM audit_log`)
+		Expect(err).To(MatchError(ContainSubstring("append-only")))
+
+		found, err := records.FindByTask(ctx, "task-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(HaveLen(1))
+		Expect(found[0].Changes["title"].After).To(Equal("Plan"))
+	})
+
+	It("rolls back every write of a failed transaction", func() {
+		failed := errors.New("failed")
+
+		err := NewSQLiteTransactor(sqlDB).Within(ctx, func(ctx context.Context) error {
+			Expect(tasks.Create(ctx, newTask("task-1", now))).To(Succeed())
+			return failed
+		})
+
+		Expect(err).To(Equal(failed))
+		_, err = tasks.FindByID(ctx, "task-1")
+		Expect(err).To(Equal(domain.ErrTaskNotFound))
+	})
+})
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/trash"
+)
+
+type sqliteTrashRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteTrashRepository(db *sql.DB) trash.Store {
+	return &sqliteTrashRepository{db: db}
+}
+
+// Purge counts the tasks to delete before deleting them, as SQLite removes a
+// subtask through ON DELETE CASCADE as soon as its parent goes and the DELETE
+// itself then no longer counts it. The rows attached to the tasks go the same
+// way.
+func (r *sqliteTrashRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := func() error {
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if err := tx.QueryRowContext(ctx,
+			`SELECT count(*) FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ?1`, sqliteTime(deletedBefore),
+		).Scan(&purged); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ?1`, sqliteTime(deletedBefore),
+		); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}()
+	if err != nil {
+		return 0, fmt.Errorf("purge tasks deleted before %s: %w", deletedBefore.Format(time.RFC3339), err)
+	}
+	return purged, nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteExecutor is the part of *sql.DB and *sql.Tx the SQLite repositories
+// use.
+type sqliteExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type sqliteTxKey struct{}
+
+// sqliteTimeFormat writes every timestamp in UTC with nanoseconds padded to
+// a fixed width, so that comparing the stored text orders them in time.
+const sqliteTimeFormat = "2006-01-02T15:04:05.000000000Z"
+
+// withSQLite is the SQLite counterpart of inWorkspace. SQLite has no row
+// level security, so the workspace is only passed on to the explicit filters
+// in each query. When ctx already carries a transaction opened by
+// sqliteTransactor, fn runs inside it and the transactor commits.
+func withSQLite(ctx context.Context, db *sql.DB, fn func(q sqliteExecutor, workspaceID string) error) error {
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok {
+		return workspace.ErrWorkspaceRequired
+	}
+	if tx, ok := ctx.Value(sqliteTxKey{}).(*sql.Tx); ok {
+		return fn(tx, workspaceID)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx, workspaceID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+type sqliteTransactor struct {
+	db *sql.DB
+}
+
+func NewSQLiteTransactor(db *sql.DB) transaction.Transactor {
+	return &sqliteTransactor{db: db}
+}
+
+// Within opens a transaction and hands it to the SQLite repositories through
+// the ctx passed to fn.
+func (t *sqliteTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(sqliteTxKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	return withSQLite(ctx, t.db, func(q sqliteExecutor, _ string) error {
+		return fn(context.WithValue(ctx, sqliteTxKey{}, q))
+	})
+}
+
+// sqliteTime converts t for a TEXT timestamp column.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeFormat)
+}
+
+// sqliteNullTime converts t for a nullable TEXT timestamp column.
+func sqliteNullTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return sqliteTime(*t)
+}
+
+// sqliteTimestamp scans a column written by sqliteTime into dst.
+type sqliteTimestamp struct {
+	dst *time.Time
+}
+
+func (s sqliteTimestamp) Scan(src any) error {
+	text, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("scan timestamp: unexpected %T", src)
+	}
+	t, err := time.Parse(sqliteTimeFormat, text)
+	if err != nil {
+		return fmt.Errorf("scan timestamp: %w", err)
+	}
+	*s.dst = t
+	return nil
+}
+
+// sqliteNullTimestamp scans a column written by sqliteNullTime into dst.
+type sqliteNullTimestamp struct {
+	dst **time.Time
+}
+
+func (s sqliteNullTimestamp) Scan(src any) error {
+	if src == nil {
+		*s.dst = nil
+		return nil
+	}
+	var t time.Time
+	if err := (sqliteTimestamp{dst: &t}).Scan(src); err != nil {
+		return err
+	}
+	*s.dst = &t
+	return nil
+}
+
+// sqliteList scans a JSON array built with json_group_array into dst.
+type sqliteList struct {
+	dst *[]string
+}
+
+func (s sqliteList) Scan(src any) error {
+	text, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("scan list: unexpected %T", src)
+	}
+	return json.Unmarshal([]byte(text), s.dst)
+}
+
+// sqliteArray encodes values for json_each, which stands in for the
+// Postgres array parameters.
+func sqliteArray(values []string) (string, error) {
+	if values == nil {
+		values = []string{}
+	}
+	encoded, err := json.Marshal(values)
+	return string(encoded), err
+}
+
+// isSQLiteUniqueViolation reports whether err is a duplicate primary or
+// unique key.
+func isSQLiteUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code()
+	return code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
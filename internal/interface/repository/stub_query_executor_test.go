@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// stubQueryExecutor records the statements of the repositories whose specs
+// check the SQL they send rather than run it against a database.
+type stubQueryExecutor struct {
+	execState *stubExecState
+}
+
+type stubCall struct {
+	sql  string
+	args []any
+}
+
+type stubExecState struct {
+	rowsAffected int64
+	execErr      error
+	commitErr    error
+	calls        []stubCall
+	begun        int
+	committed    bool
+	rolledBack   bool
+}
+
+func (s *stubExecState) record(sql string, args []any) {
+	s.calls = append(s.calls, stubCall{sql: sql, args: args})
+}
+
+func (s *stubExecState) lastCall() stubCall {
+	return s.calls[len(s.calls)-1]
+}
+
+func (s *stubQueryExecutor) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	s.execState.record(sql, args)
+	if s.execState.execErr != nil && !strings.Contains(sql, "set_config") {
+		return pgconn.CommandTag{}, s.execState.execErr
+	}
+	return pgconn.NewCommandTag("UPDATE " + strconv.FormatInt(s.execState.rowsAffected, 10)), nil
+}
+
+func (s *stubQueryExecutor) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	s.execState.record(sql, args)
+	return nil, errors.New("not implemented")
+}
+
+func (s *stubQueryExecutor) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	s.execState.record(sql, args)
+	return stubRow{}
+}
+
+func (s *stubQueryExecutor) Begin(context.Context) (pgx.Tx, error) {
+	s.execState.begun++
+	return &stubTx{stubQueryExecutor: s}, nil
+}
+
+// stubTx satisfies pgx.Tx by embedding the interface; only the methods the
+// repositories use are implemented.
+type stubTx struct {
+	pgx.Tx
+	*stubQueryExecutor
+}
+
+func (t *stubTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return t.stubQueryExecutor.Exec(ctx, sql, args...)
+}
+
+func (t *stubTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return t.stubQueryExecutor.Query(ctx, sql, args...)
+}
+
+func (t *stubTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.stubQueryExecutor.QueryRow(ctx, sql, args...)
+}
+
+func (t *stubTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	return t.stubQueryExecutor.Begin(ctx)
+}
+
+func (t *stubTx) Commit(context.Context) error {
+	if t.execState.commitErr != nil {
+		return t.execState.commitErr
+	}
+	t.execState.committed = true
+	return nil
+}
+
+func (t *stubTx) Rollback(context.Context) error {
+	if !t.execState.committed {
+		t.execState.rolledBack = true
+	}
+	return nil
+}
+
+type stubRow struct{}
+
+func (stubRow) Scan(...any) error {
+	return errors.New("not implemented")
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"cmp"
+	"slices"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+// The helpers below give the repositories that filter tasks in memory the
+// semantics of the SQL in postgresTaskRepository.
+
+// tree returns the task with the given id followed by the descendants keep
+// accepts, ordered like postgresTaskRepository.FindTree.
+func tree(all []*domain.Task, id string, keep func(child, parent *domain.Task) bool) []*domain.Task {
+	var root *domain.Task
+	children := map[string][]*domain.Task{}
+	for _, t := range all {
+		if t.ID == id {
+			root = t
+		}
+		if t.ParentID != nil {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+		}
+	}
+	if root == nil {
+		return nil
+	}
+
+	tasks := []*domain.Task{root}
+	level := []*domain.Task{root}
+	for depth := 0; depth < domain.MaxDepth && len(level) > 0; depth++ {
+		var next []*domain.Task
+		for _, parent := range level {
+			for _, child := range children[parent.ID] {
+				if keep(child, parent) {
+					next = append(next, child)
+				}
+			}
+		}
+		slices.SortFunc(next, compareCreatedAt)
+		tasks = append(tasks, next...)
+		level = next
+	}
+	return tasks
+}
+
+// matches applies filter like the WHERE clause of postgresTaskRepository.FindAll.
+// Labels are matched by name against t.Labels.
+func matches(t *domain.Task, filter domain.ListFilter) bool {
+	switch {
+	case filter.IDs != nil && !slices.Contains(filter.IDs, t.ID):
+		return false
+	case filter.ProjectID != "" && (t.ProjectID == nil || *t.ProjectID != filter.ProjectID):
+		return false
+	case filter.Priority != "" && t.Priority != filter.Priority:
+		return false
+	case !filter.OverdueAt.IsZero() && !t.IsOverdue(filter.OverdueAt):
+		return false
+	case filter.Assignee != "" && !slices.Contains(t.Assignees, filter.Assignee):
+		return false
+	case filter.SeriesID != "" && (t.SeriesID == nil || *t.SeriesID != filter.SeriesID):
+		return false
+	case t.Occurrence < filter.MinOccurrence:
+		return false
+	case len(filter.Labels) > 0 && !matchesLabels(t.Labels, filter):
+		return false
+	}
+	return true
+}
+
+func matchesLabels(labels []string, filter domain.ListFilter) bool {
+	if filter.LabelMatch == domain.LabelMatchAll {
+		return !slices.ContainsFunc(filter.Labels, func(name string) bool { return !slices.Contains(labels, name) })
+	}
+	return slices.ContainsFunc(filter.Labels, func(name string) bool { return slices.Contains(labels, name) })
+}
+
+func compareCreatedAt(a, b *domain.Task) int {
+	return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+}
+
+// compareDueAt orders by due date with undated tasks last.
+func compareDueAt(a, b *domain.Task) int {
+	switch {
+	case a.DueAt == nil && b.DueAt != nil:
+		return 1
+	case a.DueAt != nil && b.DueAt == nil:
+		return -1
+	case a.DueAt != nil && b.DueAt != nil:
+		if c := a.DueAt.Compare(*b.DueAt); c != 0 {
+			return c
+		}
+	}
+	return compareCreatedAt(a, b)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task/tasktest"
+	"github.com/ko44d/go-clean-hexapp/internal/infrastructure/db"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// postgresDSNEnv names the variable holding the DSN of a migrated Postgres
+// database for the contract specs; they are skipped when it is unset.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+var _ = Describe("task repository contract", func() {
+	Describe("memoryTaskRepository", func() {
+		tasktest.DescribeRepository(func() domain.Repository {
+			return NewMemoryRepository(NewMemoryStore())
+		})
+	})
+
+	Describe("CachedRepository", func() {
+		tasktest.DescribeRepository(func() domain.Repository {
+			return NewCachedRepository(NewMemoryRepository(NewMemoryStore()), 100, time.Minute)
+		})
+	})
+
+	Describe("sqliteTaskRepository", func() {
+		tasktest.DescribeRepository(func() domain.Repository {
+			sqlDB, err := db.NewSQLite(filepath.Join(GinkgoT().TempDir(), "tasks.db"))
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(sqlDB.Close)
+			return NewSQLiteRepository(sqlDB)
+		})
+	})
+
+	Describe("postgresTaskRepository", func() {
+		tasktest.DescribeRepository(func() domain.Repository {
+			return New(postgresPools("").Primary)
+		})
+	})
+
+	Describe("postgresTaskRepository with a replica under a Resilience policy", func() {
+		tasktest.DescribeRepository(func() domain.Repository {
+			// The replica is the same database, so reads see the writes of
+			// the spec as they would on a replica that has caught up.
+			pools := postgresPools(os.Getenv(postgresDSNEnv))
+			primary := NewResilientDB(pools.Primary, NewResilience(3, 5, time.Second))
+			return NewWithReplica(primary, pools.Replica, pools.ReplicaHealthy)
+		})
+	})
+
+	Describe("postgresEventTaskRepository", func() {
+		tasktest.DescribeRepository(func() domain.Repository {
+			return NewEventRepository(postgresPools("").Primary, 3)
+		})
+	})
+})
+
+// postgresPools connects to the database named by postgresDSNEnv, with
+// replicaDSN as the replica, and skips the spec when the variable is unset.
+func postgresPools(replicaDSN string) *db.Pools {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		Skip(postgresDSNEnv + " is not set")
+	}
+	pools, err := db.New(dsn, replicaDSN, db.Options{})
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(pools.Close)
+	return pools
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
+)
+
+type txKey struct{}
+
+// inWorkspace runs fn inside a transaction scoped to the workspace carried by
+// ctx. The workspace is published to Postgres as app.workspace_id for the
+// lifetime of the transaction so row level security policies apply on top of
+// the explicit filters in each query. When ctx already carries a transaction
+// opened by postgresTransactor, fn runs inside it and the transactor commits.
+// Otherwise, when db comes from NewResilientDB, the whole transaction runs
+// under its policy.
+func inWorkspace(ctx context.Context, db queryExecutor, fn func(q queryExecutor, workspaceID string) error) error {
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok {
+		return workspace.ErrWorkspaceRequired
+	}
+	if tx, ok := ctx.Value(txKey{}).(queryExecutor); ok {
+		return fn(tx, workspaceID)
+	}
+	if r, ok := db.(*resilientDB); ok {
+		// Until COMMIT is sent, a broken connection rolls the transaction
+		// back, so any attempt may run again; see commitError.
+		return r.policy.do(ctx, true, func() error {
+			return runInWorkspace(ctx, r.queryExecutor, workspaceID, fn)
+		})
+	}
+	return runInWorkspace(ctx, db, workspaceID, fn)
+}
+
+func runInWorkspace(ctx context.Context, db queryExecutor, workspaceID string, fn func(q queryExecutor, workspaceID string) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := setLocals(ctx, tx, workspaceID); err != nil {
+		return err
+	}
+	if err := fn(tx, workspaceID); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return &commitError{err: err}
+	}
+	return nil
+}
+
+// commitError is a failed COMMIT. Unlike a statement before it, the
+// transaction may have committed although the connection broke.
+type commitError struct {
+	err error
+}
+
+func (e *commitError) Error() string {
+	return "commit transaction: " + e.err.Error()
+}
+
+func (e *commitError) Unwrap() error {
+	return e.err
+}
+
+// setLocals publishes the workspace to the transaction. When ctx has a
+// deadline, statement_timeout is set to the time left, so Postgres stops
+// working on statements nobody waits for anymore even if the cancel request
+// sent by pgx does not reach it.
+func setLocals(ctx context.Context, tx queryExecutor, workspaceID string) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		if _, err := tx.Exec(ctx, `SELECT set_config('app.workspace_id', $1, true)`, workspaceID); err != nil {
+			return fmt.Errorf("set workspace: %w", err)
+		}
+		return nil
+	}
+
+	timeout := max(time.Until(deadline).Milliseconds(), 1)
+	if _, err := tx.Exec(ctx,
+		`SELECT set_config('app.workspace_id', $1, true), set_config('statement_timeout', $2, true)`,
+		workspaceID, strconv.FormatInt(timeout, 10),
+	); err != nil {
+		return fmt.Errorf("set workspace: %w", err)
+	}
+	return nil
+}
+
+type postgresTransactor struct {
+	db queryExecutor
+}
+
+func NewTransactor(db DB) transaction.Transactor {
+	return &postgresTransactor{db: db}
+}
+
+// Within opens a workspace scoped transaction and hands it to the
+// repositories through the ctx passed to fn.
+func (t *postgresTransactor) Within(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(queryExecutor); ok {
+		return fn(ctx)
+	}
+	return inWorkspace(ctx, t.db, func(q queryExecutor, _ string) error {
+		return fn(context.WithValue(ctx, txKey{}, q))
+	})
+}
@@ -4,14 +4,50 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/ko44d/go-clean-hexapp/internal/interface/handler"
+	"github.com/ko44d/go-clean-hexapp/internal/interface/middleware"
 )
 
-func New(taskHandler *handler.TaskHandler) *gin.Engine {
+func New(timeouts middleware.Timeouts, rateLimit gin.HandlerFunc, taskHandler *handler.TaskHandler, projectHandler *handler.ProjectHandler, labelHandler *handler.LabelHandler, commentHandler *handler.CommentHandler, auditHandler *handler.AuditHandler) *gin.Engine {
 	r := gin.Default()
+	r.Use(middleware.RequestID(), middleware.Timeout(timeouts), middleware.Identity(), rateLimit, middleware.Workspace(), middleware.ReadYourWrites())
 
 	r.GET("/tasks", taskHandler.GetTasks)
+	r.GET("/tasks/:id", taskHandler.GetTask)
+	r.GET("/tasks/:id/subtasks", taskHandler.GetSubtasks)
 	r.POST("/tasks", taskHandler.AddTask)
+	r.PUT("/tasks/:id", taskHandler.UpdateTask)
+	r.DELETE("/tasks/:id", taskHandler.DeleteTask)
+	r.POST("/tasks/:id/restore", taskHandler.RestoreTask)
+	r.GET("/tasks/:id/history", auditHandler.GetTaskHistory)
 	r.POST("/tasks/complete", taskHandler.CompleteTask)
+	r.POST("/tasks/:id/transitions", taskHandler.TransitionTask)
+	r.POST("/tasks/:id/assignees", taskHandler.AssignTask)
+	r.DELETE("/tasks/:id/assignees/:user", taskHandler.UnassignTask)
+	r.GET("/tasks/:id/dependency-graph", taskHandler.GetDependencyGraph)
+	r.POST("/tasks/:id/dependencies", taskHandler.AddDependency)
+	r.DELETE("/tasks/:id/dependencies/:blocker_id", taskHandler.RemoveDependency)
+	r.POST("/tasks/:id/labels", labelHandler.AttachLabel)
+	r.DELETE("/tasks/:id/labels/:label_id", labelHandler.DetachLabel)
+	r.GET("/tasks/:id/comments", commentHandler.GetComments)
+	r.POST("/tasks/:id/comments", commentHandler.AddComment)
+	r.PUT("/tasks/:id/comments/:comment_id", commentHandler.EditComment)
+	r.DELETE("/tasks/:id/comments/:comment_id", commentHandler.DeleteComment)
+
+	r.GET("/projects", projectHandler.GetProjects)
+	r.POST("/projects", projectHandler.CreateProject)
+	r.GET("/projects/:id", projectHandler.GetProject)
+	r.PUT("/projects/:id", projectHandler.UpdateProject)
+	r.DELETE("/projects/:id", projectHandler.DeleteProject)
+	r.GET("/projects/:id/tasks", taskHandler.GetProjectTasks)
+
+	r.GET("/labels", labelHandler.GetLabels)
+	r.POST("/labels", labelHandler.CreateLabel)
+	r.GET("/labels/:id", labelHandler.GetLabel)
+	r.PUT("/labels/:id", labelHandler.UpdateLabel)
+	r.DELETE("/labels/:id", labelHandler.DeleteLabel)
+
+	r.GET("/trash", taskHandler.GetTrash)
+	r.GET("/audit", auditHandler.GetAuditLog)
 
 	return r
 }
@@ -0,0 +1,13 @@
+package audit
+
+import (
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+var (
+	ErrInvalidLimit      = domain.ErrInvalidLimit
+	ErrForbidden         = authz.ErrForbidden
+	ErrWorkspaceRequired = workspace.ErrWorkspaceRequired
+)
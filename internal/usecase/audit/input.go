@@ -0,0 +1,11 @@
+package audit
+
+import "time"
+
+// ListRecordsInput selects records of the workspace, oldest first. Actor and
+// Since are optional; Limit defaults to 100 and may be at most 500.
+type ListRecordsInput struct {
+	Actor string
+	Since time.Time
+	Limit int
+}
@@ -0,0 +1,54 @@
+//go:generate mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+// Interactor reads the audit log written by the task interactor. The history
+// of a task needs task:read and stays readable after the task is deleted;
+// the workspace wide log needs audit:read.
+type Interactor interface {
+	GetTaskHistory(ctx context.Context, taskID string) ([]RecordOutput, error)
+	GetAuditLog(ctx context.Context, input ListRecordsInput) ([]RecordOutput, error)
+}
+
+type interactor struct {
+	repo       domain.Repository
+	authorizer authz.Authorizer
+}
+
+func New(repo domain.Repository, authorizer authz.Authorizer) Interactor {
+	return &interactor{repo: repo, authorizer: authorizer}
+}
+
+func (i *interactor) GetTaskHistory(ctx context.Context, taskID string) ([]RecordOutput, error) {
+	if err := authz.Check(ctx, i.authorizer, authz.ActionTaskRead, authz.Resource{Type: "task", ID: taskID}); err != nil {
+		return nil, err
+	}
+	records, err := i.repo.FindByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("GetTaskHistory: %w", err)
+	}
+	return toRecordOutputs(records), nil
+}
+
+func (i *interactor) GetAuditLog(ctx context.Context, input ListRecordsInput) ([]RecordOutput, error) {
+	if err := authz.Check(ctx, i.authorizer, authz.ActionAuditRead, authz.Resource{Type: "audit"}); err != nil {
+		return nil, err
+	}
+	filter, err := domain.NewFilter(input.Actor, input.Since, input.Limit)
+	if err != nil {
+		return nil, err
+	}
+	records, err := i.repo.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("GetAuditLog: %w", err)
+	}
+	return toRecordOutputs(records), nil
+}
@@ -0,0 +1,101 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+	authzmocks "github.com/ko44d/go-clean-hexapp/internal/usecase/authz/mocks"
+)
+
+func TestAuditInteractor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Interactor Suite")
+}
+
+var _ = Describe("Audit Interactor", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockRepo       *mocks.MockRepository
+		mockAuthorizer *authzmocks.MockAuthorizer
+		interactor     audit.Interactor
+		ctx            context.Context
+	)
+
+	allow := func(action authz.Action) {
+		mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), action, gomock.Any()).Return(true, nil).AnyTimes()
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepository(ctrl)
+		mockAuthorizer = authzmocks.NewMockAuthorizer(ctrl)
+		interactor = audit.New(mockRepo, mockAuthorizer)
+		ctx = authz.WithPrincipal(context.Background(), authz.Principal{ID: "user-1", Roles: []string{"admin"}})
+		ctx = workspace.WithID(ctx, "workspace-1")
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("GetTaskHistory", func() {
+		It("should return the records of the task", func() {
+			allow(authz.ActionTaskRead)
+			mockRepo.EXPECT().FindByTask(ctx, "task-1").Return([]*domain.Record{{
+				ID:      "record-1",
+				TaskID:  "task-1",
+				Actor:   "user-1",
+				Action:  domain.ActionUpdate,
+				Changes: map[string]domain.Change{"title": {Before: "Old", After: "New"}},
+			}}, nil)
+
+			records, err := interactor.GetTaskHistory(ctx, "task-1")
+
+			Expect(err).To(BeNil())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Action).To(Equal("update"))
+			Expect(records[0].Changes["title"]).To(Equal(audit.ChangeOutput{Before: "Old", After: "New"}))
+		})
+	})
+
+	Describe("GetAuditLog", func() {
+		It("should filter by actor and time", func() {
+			allow(authz.ActionAuditRead)
+			since := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+			mockRepo.EXPECT().Find(ctx, domain.Filter{Actor: "user-2", Since: since, Limit: domain.DefaultLimit}).
+				Return([]*domain.Record{}, nil)
+
+			records, err := interactor.GetAuditLog(ctx, audit.ListRecordsInput{Actor: "user-2", Since: since})
+
+			Expect(err).To(BeNil())
+			Expect(records).To(BeEmpty())
+		})
+
+		It("should reject invalid limits", func() {
+			allow(authz.ActionAuditRead)
+
+			_, err := interactor.GetAuditLog(ctx, audit.ListRecordsInput{Limit: -1})
+
+			Expect(err).To(Equal(audit.ErrInvalidLimit))
+		})
+
+		It("should require the audit:read permission", func() {
+			mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), authz.ActionAuditRead, authz.Resource{Type: "audit"}).
+				Return(false, nil)
+
+			_, err := interactor.GetAuditLog(ctx, audit.ListRecordsInput{})
+
+			Expect(err).To(Equal(audit.ErrForbidden))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	audit "github.com/ko44d/go-clean-hexapp/internal/usecase/audit"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractor is a mock of Interactor interface.
+type MockInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractorMockRecorder is the mock recorder for MockInteractor.
+type MockInteractorMockRecorder struct {
+	mock *MockInteractor
+}
+
+// NewMockInteractor creates a new mock instance.
+func NewMockInteractor(ctrl *gomock.Controller) *MockInteractor {
+	mock := &MockInteractor{ctrl: ctrl}
+	mock.recorder = &MockInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractor) EXPECT() *MockInteractorMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLog mocks base method.
+func (m *MockInteractor) GetAuditLog(ctx context.Context, input audit.ListRecordsInput) ([]audit.RecordOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLog", ctx, input)
+	ret0, _ := ret[0].([]audit.RecordOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockInteractorMockRecorder) GetAuditLog(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockInteractor)(nil).GetAuditLog), ctx, input)
+}
+
+// GetTaskHistory mocks base method.
+func (m *MockInteractor) GetTaskHistory(ctx context.Context, taskID string) ([]audit.RecordOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskHistory", ctx, taskID)
+	ret0, _ := ret[0].([]audit.RecordOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskHistory indicates an expected call of GetTaskHistory.
+func (mr *MockInteractorMockRecorder) GetTaskHistory(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskHistory", reflect.TypeOf((*MockInteractor)(nil).GetTaskHistory), ctx, taskID)
+}
@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+)
+
+type ChangeOutput struct {
+	Before any
+	After  any
+}
+
+type RecordOutput struct {
+	ID        string
+	TaskID    string
+	Actor     string
+	Action    string
+	Changes   map[string]ChangeOutput
+	RequestID string
+	CreatedAt time.Time
+}
+
+func toRecordOutputs(records []*domain.Record) []RecordOutput {
+	outputs := make([]RecordOutput, 0, len(records))
+	for _, record := range records {
+		outputs = append(outputs, toRecordOutput(record))
+	}
+	return outputs
+}
+
+func toRecordOutput(record *domain.Record) RecordOutput {
+	changes := make(map[string]ChangeOutput, len(record.Changes))
+	for field, change := range record.Changes {
+		changes[field] = ChangeOutput{Before: change.Before, After: change.After}
+	}
+	return RecordOutput{
+		ID:        record.ID,
+		TaskID:    record.TaskID,
+		Actor:     record.Actor,
+		Action:    string(record.Action),
+		Changes:   changes,
+		RequestID: record.RequestID,
+		CreatedAt: record.CreatedAt,
+	}
+}
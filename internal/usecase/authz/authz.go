@@ -0,0 +1,78 @@
+//go:generate mockgen -source=authz.go -destination=mocks/mock_authorizer.go -package=mocks
+
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+type Action string
+
+const (
+	ActionTaskRead   Action = "task:read"
+	ActionTaskCreate Action = "task:create"
+	ActionTaskUpdate Action = "task:update"
+	ActionTaskDelete Action = "task:delete"
+
+	ActionProjectRead   Action = "project:read"
+	ActionProjectCreate Action = "project:create"
+	ActionProjectUpdate Action = "project:update"
+	ActionProjectDelete Action = "project:delete"
+
+	ActionLabelRead   Action = "label:read"
+	ActionLabelCreate Action = "label:create"
+	ActionLabelUpdate Action = "label:update"
+	ActionLabelDelete Action = "label:delete"
+
+	ActionCommentCreate Action = "comment:create"
+	ActionCommentUpdate Action = "comment:update"
+	ActionCommentDelete Action = "comment:delete"
+
+	ActionAuditRead Action = "audit:read"
+)
+
+type Resource struct {
+	Type string
+	ID   string
+}
+
+type Principal struct {
+	ID         string
+	Roles      []string
+	Workspaces []string
+}
+
+type Authorizer interface {
+	Can(ctx context.Context, principal Principal, action Action, resource Resource) (bool, error)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the zero Principal when none was attached, which
+// the Authorizer treats as an anonymous caller without roles.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// Check asks authorizer whether the principal on ctx may perform action on
+// resource, returning ErrForbidden when it may not.
+func Check(ctx context.Context, authorizer Authorizer, action Action, resource Resource) error {
+	principal, _ := PrincipalFromContext(ctx)
+	allowed, err := authorizer.Can(ctx, principal, action, resource)
+	if err != nil {
+		return fmt.Errorf("authorize %s: %w", action, err)
+	}
+	if !allowed {
+		return ErrForbidden
+	}
+	return nil
+}
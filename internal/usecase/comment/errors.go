@@ -0,0 +1,21 @@
+package comment
+
+import (
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+var (
+	ErrCommentNotFound   = domain.ErrCommentNotFound
+	ErrInvalidBody       = domain.ErrInvalidBody
+	ErrBodyBlank         = domain.ErrBodyBlank
+	ErrBodyTooLong       = domain.ErrBodyTooLong
+	ErrNotAuthor         = domain.ErrNotAuthor
+	ErrInvalidCursor     = domain.ErrInvalidCursor
+	ErrInvalidPageSize   = domain.ErrInvalidPageSize
+	ErrTaskNotFound      = task.ErrTaskNotFound
+	ErrForbidden         = authz.ErrForbidden
+	ErrWorkspaceRequired = workspace.ErrWorkspaceRequired
+)
@@ -0,0 +1,8 @@
+package comment
+
+// ListCommentsInput selects a page of comments. Limit defaults to 20 and may
+// be at most 100; Cursor is the NextCursor of the previous page.
+type ListCommentsInput struct {
+	Limit  int
+	Cursor string
+}
@@ -0,0 +1,161 @@
+//go:generate mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+
+package comment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+// Interactor manages the comment thread of a task. Reading needs task:read,
+// commenting comment:create, editing comment:update and deleting
+// comment:delete; only the author may edit or delete a comment in addition.
+type Interactor interface {
+	GetComments(ctx context.Context, taskID string, input ListCommentsInput) (CommentPageOutput, error)
+	AddComment(ctx context.Context, taskID string, body string) (CommentOutput, error)
+	EditComment(ctx context.Context, taskID string, id string, body string) (CommentOutput, error)
+	DeleteComment(ctx context.Context, taskID string, id string) error
+}
+
+type interactor struct {
+	repo       domain.Repository
+	tasks      task.Repository
+	authorizer authz.Authorizer
+}
+
+func New(repo domain.Repository, tasks task.Repository, authorizer authz.Authorizer) Interactor {
+	return &interactor{repo: repo, tasks: tasks, authorizer: authorizer}
+}
+
+func (i *interactor) GetComments(ctx context.Context, taskID string, input ListCommentsInput) (CommentPageOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskRead, taskID); err != nil {
+		return CommentPageOutput{}, err
+	}
+	page, err := domain.NewPage(input.Limit, input.Cursor)
+	if err != nil {
+		return CommentPageOutput{}, err
+	}
+	if err := i.findTask(ctx, taskID); err != nil {
+		return CommentPageOutput{}, err
+	}
+	// One extra comment tells whether another page follows.
+	limit := page.Limit
+	page.Limit++
+	comments, err := i.repo.FindByTask(ctx, taskID, page)
+	if err != nil {
+		return CommentPageOutput{}, fmt.Errorf("GetComments: %w", err)
+	}
+	output := CommentPageOutput{}
+	if len(comments) > limit {
+		comments = comments[:limit]
+		last := comments[limit-1]
+		output.NextCursor = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.String()
+	}
+	output.Comments = toCommentOutputs(comments)
+	return output, nil
+}
+
+func (i *interactor) AddComment(ctx context.Context, taskID string, body string) (CommentOutput, error) {
+	if err := i.authorize(ctx, authz.ActionCommentCreate, taskID); err != nil {
+		return CommentOutput{}, err
+	}
+	principal, _ := authz.PrincipalFromContext(ctx)
+	if principal.ID == "" {
+		return CommentOutput{}, ErrForbidden
+	}
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok {
+		return CommentOutput{}, ErrWorkspaceRequired
+	}
+	comment, err := domain.New(uuid.New().String(), taskID, principal.ID, body, time.Now())
+	if err != nil {
+		return CommentOutput{}, err
+	}
+	if err := i.findTask(ctx, taskID); err != nil {
+		return CommentOutput{}, err
+	}
+	comment.WorkspaceID = workspaceID
+	if err := i.repo.Create(ctx, comment); err != nil {
+		return CommentOutput{}, fmt.Errorf("AddComment: %w", err)
+	}
+	return toCommentOutput(comment), nil
+}
+
+func (i *interactor) EditComment(ctx context.Context, taskID string, id string, body string) (CommentOutput, error) {
+	if err := i.authorize(ctx, authz.ActionCommentUpdate, taskID); err != nil {
+		return CommentOutput{}, err
+	}
+	comment, err := i.findComment(ctx, taskID, id)
+	if err != nil {
+		return CommentOutput{}, err
+	}
+	principal, _ := authz.PrincipalFromContext(ctx)
+	if err := comment.Edit(principal.ID, body, time.Now()); err != nil {
+		return CommentOutput{}, err
+	}
+	if err := i.repo.Update(ctx, comment); err != nil {
+		if err == domain.ErrCommentNotFound {
+			return CommentOutput{}, err
+		}
+		return CommentOutput{}, fmt.Errorf("EditComment: %w", err)
+	}
+	return toCommentOutput(comment), nil
+}
+
+func (i *interactor) DeleteComment(ctx context.Context, taskID string, id string) error {
+	if err := i.authorize(ctx, authz.ActionCommentDelete, taskID); err != nil {
+		return err
+	}
+	comment, err := i.findComment(ctx, taskID, id)
+	if err != nil {
+		return err
+	}
+	principal, _ := authz.PrincipalFromContext(ctx)
+	if err := comment.CheckAuthor(principal.ID); err != nil {
+		return err
+	}
+	if err := i.repo.Delete(ctx, id); err != nil {
+		if err == domain.ErrCommentNotFound {
+			return err
+		}
+		return fmt.Errorf("DeleteComment: %w", err)
+	}
+	return nil
+}
+
+func (i *interactor) findTask(ctx context.Context, taskID string) error {
+	if _, err := i.tasks.FindByID(ctx, taskID); err != nil {
+		if err == task.ErrTaskNotFound {
+			return err
+		}
+		return fmt.Errorf("find task: %w", err)
+	}
+	return nil
+}
+
+// findComment loads comment id, reporting comments of other tasks as not
+// found.
+func (i *interactor) findComment(ctx context.Context, taskID string, id string) (*domain.Comment, error) {
+	comment, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrCommentNotFound {
+			return nil, err
+		}
+		return nil, fmt.Errorf("find comment: %w", err)
+	}
+	if comment.TaskID != taskID {
+		return nil, domain.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+func (i *interactor) authorize(ctx context.Context, action authz.Action, taskID string) error {
+	return authz.Check(ctx, i.authorizer, action, authz.Resource{Type: "task", ID: taskID})
+}
@@ -0,0 +1,218 @@
+package comment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/comment/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	taskmocks "github.com/ko44d/go-clean-hexapp/internal/domain/task/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+	authzmocks "github.com/ko44d/go-clean-hexapp/internal/usecase/authz/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/comment"
+)
+
+func TestCommentInteractor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Comment Interactor Suite")
+}
+
+var _ = Describe("Comment Interactor", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockRepo       *mocks.MockRepository
+		mockTasks      *taskmocks.MockRepository
+		mockAuthorizer *authzmocks.MockAuthorizer
+		interactor     comment.Interactor
+		ctx            context.Context
+	)
+
+	allow := func(action authz.Action) {
+		mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), action, gomock.Any()).Return(true, nil).AnyTimes()
+	}
+
+	existingComment := func(id string, minute int) *domain.Comment {
+		return &domain.Comment{
+			ID:        id,
+			TaskID:    "task-1",
+			AuthorID:  "user-1",
+			Body:      "Looks good",
+			CreatedAt: time.Date(2025, 9, 30, 12, minute, 0, 0, time.UTC),
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepository(ctrl)
+		mockTasks = taskmocks.NewMockRepository(ctrl)
+		mockAuthorizer = authzmocks.NewMockAuthorizer(ctrl)
+		interactor = comment.New(mockRepo, mockTasks, mockAuthorizer)
+		ctx = authz.WithPrincipal(context.Background(), authz.Principal{ID: "user-1", Roles: []string{"editor"}})
+		ctx = workspace.WithID(ctx, "workspace-1")
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("GetComments", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskRead)
+		})
+
+		It("should return a cursor when more comments follow", func() {
+			mockTasks.EXPECT().FindByID(ctx, "task-1").Return(&task.Task{ID: "task-1"}, nil)
+			mockRepo.EXPECT().FindByTask(ctx, "task-1", domain.Page{Limit: 3}).Return([]*domain.Comment{
+				existingComment("comment-1", 0), existingComment("comment-2", 1), existingComment("comment-3", 2),
+			}, nil)
+
+			page, err := interactor.GetComments(ctx, "task-1", comment.ListCommentsInput{Limit: 2})
+
+			Expect(err).To(BeNil())
+			Expect(page.Comments).To(HaveLen(2))
+			cursor, err := domain.ParseCursor(page.NextCursor)
+			Expect(err).To(BeNil())
+			Expect(cursor.ID).To(Equal("comment-2"))
+		})
+
+		It("should leave the cursor empty on the last page", func() {
+			mockTasks.EXPECT().FindByID(ctx, "task-1").Return(&task.Task{ID: "task-1"}, nil)
+			mockRepo.EXPECT().FindByTask(ctx, "task-1", gomock.Any()).Return([]*domain.Comment{existingComment("comment-1", 0)}, nil)
+
+			page, err := interactor.GetComments(ctx, "task-1", comment.ListCommentsInput{})
+
+			Expect(err).To(BeNil())
+			Expect(page.Comments).To(HaveLen(1))
+			Expect(page.NextCursor).To(BeEmpty())
+		})
+
+		It("should reject a malformed cursor", func() {
+			_, err := interactor.GetComments(ctx, "task-1", comment.ListCommentsInput{Cursor: "???"})
+
+			Expect(err).To(MatchError(comment.ErrInvalidCursor))
+		})
+
+		It("should return task not found error", func() {
+			mockTasks.EXPECT().FindByID(ctx, "task-1").Return(nil, task.ErrTaskNotFound)
+
+			_, err := interactor.GetComments(ctx, "task-1", comment.ListCommentsInput{})
+
+			Expect(err).To(Equal(comment.ErrTaskNotFound))
+		})
+	})
+
+	Describe("AddComment", func() {
+		BeforeEach(func() {
+			allow(authz.ActionCommentCreate)
+		})
+
+		It("should record the caller as author", func() {
+			mockTasks.EXPECT().FindByID(ctx, "task-1").Return(&task.Task{ID: "task-1"}, nil)
+			mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, c *domain.Comment) error {
+					Expect(c.AuthorID).To(Equal("user-1"))
+					Expect(c.WorkspaceID).To(Equal("workspace-1"))
+					return nil
+				},
+			)
+
+			output, err := interactor.AddComment(ctx, "task-1", "Shipped in #42")
+
+			Expect(err).To(BeNil())
+			Expect(output.Body).To(Equal("Shipped in #42"))
+		})
+
+		It("should refuse anonymous callers", func() {
+			ctx = authz.WithPrincipal(ctx, authz.Principal{Roles: []string{"editor"}})
+
+			_, err := interactor.AddComment(ctx, "task-1", "Hello")
+
+			Expect(err).To(MatchError(comment.ErrForbidden))
+		})
+	})
+
+	Describe("EditComment", func() {
+		BeforeEach(func() {
+			allow(authz.ActionCommentUpdate)
+		})
+
+		It("should let the author edit", func() {
+			mockRepo.EXPECT().FindByID(ctx, "comment-1").Return(existingComment("comment-1", 0), nil)
+			mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+			output, err := interactor.EditComment(ctx, "task-1", "comment-1", "Edited")
+
+			Expect(err).To(BeNil())
+			Expect(output.Body).To(Equal("Edited"))
+			Expect(output.EditedAt).NotTo(BeNil())
+		})
+
+		It("should refuse other users without saving", func() {
+			ctx = authz.WithPrincipal(ctx, authz.Principal{ID: "user-2", Roles: []string{"admin"}})
+			mockRepo.EXPECT().FindByID(ctx, "comment-1").Return(existingComment("comment-1", 0), nil)
+
+			_, err := interactor.EditComment(ctx, "task-1", "comment-1", "Edited")
+
+			Expect(err).To(MatchError(comment.ErrNotAuthor))
+		})
+
+		It("should not find comments of another task", func() {
+			mockRepo.EXPECT().FindByID(ctx, "comment-1").Return(existingComment("comment-1", 0), nil)
+
+			_, err := interactor.EditComment(ctx, "task-2", "comment-1", "Edited")
+
+			Expect(err).To(Equal(comment.ErrCommentNotFound))
+		})
+	})
+
+	Describe("DeleteComment", func() {
+		BeforeEach(func() {
+			allow(authz.ActionCommentDelete)
+		})
+
+		It("should let the author delete", func() {
+			mockRepo.EXPECT().FindByID(ctx, "comment-1").Return(existingComment("comment-1", 0), nil)
+			mockRepo.EXPECT().Delete(ctx, "comment-1").Return(nil)
+
+			Expect(interactor.DeleteComment(ctx, "task-1", "comment-1")).To(Succeed())
+		})
+
+		It("should refuse other users", func() {
+			ctx = authz.WithPrincipal(ctx, authz.Principal{ID: "user-2"})
+			mockRepo.EXPECT().FindByID(ctx, "comment-1").Return(existingComment("comment-1", 0), nil)
+
+			err := interactor.DeleteComment(ctx, "task-1", "comment-1")
+
+			Expect(err).To(MatchError(comment.ErrNotAuthor))
+		})
+	})
+
+	Describe("EditComment and DeleteComment without permission", func() {
+		BeforeEach(func() {
+			ctx = authz.WithPrincipal(ctx, authz.Principal{ID: "user-1", Roles: []string{"viewer"}})
+		})
+
+		It("should refuse the author without comment:update before loading the comment", func() {
+			mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), authz.ActionCommentUpdate, gomock.Any()).Return(false, nil)
+
+			_, err := interactor.EditComment(ctx, "task-1", "comment-1", "Edited")
+
+			Expect(err).To(MatchError(comment.ErrForbidden))
+		})
+
+		It("should refuse the author without comment:delete before loading the comment", func() {
+			mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), authz.ActionCommentDelete, gomock.Any()).Return(false, nil)
+
+			err := interactor.DeleteComment(ctx, "task-1", "comment-1")
+
+			Expect(err).To(MatchError(comment.ErrForbidden))
+		})
+	})
+})
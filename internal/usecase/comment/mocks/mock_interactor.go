@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	comment "github.com/ko44d/go-clean-hexapp/internal/usecase/comment"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractor is a mock of Interactor interface.
+type MockInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractorMockRecorder is the mock recorder for MockInteractor.
+type MockInteractorMockRecorder struct {
+	mock *MockInteractor
+}
+
+// NewMockInteractor creates a new mock instance.
+func NewMockInteractor(ctrl *gomock.Controller) *MockInteractor {
+	mock := &MockInteractor{ctrl: ctrl}
+	mock.recorder = &MockInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractor) EXPECT() *MockInteractorMockRecorder {
+	return m.recorder
+}
+
+// AddComment mocks base method.
+func (m *MockInteractor) AddComment(ctx context.Context, taskID, body string) (comment.CommentOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", ctx, taskID, body)
+	ret0, _ := ret[0].(comment.CommentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockInteractorMockRecorder) AddComment(ctx, taskID, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockInteractor)(nil).AddComment), ctx, taskID, body)
+}
+
+// DeleteComment mocks base method.
+func (m *MockInteractor) DeleteComment(ctx context.Context, taskID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, taskID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockInteractorMockRecorder) DeleteComment(ctx, taskID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockInteractor)(nil).DeleteComment), ctx, taskID, id)
+}
+
+// EditComment mocks base method.
+func (m *MockInteractor) EditComment(ctx context.Context, taskID, id, body string) (comment.CommentOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditComment", ctx, taskID, id, body)
+	ret0, _ := ret[0].(comment.CommentOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditComment indicates an expected call of EditComment.
+func (mr *MockInteractorMockRecorder) EditComment(ctx, taskID, id, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditComment", reflect.TypeOf((*MockInteractor)(nil).EditComment), ctx, taskID, id, body)
+}
+
+// GetComments mocks base method.
+func (m *MockInteractor) GetComments(ctx context.Context, taskID string, input comment.ListCommentsInput) (comment.CommentPageOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, taskID, input)
+	ret0, _ := ret[0].(comment.CommentPageOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockInteractorMockRecorder) GetComments(ctx, taskID, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockInteractor)(nil).GetComments), ctx, taskID, input)
+}
@@ -0,0 +1,48 @@
+package comment
+
+import (
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/comment"
+)
+
+type CommentOutput struct {
+	ID          string
+	WorkspaceID string
+	TaskID      string
+	AuthorID    string
+	Body        string
+	CreatedAt   time.Time
+	EditedAt    *time.Time
+}
+
+// CommentPageOutput is one page of comments. NextCursor is empty on the last
+// page.
+type CommentPageOutput struct {
+	Comments   []CommentOutput
+	NextCursor string
+}
+
+func toCommentOutputs(comments []*domain.Comment) []CommentOutput {
+	outputs := make([]CommentOutput, 0, len(comments))
+	for _, comment := range comments {
+		outputs = append(outputs, toCommentOutput(comment))
+	}
+	return outputs
+}
+
+func toCommentOutput(comment *domain.Comment) CommentOutput {
+	if comment == nil {
+		return CommentOutput{}
+	}
+
+	return CommentOutput{
+		ID:          comment.ID,
+		WorkspaceID: comment.WorkspaceID,
+		TaskID:      comment.TaskID,
+		AuthorID:    comment.AuthorID,
+		Body:        comment.Body,
+		CreatedAt:   comment.CreatedAt,
+		EditedAt:    comment.EditedAt,
+	}
+}
@@ -0,0 +1,21 @@
+package label
+
+import (
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+var (
+	ErrLabelNotFound     = domain.ErrLabelNotFound
+	ErrLabelExists       = domain.ErrLabelExists
+	ErrLabelNotAttached  = domain.ErrLabelNotAttached
+	ErrInvalidName       = domain.ErrInvalidName
+	ErrNameBlank         = domain.ErrNameBlank
+	ErrNameTooLong       = domain.ErrNameTooLong
+	ErrInvalidColor      = domain.ErrInvalidColor
+	ErrTaskNotFound      = task.ErrTaskNotFound
+	ErrForbidden         = authz.ErrForbidden
+	ErrWorkspaceRequired = workspace.ErrWorkspaceRequired
+)
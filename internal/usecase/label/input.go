@@ -0,0 +1,13 @@
+package label
+
+// CreateLabelInput and UpdateLabelInput take a colour as "#rrggbb"; an empty
+// Color selects the default colour.
+type CreateLabelInput struct {
+	Name  string
+	Color string
+}
+
+type UpdateLabelInput struct {
+	Name  string
+	Color string
+}
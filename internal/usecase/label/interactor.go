@@ -0,0 +1,166 @@
+//go:generate mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+
+package label
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+const resourceType = "label"
+
+type Interactor interface {
+	GetLabels(ctx context.Context) ([]LabelOutput, error)
+	GetLabel(ctx context.Context, id string) (LabelOutput, error)
+	CreateLabel(ctx context.Context, input CreateLabelInput) (LabelOutput, error)
+	UpdateLabel(ctx context.Context, id string, input UpdateLabelInput) (LabelOutput, error)
+	DeleteLabel(ctx context.Context, id string) error
+	// AttachLabel and DetachLabel change the labels of a task and are
+	// authorized as task updates.
+	AttachLabel(ctx context.Context, taskID string, labelID string) error
+	DetachLabel(ctx context.Context, taskID string, labelID string) error
+}
+
+type interactor struct {
+	repo       domain.Repository
+	tasks      task.Repository
+	authorizer authz.Authorizer
+}
+
+func New(repo domain.Repository, tasks task.Repository, authorizer authz.Authorizer) Interactor {
+	return &interactor{repo: repo, tasks: tasks, authorizer: authorizer}
+}
+
+func (i *interactor) GetLabels(ctx context.Context) ([]LabelOutput, error) {
+	if err := i.authorize(ctx, authz.ActionLabelRead, ""); err != nil {
+		return nil, err
+	}
+	labels, err := i.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetLabels: %w", err)
+	}
+	return toLabelOutputs(labels), nil
+}
+
+func (i *interactor) GetLabel(ctx context.Context, id string) (LabelOutput, error) {
+	if err := i.authorize(ctx, authz.ActionLabelRead, id); err != nil {
+		return LabelOutput{}, err
+	}
+	label, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrLabelNotFound {
+			return LabelOutput{}, err
+		}
+		return LabelOutput{}, fmt.Errorf("GetLabel: %w", err)
+	}
+	return toLabelOutput(label), nil
+}
+
+func (i *interactor) CreateLabel(ctx context.Context, input CreateLabelInput) (LabelOutput, error) {
+	if err := i.authorize(ctx, authz.ActionLabelCreate, ""); err != nil {
+		return LabelOutput{}, err
+	}
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok {
+		return LabelOutput{}, ErrWorkspaceRequired
+	}
+	now := time.Now()
+	label, err := domain.New(uuid.New().String(), input.Name, input.Color, now, now)
+	if err != nil {
+		return LabelOutput{}, err
+	}
+	label.WorkspaceID = workspaceID
+	if err := i.repo.Create(ctx, label); err != nil {
+		if err == domain.ErrLabelExists {
+			return LabelOutput{}, err
+		}
+		return LabelOutput{}, fmt.Errorf("CreateLabel: %w", err)
+	}
+	return toLabelOutput(label), nil
+}
+
+func (i *interactor) UpdateLabel(ctx context.Context, id string, input UpdateLabelInput) (LabelOutput, error) {
+	if err := i.authorize(ctx, authz.ActionLabelUpdate, id); err != nil {
+		return LabelOutput{}, err
+	}
+	label, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrLabelNotFound {
+			return LabelOutput{}, err
+		}
+		return LabelOutput{}, fmt.Errorf("UpdateLabel: %w", err)
+	}
+	if err := label.Edit(input.Name, input.Color, time.Now()); err != nil {
+		return LabelOutput{}, err
+	}
+	if err := i.repo.Update(ctx, label); err != nil {
+		if err == domain.ErrLabelNotFound || err == domain.ErrLabelExists {
+			return LabelOutput{}, err
+		}
+		return LabelOutput{}, fmt.Errorf("UpdateLabel: %w", err)
+	}
+	return toLabelOutput(label), nil
+}
+
+func (i *interactor) DeleteLabel(ctx context.Context, id string) error {
+	if err := i.authorize(ctx, authz.ActionLabelDelete, id); err != nil {
+		return err
+	}
+	if err := i.repo.Delete(ctx, id); err != nil {
+		if err == domain.ErrLabelNotFound {
+			return err
+		}
+		return fmt.Errorf("DeleteLabel: %w", err)
+	}
+	return nil
+}
+
+func (i *interactor) AttachLabel(ctx context.Context, taskID string, labelID string) error {
+	if err := i.authorizeTask(ctx, taskID); err != nil {
+		return err
+	}
+	if _, err := i.tasks.FindByID(ctx, taskID); err != nil {
+		if err == task.ErrTaskNotFound {
+			return err
+		}
+		return fmt.Errorf("AttachLabel: %w", err)
+	}
+	if _, err := i.repo.FindByID(ctx, labelID); err != nil {
+		if err == domain.ErrLabelNotFound {
+			return err
+		}
+		return fmt.Errorf("AttachLabel: %w", err)
+	}
+	if err := i.repo.Attach(ctx, taskID, labelID); err != nil {
+		return fmt.Errorf("AttachLabel: %w", err)
+	}
+	return nil
+}
+
+func (i *interactor) DetachLabel(ctx context.Context, taskID string, labelID string) error {
+	if err := i.authorizeTask(ctx, taskID); err != nil {
+		return err
+	}
+	if err := i.repo.Detach(ctx, taskID, labelID); err != nil {
+		if err == domain.ErrLabelNotAttached {
+			return err
+		}
+		return fmt.Errorf("DetachLabel: %w", err)
+	}
+	return nil
+}
+
+func (i *interactor) authorize(ctx context.Context, action authz.Action, id string) error {
+	return authz.Check(ctx, i.authorizer, action, authz.Resource{Type: resourceType, ID: id})
+}
+
+func (i *interactor) authorizeTask(ctx context.Context, taskID string) error {
+	return authz.Check(ctx, i.authorizer, authz.ActionTaskUpdate, authz.Resource{Type: "task", ID: taskID})
+}
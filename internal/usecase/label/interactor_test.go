@@ -0,0 +1,207 @@
+package label_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/label"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/label/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	taskmocks "github.com/ko44d/go-clean-hexapp/internal/domain/task/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+	authzmocks "github.com/ko44d/go-clean-hexapp/internal/usecase/authz/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/label"
+)
+
+func TestLabelInteractor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Label Interactor Suite")
+}
+
+var _ = Describe("Label Interactor", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockRepo       *mocks.MockRepository
+		mockTasks      *taskmocks.MockRepository
+		mockAuthorizer *authzmocks.MockAuthorizer
+		interactor     label.Interactor
+		ctx            context.Context
+	)
+
+	allow := func(action authz.Action) {
+		mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), action, gomock.Any()).Return(true, nil).AnyTimes()
+	}
+
+	existingLabel := func() *domain.Label {
+		return &domain.Label{
+			ID:          "label-1",
+			WorkspaceID: "workspace-1",
+			Name:        "bug",
+			Color:       "#d73a4a",
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepository(ctrl)
+		mockTasks = taskmocks.NewMockRepository(ctrl)
+		mockAuthorizer = authzmocks.NewMockAuthorizer(ctrl)
+		interactor = label.New(mockRepo, mockTasks, mockAuthorizer)
+		ctx = authz.WithPrincipal(context.Background(), authz.Principal{ID: "user-1", Roles: []string{"editor"}})
+		ctx = workspace.WithID(ctx, "workspace-1")
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("GetLabels", func() {
+		BeforeEach(func() {
+			allow(authz.ActionLabelRead)
+		})
+
+		It("should return all labels", func() {
+			mockRepo.EXPECT().FindAll(ctx).Return([]*domain.Label{existingLabel()}, nil)
+
+			labels, err := interactor.GetLabels(ctx)
+
+			Expect(err).To(BeNil())
+			Expect(labels).To(HaveLen(1))
+			Expect(labels[0].Name).To(Equal("bug"))
+		})
+
+		It("should return repository errors", func() {
+			expectedError := errors.New("database error")
+			mockRepo.EXPECT().FindAll(ctx).Return(nil, expectedError)
+
+			_, err := interactor.GetLabels(ctx)
+
+			Expect(err).To(MatchError(expectedError))
+		})
+	})
+
+	Describe("CreateLabel", func() {
+		BeforeEach(func() {
+			allow(authz.ActionLabelCreate)
+		})
+
+		It("should create the label in the current workspace", func() {
+			mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, l *domain.Label) error {
+					Expect(l.WorkspaceID).To(Equal("workspace-1"))
+					Expect(l.Color).To(Equal("#0969da"))
+					return nil
+				},
+			)
+
+			output, err := interactor.CreateLabel(ctx, label.CreateLabelInput{Name: "backend", Color: "#0969DA"})
+
+			Expect(err).To(BeNil())
+			Expect(output.Name).To(Equal("backend"))
+		})
+
+		It("should reject a duplicate name", func() {
+			mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(domain.ErrLabelExists)
+
+			_, err := interactor.CreateLabel(ctx, label.CreateLabelInput{Name: "bug"})
+
+			Expect(err).To(Equal(label.ErrLabelExists))
+		})
+
+		It("should reject an invalid colour without saving", func() {
+			_, err := interactor.CreateLabel(ctx, label.CreateLabelInput{Name: "bug", Color: "red"})
+
+			Expect(err).To(MatchError(label.ErrInvalidColor))
+		})
+	})
+
+	Describe("UpdateLabel", func() {
+		BeforeEach(func() {
+			allow(authz.ActionLabelUpdate)
+		})
+
+		It("should rename the label", func() {
+			mockRepo.EXPECT().FindByID(ctx, "label-1").Return(existingLabel(), nil)
+			mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+			output, err := interactor.UpdateLabel(ctx, "label-1", label.UpdateLabelInput{Name: "defect", Color: "#d73a4a"})
+
+			Expect(err).To(BeNil())
+			Expect(output.Name).To(Equal("defect"))
+		})
+
+		It("should return label not found error", func() {
+			mockRepo.EXPECT().FindByID(ctx, "label-1").Return(nil, domain.ErrLabelNotFound)
+
+			_, err := interactor.UpdateLabel(ctx, "label-1", label.UpdateLabelInput{Name: "defect"})
+
+			Expect(err).To(Equal(label.ErrLabelNotFound))
+		})
+	})
+
+	Describe("DeleteLabel", func() {
+		It("should require the delete permission", func() {
+			mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), authz.ActionLabelDelete, gomock.Any()).Return(false, nil)
+
+			err := interactor.DeleteLabel(ctx, "label-1")
+
+			Expect(err).To(MatchError(label.ErrForbidden))
+		})
+	})
+
+	Describe("AttachLabel", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+		})
+
+		It("should attach an existing label to an existing task", func() {
+			mockTasks.EXPECT().FindByID(ctx, "task-1").Return(&task.Task{ID: "task-1"}, nil)
+			mockRepo.EXPECT().FindByID(ctx, "label-1").Return(existingLabel(), nil)
+			mockRepo.EXPECT().Attach(ctx, "task-1", "label-1").Return(nil)
+
+			err := interactor.AttachLabel(ctx, "task-1", "label-1")
+
+			Expect(err).To(BeNil())
+		})
+
+		It("should return task not found error", func() {
+			mockTasks.EXPECT().FindByID(ctx, "task-1").Return(nil, task.ErrTaskNotFound)
+
+			err := interactor.AttachLabel(ctx, "task-1", "label-1")
+
+			Expect(err).To(Equal(label.ErrTaskNotFound))
+		})
+
+		It("should return label not found error", func() {
+			mockTasks.EXPECT().FindByID(ctx, "task-1").Return(&task.Task{ID: "task-1"}, nil)
+			mockRepo.EXPECT().FindByID(ctx, "label-1").Return(nil, domain.ErrLabelNotFound)
+
+			err := interactor.AttachLabel(ctx, "task-1", "label-1")
+
+			Expect(err).To(Equal(label.ErrLabelNotFound))
+		})
+	})
+
+	Describe("DetachLabel", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+		})
+
+		It("should report a label that is not attached", func() {
+			mockRepo.EXPECT().Detach(ctx, "task-1", "label-1").Return(domain.ErrLabelNotAttached)
+
+			err := interactor.DetachLabel(ctx, "task-1", "label-1")
+
+			Expect(err).To(Equal(label.ErrLabelNotAttached))
+		})
+	})
+})
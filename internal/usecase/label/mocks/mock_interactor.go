@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	label "github.com/ko44d/go-clean-hexapp/internal/usecase/label"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractor is a mock of Interactor interface.
+type MockInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractorMockRecorder is the mock recorder for MockInteractor.
+type MockInteractorMockRecorder struct {
+	mock *MockInteractor
+}
+
+// NewMockInteractor creates a new mock instance.
+func NewMockInteractor(ctrl *gomock.Controller) *MockInteractor {
+	mock := &MockInteractor{ctrl: ctrl}
+	mock.recorder = &MockInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractor) EXPECT() *MockInteractorMockRecorder {
+	return m.recorder
+}
+
+// AttachLabel mocks base method.
+func (m *MockInteractor) AttachLabel(ctx context.Context, taskID, labelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachLabel", ctx, taskID, labelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachLabel indicates an expected call of AttachLabel.
+func (mr *MockInteractorMockRecorder) AttachLabel(ctx, taskID, labelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachLabel", reflect.TypeOf((*MockInteractor)(nil).AttachLabel), ctx, taskID, labelID)
+}
+
+// CreateLabel mocks base method.
+func (m *MockInteractor) CreateLabel(ctx context.Context, input label.CreateLabelInput) (label.LabelOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabel", ctx, input)
+	ret0, _ := ret[0].(label.LabelOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabel indicates an expected call of CreateLabel.
+func (mr *MockInteractorMockRecorder) CreateLabel(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabel", reflect.TypeOf((*MockInteractor)(nil).CreateLabel), ctx, input)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockInteractor) DeleteLabel(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockInteractorMockRecorder) DeleteLabel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockInteractor)(nil).DeleteLabel), ctx, id)
+}
+
+// DetachLabel mocks base method.
+func (m *MockInteractor) DetachLabel(ctx context.Context, taskID, labelID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachLabel", ctx, taskID, labelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachLabel indicates an expected call of DetachLabel.
+func (mr *MockInteractorMockRecorder) DetachLabel(ctx, taskID, labelID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachLabel", reflect.TypeOf((*MockInteractor)(nil).DetachLabel), ctx, taskID, labelID)
+}
+
+// GetLabel mocks base method.
+func (m *MockInteractor) GetLabel(ctx context.Context, id string) (label.LabelOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabel", ctx, id)
+	ret0, _ := ret[0].(label.LabelOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabel indicates an expected call of GetLabel.
+func (mr *MockInteractorMockRecorder) GetLabel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabel", reflect.TypeOf((*MockInteractor)(nil).GetLabel), ctx, id)
+}
+
+// GetLabels mocks base method.
+func (m *MockInteractor) GetLabels(ctx context.Context) ([]label.LabelOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabels", ctx)
+	ret0, _ := ret[0].([]label.LabelOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabels indicates an expected call of GetLabels.
+func (mr *MockInteractorMockRecorder) GetLabels(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabels", reflect.TypeOf((*MockInteractor)(nil).GetLabels), ctx)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockInteractor) UpdateLabel(ctx context.Context, id string, input label.UpdateLabelInput) (label.LabelOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", ctx, id, input)
+	ret0, _ := ret[0].(label.LabelOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockInteractorMockRecorder) UpdateLabel(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockInteractor)(nil).UpdateLabel), ctx, id, input)
+}
@@ -0,0 +1,39 @@
+package label
+
+import (
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/label"
+)
+
+type LabelOutput struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Color       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func toLabelOutputs(labels []*domain.Label) []LabelOutput {
+	outputs := make([]LabelOutput, 0, len(labels))
+	for _, label := range labels {
+		outputs = append(outputs, toLabelOutput(label))
+	}
+	return outputs
+}
+
+func toLabelOutput(label *domain.Label) LabelOutput {
+	if label == nil {
+		return LabelOutput{}
+	}
+
+	return LabelOutput{
+		ID:          label.ID,
+		WorkspaceID: label.WorkspaceID,
+		Name:        label.Name,
+		Color:       label.Color,
+		CreatedAt:   label.CreatedAt,
+		UpdatedAt:   label.UpdatedAt,
+	}
+}
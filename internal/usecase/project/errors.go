@@ -0,0 +1,17 @@
+package project
+
+import (
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+var (
+	ErrProjectNotFound    = domain.ErrProjectNotFound
+	ErrInvalidName        = domain.ErrInvalidName
+	ErrNameBlank          = domain.ErrNameBlank
+	ErrNameTooLong        = domain.ErrNameTooLong
+	ErrDescriptionTooLong = domain.ErrDescriptionTooLong
+	ErrForbidden          = authz.ErrForbidden
+	ErrWorkspaceRequired  = workspace.ErrWorkspaceRequired
+)
@@ -0,0 +1,12 @@
+package project
+
+type CreateProjectInput struct {
+	Name        string
+	Description string
+}
+
+type UpdateProjectInput struct {
+	Name        string
+	Description string
+	Archived    bool
+}
@@ -0,0 +1,124 @@
+//go:generate mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
+
+const resourceType = "project"
+
+type Interactor interface {
+	GetProjects(ctx context.Context) ([]ProjectOutput, error)
+	GetProject(ctx context.Context, id string) (ProjectOutput, error)
+	CreateProject(ctx context.Context, input CreateProjectInput) (ProjectOutput, error)
+	UpdateProject(ctx context.Context, id string, input UpdateProjectInput) (ProjectOutput, error)
+	DeleteProject(ctx context.Context, id string) error
+}
+
+type interactor struct {
+	repo       domain.Repository
+	authorizer authz.Authorizer
+}
+
+func New(repo domain.Repository, authorizer authz.Authorizer) Interactor {
+	return &interactor{repo: repo, authorizer: authorizer}
+}
+
+func (i *interactor) GetProjects(ctx context.Context) ([]ProjectOutput, error) {
+	if err := i.authorize(ctx, authz.ActionProjectRead, ""); err != nil {
+		return nil, err
+	}
+	projects, err := i.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetProjects: %w", err)
+	}
+	return toProjectOutputs(projects), nil
+}
+
+func (i *interactor) GetProject(ctx context.Context, id string) (ProjectOutput, error) {
+	if err := i.authorize(ctx, authz.ActionProjectRead, id); err != nil {
+		return ProjectOutput{}, err
+	}
+	project, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrProjectNotFound {
+			return ProjectOutput{}, err
+		}
+		return ProjectOutput{}, fmt.Errorf("GetProject: %w", err)
+	}
+	return toProjectOutput(project), nil
+}
+
+func (i *interactor) CreateProject(ctx context.Context, input CreateProjectInput) (ProjectOutput, error) {
+	if err := i.authorize(ctx, authz.ActionProjectCreate, ""); err != nil {
+		return ProjectOutput{}, err
+	}
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok {
+		return ProjectOutput{}, ErrWorkspaceRequired
+	}
+	now := time.Now()
+	project, err := domain.New(uuid.New().String(), input.Name, input.Description, now, now)
+	if err != nil {
+		return ProjectOutput{}, err
+	}
+	project.WorkspaceID = workspaceID
+	if err := i.repo.Create(ctx, project); err != nil {
+		return ProjectOutput{}, fmt.Errorf("CreateProject: %w", err)
+	}
+	return toProjectOutput(project), nil
+}
+
+func (i *interactor) UpdateProject(ctx context.Context, id string, input UpdateProjectInput) (ProjectOutput, error) {
+	if err := i.authorize(ctx, authz.ActionProjectUpdate, id); err != nil {
+		return ProjectOutput{}, err
+	}
+	project, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrProjectNotFound {
+			return ProjectOutput{}, err
+		}
+		return ProjectOutput{}, fmt.Errorf("UpdateProject: %w", err)
+	}
+	now := time.Now()
+	if err := project.Edit(input.Name, input.Description, now); err != nil {
+		return ProjectOutput{}, err
+	}
+	if input.Archived {
+		project.Archive(now)
+	} else {
+		project.Unarchive(now)
+	}
+	if err := i.repo.Update(ctx, project); err != nil {
+		if err == domain.ErrProjectNotFound {
+			return ProjectOutput{}, err
+		}
+		return ProjectOutput{}, fmt.Errorf("UpdateProject: %w", err)
+	}
+	return toProjectOutput(project), nil
+}
+
+func (i *interactor) DeleteProject(ctx context.Context, id string) error {
+	if err := i.authorize(ctx, authz.ActionProjectDelete, id); err != nil {
+		return err
+	}
+	if err := i.repo.Delete(ctx, id); err != nil {
+		if err == domain.ErrProjectNotFound {
+			return err
+		}
+		return fmt.Errorf("DeleteProject: %w", err)
+	}
+	return nil
+}
+
+func (i *interactor) authorize(ctx context.Context, action authz.Action, id string) error {
+	return authz.Check(ctx, i.authorizer, action, authz.Resource{Type: resourceType, ID: id})
+}
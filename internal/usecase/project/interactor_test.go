@@ -0,0 +1,192 @@
+package project_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+	authzmocks "github.com/ko44d/go-clean-hexapp/internal/usecase/authz/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/project"
+)
+
+func TestProjectInteractor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Project Interactor Suite")
+}
+
+var _ = Describe("Project Interactor", func() {
+	var (
+		ctrl           *gomock.Controller
+		mockRepo       *mocks.MockRepository
+		mockAuthorizer *authzmocks.MockAuthorizer
+		interactor     project.Interactor
+		ctx            context.Context
+	)
+
+	allow := func(action authz.Action) {
+		mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), action, gomock.Any()).Return(true, nil).AnyTimes()
+	}
+
+	existingProject := func() *domain.Project {
+		return &domain.Project{
+			ID:          "project-1",
+			WorkspaceID: "workspace-1",
+			Name:        "Ops",
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockRepo = mocks.NewMockRepository(ctrl)
+		mockAuthorizer = authzmocks.NewMockAuthorizer(ctrl)
+		interactor = project.New(mockRepo, mockAuthorizer)
+		ctx = authz.WithPrincipal(context.Background(), authz.Principal{ID: "user-1", Roles: []string{"editor"}})
+		ctx = workspace.WithID(ctx, "workspace-1")
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("GetProjects", func() {
+		BeforeEach(func() {
+			allow(authz.ActionProjectRead)
+		})
+
+		It("should return all projects", func() {
+			mockRepo.EXPECT().FindAll(ctx).Return([]*domain.Project{existingProject()}, nil)
+
+			projects, err := interactor.GetProjects(ctx)
+
+			Expect(err).To(BeNil())
+			Expect(projects).To(HaveLen(1))
+			Expect(projects[0].Name).To(Equal("Ops"))
+		})
+
+		It("should return repository errors", func() {
+			expectedError := errors.New("database error")
+			mockRepo.EXPECT().FindAll(ctx).Return(nil, expectedError)
+
+			_, err := interactor.GetProjects(ctx)
+
+			Expect(err).To(MatchError(expectedError))
+		})
+	})
+
+	Describe("GetProject", func() {
+		BeforeEach(func() {
+			allow(authz.ActionProjectRead)
+		})
+
+		It("should return project not found error", func() {
+			mockRepo.EXPECT().FindByID(ctx, "project-1").Return(nil, domain.ErrProjectNotFound)
+
+			_, err := interactor.GetProject(ctx, "project-1")
+
+			Expect(err).To(Equal(project.ErrProjectNotFound))
+		})
+	})
+
+	Describe("CreateProject", func() {
+		BeforeEach(func() {
+			allow(authz.ActionProjectCreate)
+		})
+
+		Context("when input is valid", func() {
+			It("should create the project in the current workspace", func() {
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, p *domain.Project) error {
+						Expect(p.ID).NotTo(BeEmpty())
+						Expect(p.WorkspaceID).To(Equal("workspace-1"))
+						Expect(p.Name).To(Equal("Ops"))
+						Expect(p.Archived).To(BeFalse())
+						return nil
+					},
+				)
+
+				output, err := interactor.CreateProject(ctx, project.CreateProjectInput{Name: "Ops", Description: "Runbooks"})
+
+				Expect(err).To(BeNil())
+				Expect(output.Name).To(Equal("Ops"))
+				Expect(output.Description).To(Equal("Runbooks"))
+			})
+		})
+
+		Context("when name is blank", func() {
+			It("should return validation error", func() {
+				_, err := interactor.CreateProject(ctx, project.CreateProjectInput{Name: "  "})
+
+				Expect(err).To(Equal(project.ErrNameBlank))
+			})
+		})
+	})
+
+	Describe("UpdateProject", func() {
+		BeforeEach(func() {
+			allow(authz.ActionProjectUpdate)
+		})
+
+		It("should archive the project", func() {
+			mockRepo.EXPECT().FindByID(ctx, "project-1").Return(existingProject(), nil)
+			mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, p *domain.Project) error {
+					Expect(p.Name).To(Equal("Operations"))
+					Expect(p.Archived).To(BeTrue())
+					return nil
+				},
+			)
+
+			output, err := interactor.UpdateProject(ctx, "project-1", project.UpdateProjectInput{Name: "Operations", Archived: true})
+
+			Expect(err).To(BeNil())
+			Expect(output.Archived).To(BeTrue())
+		})
+
+		It("should return project not found error", func() {
+			mockRepo.EXPECT().FindByID(ctx, "project-1").Return(nil, domain.ErrProjectNotFound)
+
+			_, err := interactor.UpdateProject(ctx, "project-1", project.UpdateProjectInput{Name: "Ops"})
+
+			Expect(err).To(Equal(project.ErrProjectNotFound))
+		})
+	})
+
+	Describe("DeleteProject", func() {
+		BeforeEach(func() {
+			allow(authz.ActionProjectDelete)
+		})
+
+		It("should delete the project", func() {
+			mockRepo.EXPECT().Delete(ctx, "project-1").Return(nil)
+
+			Expect(interactor.DeleteProject(ctx, "project-1")).To(Succeed())
+		})
+
+		It("should return project not found error", func() {
+			mockRepo.EXPECT().Delete(ctx, "project-1").Return(domain.ErrProjectNotFound)
+
+			Expect(interactor.DeleteProject(ctx, "project-1")).To(Equal(project.ErrProjectNotFound))
+		})
+	})
+
+	Describe("Authorization", func() {
+		It("should not delete a project when denied", func() {
+			mockAuthorizer.EXPECT().
+				Can(gomock.Any(), gomock.Any(), authz.ActionProjectDelete, authz.Resource{Type: "project", ID: "project-1"}).
+				Return(false, nil)
+
+			Expect(interactor.DeleteProject(ctx, "project-1")).To(Equal(project.ErrForbidden))
+		})
+	})
+})
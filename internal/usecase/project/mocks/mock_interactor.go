@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interactor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interactor.go -destination=mocks/mock_interactor.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	project "github.com/ko44d/go-clean-hexapp/internal/usecase/project"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractor is a mock of Interactor interface.
+type MockInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractorMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractorMockRecorder is the mock recorder for MockInteractor.
+type MockInteractorMockRecorder struct {
+	mock *MockInteractor
+}
+
+// NewMockInteractor creates a new mock instance.
+func NewMockInteractor(ctrl *gomock.Controller) *MockInteractor {
+	mock := &MockInteractor{ctrl: ctrl}
+	mock.recorder = &MockInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractor) EXPECT() *MockInteractorMockRecorder {
+	return m.recorder
+}
+
+// CreateProject mocks base method.
+func (m *MockInteractor) CreateProject(ctx context.Context, input project.CreateProjectInput) (project.ProjectOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", ctx, input)
+	ret0, _ := ret[0].(project.ProjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockInteractorMockRecorder) CreateProject(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockInteractor)(nil).CreateProject), ctx, input)
+}
+
+// DeleteProject mocks base method.
+func (m *MockInteractor) DeleteProject(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockInteractorMockRecorder) DeleteProject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockInteractor)(nil).DeleteProject), ctx, id)
+}
+
+// GetProject mocks base method.
+func (m *MockInteractor) GetProject(ctx context.Context, id string) (project.ProjectOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", ctx, id)
+	ret0, _ := ret[0].(project.ProjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockInteractorMockRecorder) GetProject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockInteractor)(nil).GetProject), ctx, id)
+}
+
+// GetProjects mocks base method.
+func (m *MockInteractor) GetProjects(ctx context.Context) ([]project.ProjectOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", ctx)
+	ret0, _ := ret[0].([]project.ProjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockInteractorMockRecorder) GetProjects(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockInteractor)(nil).GetProjects), ctx)
+}
+
+// UpdateProject mocks base method.
+func (m *MockInteractor) UpdateProject(ctx context.Context, id string, input project.UpdateProjectInput) (project.ProjectOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, id, input)
+	ret0, _ := ret[0].(project.ProjectOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockInteractorMockRecorder) UpdateProject(ctx, id, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockInteractor)(nil).UpdateProject), ctx, id, input)
+}
@@ -0,0 +1,41 @@
+package project
+
+import (
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/project"
+)
+
+type ProjectOutput struct {
+	ID          string
+	WorkspaceID string
+	Name        string
+	Description string
+	Archived    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func toProjectOutputs(projects []*domain.Project) []ProjectOutput {
+	outputs := make([]ProjectOutput, 0, len(projects))
+	for _, project := range projects {
+		outputs = append(outputs, toProjectOutput(project))
+	}
+	return outputs
+}
+
+func toProjectOutput(project *domain.Project) ProjectOutput {
+	if project == nil {
+		return ProjectOutput{}
+	}
+
+	return ProjectOutput{
+		ID:          project.ID,
+		WorkspaceID: project.WorkspaceID,
+		Name:        project.Name,
+		Description: project.Description,
+		Archived:    project.Archived,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
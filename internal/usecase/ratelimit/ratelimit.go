@@ -0,0 +1,84 @@
+//go:generate mockgen -source=ratelimit.go -destination=mocks/mock_ratelimit.go -package=mocks
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit allows Burst requests at once and refills them at Burst per Period,
+// as a token bucket.
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// IsZero reports whether l leaves requests unlimited.
+func (l Limit) IsZero() bool {
+	return l.Burst <= 0 || l.Period <= 0
+}
+
+// rate is the number of tokens refilled per second.
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Period.Seconds()
+}
+
+// Bucket is the state of one client's token bucket. The zero Bucket is full.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Take refills b for the time passed since it was last updated and takes a
+// token from it if there is one.
+func (l Limit) Take(b *Bucket, now time.Time) Decision {
+	tokens := float64(l.Burst)
+	if !b.UpdatedAt.IsZero() {
+		elapsed := max(now.Sub(b.UpdatedAt).Seconds(), 0)
+		tokens = min(float64(l.Burst), b.Tokens+elapsed*l.rate())
+	}
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	b.Tokens, b.UpdatedAt = tokens, now
+	return l.Decide(allowed, tokens)
+}
+
+// Decide describes the bucket left with tokens after a request was allowed
+// or not. Stores that refill buckets themselves use it to report the result.
+func (l Limit) Decide(allowed bool, tokens float64) Decision {
+	decision := Decision{
+		Allowed:   allowed,
+		Limit:     l,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     l.duration(float64(l.Burst) - tokens),
+	}
+	if !allowed {
+		decision.RetryAfter = l.duration(1 - tokens)
+	}
+	return decision
+}
+
+// duration is how long refilling tokens takes.
+func (l Limit) duration(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(max(tokens, 0) / l.rate() * float64(time.Second)))
+}
+
+// Decision is the outcome of taking a token.
+type Decision struct {
+	Allowed   bool
+	Limit     Limit
+	Remaining int
+	// Reset is how long until the bucket is full again, RetryAfter how long
+	// until the next token when the request was not allowed.
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets, keyed by client and limit.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
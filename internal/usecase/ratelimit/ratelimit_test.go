@@ -0,0 +1,76 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rate Limit Suite")
+}
+
+var _ = Describe("Limit", func() {
+	var (
+		limit  ratelimit.Limit
+		bucket ratelimit.Bucket
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		limit = ratelimit.Limit{Burst: 3, Period: 3 * time.Second}
+		bucket = ratelimit.Bucket{}
+		now = time.Date(2025, 10, 31, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("allows a burst from a fresh bucket", func() {
+		for remaining := 2; remaining >= 0; remaining-- {
+			decision := limit.Take(&bucket, now)
+
+			Expect(decision.Allowed).To(BeTrue())
+			Expect(decision.Remaining).To(Equal(remaining))
+		}
+	})
+
+	It("refuses once the bucket is empty and says when to retry", func() {
+		for range 3 {
+			limit.Take(&bucket, now)
+		}
+
+		decision := limit.Take(&bucket, now.Add(500*time.Millisecond))
+
+		Expect(decision.Allowed).To(BeFalse())
+		Expect(decision.Remaining).To(BeZero())
+		Expect(decision.RetryAfter).To(Equal(500 * time.Millisecond))
+		Expect(decision.Reset).To(Equal(2500 * time.Millisecond))
+	})
+
+	It("refills at the rate of the limit", func() {
+		for range 3 {
+			limit.Take(&bucket, now)
+		}
+
+		Expect(limit.Take(&bucket, now.Add(time.Second)).Allowed).To(BeTrue())
+		Expect(limit.Take(&bucket, now.Add(time.Second)).Allowed).To(BeFalse())
+	})
+
+	It("never holds more than the burst", func() {
+		limit.Take(&bucket, now)
+
+		decision := limit.Take(&bucket, now.Add(time.Hour))
+
+		Expect(decision.Remaining).To(Equal(2))
+		Expect(decision.Reset).To(Equal(time.Second))
+	})
+
+	It("is unlimited without a burst or period", func() {
+		Expect(ratelimit.Limit{}.IsZero()).To(BeTrue())
+		Expect(ratelimit.Limit{Burst: 1}.IsZero()).To(BeTrue())
+		Expect(limit.IsZero()).To(BeFalse())
+	})
+})
@@ -0,0 +1,59 @@
+//go:generate mockgen -source=reminder.go -destination=mocks/mock_reminder.go -package=mocks
+package reminder
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+)
+
+// Kind tells recipients why a reminder was sent.
+type Kind string
+
+const (
+	KindDueSoon Kind = "due_soon"
+	KindOverdue Kind = "overdue"
+)
+
+// Reminder is a single notification about a task. A task is reminded at most
+// once per kind and due date, so rescheduling a task makes it eligible again.
+type Reminder struct {
+	Kind        Kind
+	TaskID      string
+	WorkspaceID string
+	Title       string
+	DueAt       time.Time
+}
+
+func newReminder(kind Kind, t *domain.Task) Reminder {
+	return Reminder{
+		Kind:        kind,
+		TaskID:      t.ID,
+		WorkspaceID: t.WorkspaceID,
+		Title:       t.Title,
+		DueAt:       *t.DueAt,
+	}
+}
+
+// Notifier delivers reminders to people, e.g. by mail or webhook.
+type Notifier interface {
+	Notify(ctx context.Context, r Reminder) error
+}
+
+// Store records which reminders were sent and lists the workspaces that may
+// need reminding. Workspaces are listed across tenants, so implementations
+// must not rely on a workspace in ctx for that call.
+type Store interface {
+	DueWorkspaces(ctx context.Context, before time.Time) ([]string, error)
+	// Claim records r as sent and reports false if it was recorded before.
+	Claim(ctx context.Context, r Reminder) (bool, error)
+	// Release forgets a claim whose delivery failed so it is retried.
+	Release(ctx context.Context, r Reminder) error
+}
+
+// Locker elects a single scheduler among replicas. TryLock reports false
+// when another replica holds the lock; unlock must be called otherwise.
+type Locker interface {
+	TryLock(ctx context.Context) (unlock func(), acquired bool, err error)
+}
@@ -0,0 +1,116 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+)
+
+// Scheduler periodically reminds about open tasks that are overdue or due
+// within a window.
+type Scheduler struct {
+	tasks    domain.Repository
+	store    Store
+	notifier Notifier
+	locker   Locker
+	window   time.Duration
+	now      func() time.Time
+}
+
+func NewScheduler(tasks domain.Repository, store Store, notifier Notifier, locker Locker, window time.Duration) *Scheduler {
+	return &Scheduler{
+		tasks:    tasks,
+		store:    store,
+		notifier: notifier,
+		locker:   locker,
+		window:   window,
+		now:      time.Now,
+	}
+}
+
+// Run calls Tick every interval until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Tick(ctx, s.now()); err != nil {
+				log.Printf("reminder: %v", err)
+			}
+		}
+	}
+}
+
+// Tick sends the reminders that are due at now. It does nothing when another
+// replica holds the scheduler lock. Failures for single tasks do not stop the
+// remaining reminders and are returned together.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) error {
+	unlock, acquired, err := s.locker.TryLock(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire scheduler lock: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer unlock()
+
+	workspaces, err := s.store.DueWorkspaces(ctx, now.Add(s.window))
+	if err != nil {
+		return fmt.Errorf("list workspaces: %w", err)
+	}
+
+	var errs []error
+	for _, workspaceID := range workspaces {
+		if err := s.remindWorkspace(workspace.WithID(ctx, workspaceID), now); err != nil {
+			errs = append(errs, fmt.Errorf("workspace %s: %w", workspaceID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) remindWorkspace(ctx context.Context, now time.Time) error {
+	overdue, err := s.tasks.FindAll(ctx, domain.ListFilter{OverdueAt: now})
+	if err != nil {
+		return err
+	}
+	dueSoon, err := s.tasks.FindDueWithin(ctx, now, s.window)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, t := range overdue {
+		errs = append(errs, s.send(ctx, newReminder(KindOverdue, t)))
+	}
+	for _, t := range dueSoon {
+		errs = append(errs, s.send(ctx, newReminder(KindDueSoon, t)))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) send(ctx context.Context, r Reminder) error {
+	claimed, err := s.store.Claim(ctx, r)
+	if err != nil {
+		return fmt.Errorf("claim %s reminder for task %s: %w", r.Kind, r.TaskID, err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := s.notifier.Notify(ctx, r); err != nil {
+		if releaseErr := s.store.Release(ctx, r); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+		return fmt.Errorf("notify %s reminder for task %s: %w", r.Kind, r.TaskID, err)
+	}
+	return nil
+}
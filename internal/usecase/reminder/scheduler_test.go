@@ -0,0 +1,150 @@
+package reminder_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	taskmocks "github.com/ko44d/go-clean-hexapp/internal/domain/task/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/reminder/mocks"
+)
+
+func TestReminderScheduler(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reminder Scheduler Suite")
+}
+
+const workspaceID = "11111111-1111-1111-1111-111111111111"
+
+var _ = Describe("Scheduler", func() {
+	var (
+		ctrl         *gomock.Controller
+		mockTasks    *taskmocks.MockRepository
+		mockStore    *mocks.MockStore
+		mockNotifier *mocks.MockNotifier
+		mockLocker   *mocks.MockLocker
+		scheduler    *reminder.Scheduler
+		ctx          context.Context
+		now          time.Time
+		window       time.Duration
+		unlocked     bool
+	)
+
+	newTask := func(id string, dueAt time.Time) *domain.Task {
+		return &domain.Task{
+			ID:          id,
+			WorkspaceID: workspaceID,
+			Title:       "Task " + id,
+			Status:      domain.StatusTodo,
+			DueAt:       &dueAt,
+		}
+	}
+
+	inWorkspace := gomock.Cond(func(ctx context.Context) bool {
+		id, ok := workspace.IDFromContext(ctx)
+		return ok && id == workspaceID
+	})
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockTasks = taskmocks.NewMockRepository(ctrl)
+		mockStore = mocks.NewMockStore(ctrl)
+		mockNotifier = mocks.NewMockNotifier(ctrl)
+		mockLocker = mocks.NewMockLocker(ctrl)
+		window = 24 * time.Hour
+		scheduler = reminder.NewScheduler(mockTasks, mockStore, mockNotifier, mockLocker, window)
+		ctx = context.Background()
+		now = time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+		unlocked = false
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	holdLock := func() {
+		mockLocker.EXPECT().TryLock(ctx).Return(func() { unlocked = true }, true, nil)
+	}
+
+	Context("when another replica holds the lock", func() {
+		It("should do nothing", func() {
+			mockLocker.EXPECT().TryLock(ctx).Return(nil, false, nil)
+
+			Expect(scheduler.Tick(ctx, now)).To(Succeed())
+		})
+	})
+
+	Context("when the lock cannot be queried", func() {
+		It("should return the error", func() {
+			mockLocker.EXPECT().TryLock(ctx).Return(nil, false, errors.New("connection refused"))
+
+			Expect(scheduler.Tick(ctx, now)).To(MatchError(ContainSubstring("acquire scheduler lock")))
+		})
+	})
+
+	Context("when tasks are due", func() {
+		It("should remind overdue and upcoming tasks within their workspace", func() {
+			overdue := newTask("task-1", now.Add(-time.Hour))
+			dueSoon := newTask("task-2", now.Add(time.Hour))
+
+			holdLock()
+			mockStore.EXPECT().DueWorkspaces(ctx, now.Add(window)).Return([]string{workspaceID}, nil)
+			mockTasks.EXPECT().FindAll(inWorkspace, domain.ListFilter{OverdueAt: now}).Return([]*domain.Task{overdue}, nil)
+			mockTasks.EXPECT().FindDueWithin(inWorkspace, now, window).Return([]*domain.Task{dueSoon}, nil)
+
+			overdueReminder := reminder.Reminder{
+				Kind: reminder.KindOverdue, TaskID: "task-1", WorkspaceID: workspaceID, Title: "Task task-1", DueAt: *overdue.DueAt,
+			}
+			dueSoonReminder := reminder.Reminder{
+				Kind: reminder.KindDueSoon, TaskID: "task-2", WorkspaceID: workspaceID, Title: "Task task-2", DueAt: *dueSoon.DueAt,
+			}
+			mockStore.EXPECT().Claim(inWorkspace, overdueReminder).Return(true, nil)
+			mockNotifier.EXPECT().Notify(inWorkspace, overdueReminder).Return(nil)
+			mockStore.EXPECT().Claim(inWorkspace, dueSoonReminder).Return(true, nil)
+			mockNotifier.EXPECT().Notify(inWorkspace, dueSoonReminder).Return(nil)
+
+			Expect(scheduler.Tick(ctx, now)).To(Succeed())
+			Expect(unlocked).To(BeTrue())
+		})
+
+		It("should not send reminders that were already sent", func() {
+			holdLock()
+			mockStore.EXPECT().DueWorkspaces(ctx, gomock.Any()).Return([]string{workspaceID}, nil)
+			mockTasks.EXPECT().FindAll(inWorkspace, gomock.Any()).Return([]*domain.Task{newTask("task-1", now.Add(-time.Hour))}, nil)
+			mockTasks.EXPECT().FindDueWithin(inWorkspace, now, window).Return([]*domain.Task{}, nil)
+			mockStore.EXPECT().Claim(inWorkspace, gomock.Any()).Return(false, nil)
+
+			Expect(scheduler.Tick(ctx, now)).To(Succeed())
+		})
+
+		It("should release the claim and continue when delivery fails", func() {
+			holdLock()
+			mockStore.EXPECT().DueWorkspaces(ctx, gomock.Any()).Return([]string{workspaceID}, nil)
+			mockTasks.EXPECT().FindAll(inWorkspace, gomock.Any()).Return([]*domain.Task{
+				newTask("task-1", now.Add(-2*time.Hour)),
+				newTask("task-2", now.Add(-time.Hour)),
+			}, nil)
+			mockTasks.EXPECT().FindDueWithin(inWorkspace, now, window).Return([]*domain.Task{}, nil)
+
+			failing := gomock.Cond(func(r reminder.Reminder) bool { return r.TaskID == "task-1" })
+			succeeding := gomock.Cond(func(r reminder.Reminder) bool { return r.TaskID == "task-2" })
+			mockStore.EXPECT().Claim(inWorkspace, gomock.Any()).Return(true, nil).Times(2)
+			mockNotifier.EXPECT().Notify(inWorkspace, failing).Return(errors.New("smtp down"))
+			mockStore.EXPECT().Release(inWorkspace, failing).Return(nil)
+			mockNotifier.EXPECT().Notify(inWorkspace, succeeding).Return(nil)
+
+			err := scheduler.Tick(ctx, now)
+
+			Expect(err).To(MatchError(ContainSubstring("smtp down")))
+			Expect(unlocked).To(BeTrue())
+		})
+	})
+})
@@ -1,9 +1,41 @@
 package task
 
-import domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+import (
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+)
 
 var (
-	ErrTaskNotFound = domain.ErrTaskNotFound
-	ErrInvalidTitle = domain.ErrInvalidTitle
-	ErrTitleBlank   = domain.ErrTitleBlank
+	ErrTaskNotFound             = domain.ErrTaskNotFound
+	ErrVersionConflict          = domain.ErrVersionConflict
+	ErrInvalidTitle             = domain.ErrInvalidTitle
+	ErrTitleBlank               = domain.ErrTitleBlank
+	ErrTitleTooLong             = domain.ErrTitleTooLong
+	ErrInvalidStatus            = domain.ErrInvalidStatus
+	ErrInvalidTransition        = domain.ErrInvalidTransition
+	ErrInvalidPriority          = domain.ErrInvalidPriority
+	ErrDueBeforeCreation        = domain.ErrDueBeforeCreation
+	ErrInvalidSort              = domain.ErrInvalidSort
+	ErrInvalidLabelMatch        = domain.ErrInvalidLabelMatch
+	ErrInvalidRecurrence        = domain.ErrInvalidRecurrence
+	ErrRecurrenceWithoutDueDate = domain.ErrRecurrenceWithoutDueDate
+	ErrParentNotFound           = domain.ErrParentNotFound
+	ErrHierarchyCycle           = domain.ErrHierarchyCycle
+	ErrHierarchyTooDeep         = domain.ErrHierarchyTooDeep
+	ErrOpenSubtasks             = domain.ErrOpenSubtasks
+	ErrParentDeleted            = domain.ErrParentDeleted
+	ErrBlockerNotFound          = domain.ErrBlockerNotFound
+	ErrDependencyNotFound       = domain.ErrDependencyNotFound
+	ErrDependencyCycle          = domain.ErrDependencyCycle
+	ErrOpenBlockers             = domain.ErrOpenBlockers
+	ErrInvalidAssignee          = domain.ErrInvalidAssignee
+	ErrAssigneeNotFound         = domain.ErrAssigneeNotFound
+	ErrTooManyAssignees         = domain.ErrTooManyAssignees
+	ErrTaskFinished             = domain.ErrTaskFinished
+	ErrForbidden                = authz.ErrForbidden
+	ErrWorkspaceRequired        = workspace.ErrWorkspaceRequired
+	ErrProjectNotFound          = project.ErrProjectNotFound
+	ErrProjectArchived          = project.ErrProjectArchived
 )
@@ -0,0 +1,42 @@
+package task
+
+import "time"
+
+type AddTaskInput struct {
+	Title     string
+	ProjectID string
+	// ParentID makes the new task a subtask.
+	ParentID string
+	// Priority defaults to medium when empty.
+	Priority string
+	DueAt    *time.Time
+	// Recurrence is an RRULE such as "FREQ=WEEKLY;BYDAY=MO". Empty means the
+	// task does not repeat.
+	Recurrence string
+}
+
+// UpdateTaskInput replaces the editable fields of a task. A nil DueAt clears
+// the due date; an empty Priority resets it to medium, an empty Recurrence
+// stops the task from repeating and an empty ParentID makes it top-level.
+type UpdateTaskInput struct {
+	Title      string
+	ParentID   string
+	Priority   string
+	DueAt      *time.Time
+	Recurrence string
+}
+
+type TaskFilter struct {
+	ProjectID string
+	Priority  string
+	Overdue   bool
+	// Assignee keeps tasks assigned to this user ID; "me" stands for the
+	// caller.
+	Assignee string
+	// Labels keeps tasks carrying these label names; LabelMatch is "any"
+	// (default) or "all".
+	Labels     []string
+	LabelMatch string
+	// Sort is "created_at" (default) or "due_at".
+	Sort string
+}
@@ -5,37 +5,136 @@ package task
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
 	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/transaction"
 )
 
+const resourceType = "task"
+
+// AssigneeMe is the TaskFilter.Assignee value that selects the caller's tasks.
+const AssigneeMe = "me"
+
 type Interactor interface {
-	GetTasks(ctx context.Context) ([]TaskOutput, error)
-	AddTask(ctx context.Context, title string) error
-	CompleteTask(ctx context.Context, id string) error
+	GetTasks(ctx context.Context, filter TaskFilter) ([]TaskOutput, error)
+	GetTask(ctx context.Context, id string) (TaskOutput, error)
+	GetSubtasks(ctx context.Context, id string) ([]TaskTreeOutput, error)
+	AddTask(ctx context.Context, input AddTaskInput) error
+	UpdateTask(ctx context.Context, id string, input UpdateTaskInput) (TaskOutput, error)
+	// CompleteTask completes a task. Open subtasks prevent this unless
+	// cascade is set, which completes them as well.
+	CompleteTask(ctx context.Context, id string, cascade bool) error
+	TransitionTask(ctx context.Context, id string, status string) (TaskOutput, error)
+	AssignTask(ctx context.Context, id string, userID string) (TaskOutput, error)
+	UnassignTask(ctx context.Context, id string, userID string) error
+	// DeleteTask moves a task and its subtasks to the trash.
+	DeleteTask(ctx context.Context, id string) error
+	// GetTrash lists the deleted tasks that have not been purged yet.
+	GetTrash(ctx context.Context) ([]TaskOutput, error)
+	// RestoreTask takes a task and the subtasks deleted with it out of the
+	// trash.
+	RestoreTask(ctx context.Context, id string) (TaskOutput, error)
+	// AddDependency records that taskID is blocked by blockerID.
+	AddDependency(ctx context.Context, taskID string, blockerID string) error
+	RemoveDependency(ctx context.Context, taskID string, blockerID string) error
+	GetDependencyGraph(ctx context.Context, id string) (DependencyGraphOutput, error)
 }
 
+// interactor writes an audit record for every change it makes to a task, in
+// the same transaction as the change itself.
 type interactor struct {
-	repo domain.Repository
+	repo       domain.Repository
+	projects   project.Repository
+	deps       domain.DependencyRepository
+	audits     audit.Repository
+	tx         transaction.Transactor
+	authorizer authz.Authorizer
 }
 
-func New(repo domain.Repository) Interactor {
-	return &interactor{repo: repo}
+func New(repo domain.Repository, projects project.Repository, deps domain.DependencyRepository,
+	audits audit.Repository, tx transaction.Transactor, authorizer authz.Authorizer) Interactor {
+	return &interactor{repo: repo, projects: projects, deps: deps, audits: audits, tx: tx, authorizer: authorizer}
 }
 
-func (i *interactor) GetTasks(ctx context.Context) ([]TaskOutput, error) {
-	tasks, err := i.repo.FindAll(ctx)
+func (i *interactor) GetTasks(ctx context.Context, filter TaskFilter) ([]TaskOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskRead, ""); err != nil {
+		return nil, err
+	}
+	if filter.ProjectID != "" {
+		if _, err := i.projects.FindByID(ctx, filter.ProjectID); err != nil {
+			if err == project.ErrProjectNotFound {
+				return nil, err
+			}
+			return nil, fmt.Errorf("GetTasks: %w", err)
+		}
+	}
+	if filter.Assignee == AssigneeMe {
+		principal, _ := authz.PrincipalFromContext(ctx)
+		if principal.ID == "" {
+			return nil, ErrForbidden
+		}
+		filter.Assignee = principal.ID
+	}
+	listFilter, err := toListFilter(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := i.repo.FindAll(ctx, listFilter)
 	if err != nil {
 		return nil, fmt.Errorf("GetTasks: %w", err)
 	}
 	return toTaskOutputs(tasks), nil
 }
 
-func (i *interactor) AddTask(ctx context.Context, title string) error {
+func (i *interactor) GetTask(ctx context.Context, id string) (TaskOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskRead, id); err != nil {
+		return TaskOutput{}, err
+	}
+	task, err := i.repo.FindByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrTaskNotFound {
+			return TaskOutput{}, err
+		}
+		return TaskOutput{}, fmt.Errorf("GetTask: %w", err)
+	}
+	return toTaskOutput(task), nil
+}
+
+func (i *interactor) GetSubtasks(ctx context.Context, id string) ([]TaskTreeOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskRead, id); err != nil {
+		return nil, err
+	}
+	tasks, err := i.repo.FindTree(ctx, id)
+	if err != nil {
+		if err == domain.ErrTaskNotFound {
+			return nil, err
+		}
+		return nil, fmt.Errorf("GetSubtasks: %w", err)
+	}
+	tree, err := domain.BuildTree(id, tasks)
+	if err != nil {
+		return nil, err
+	}
+	return toTaskTreeOutputs(tree.Subtasks), nil
+}
+
+func (i *interactor) AddTask(ctx context.Context, input AddTaskInput) error {
+	if err := i.authorize(ctx, authz.ActionTaskCreate, ""); err != nil {
+		return err
+	}
+	workspaceID, ok := workspace.IDFromContext(ctx)
+	if !ok {
+		return ErrWorkspaceRequired
+	}
 	now := time.Now()
-	task, err := domain.New(uuid.New().String(), title, now, now)
+	task, err := domain.New(uuid.New().String(), input.Title, now, now)
 	if err != nil {
 		switch err {
 		case domain.ErrInvalidTitle, domain.ErrTitleBlank, domain.ErrTitleTooLong:
@@ -44,23 +143,589 @@ func (i *interactor) AddTask(ctx context.Context, title string) error {
 			return fmt.Errorf("AddTask: %w", err)
 		}
 	}
-	if err := i.repo.Create(ctx, task); err != nil {
-		return fmt.Errorf("AddTask: %w", err)
+	task.WorkspaceID = workspaceID
+	if err := applySchedule(task, input.Priority, input.DueAt, input.Recurrence, now); err != nil {
+		return err
 	}
-	return nil
+	return i.transact(ctx, "AddTask", func(ctx context.Context) error {
+		if input.ParentID != "" {
+			lineage, err := i.lineage(ctx, input.ParentID)
+			if err != nil {
+				if err == domain.ErrParentNotFound {
+					return err
+				}
+				return fmt.Errorf("AddTask: %w", err)
+			}
+			if err := task.SetParent(lineage, 0, now); err != nil {
+				return err
+			}
+		}
+		if input.ProjectID != "" {
+			p, err := i.projects.FindByID(ctx, input.ProjectID)
+			if err != nil {
+				if err == project.ErrProjectNotFound {
+					return err
+				}
+				return fmt.Errorf("AddTask: %w", err)
+			}
+			if err := p.AddTask(task); err != nil {
+				return err
+			}
+		}
+		if err := i.repo.Create(ctx, task); err != nil {
+			return fmt.Errorf("AddTask: %w", err)
+		}
+		if err := i.record(ctx, audit.ActionCreate, nil, task); err != nil {
+			return fmt.Errorf("AddTask: %w", err)
+		}
+		return nil
+	})
 }
 
-func (i *interactor) CompleteTask(ctx context.Context, id string) error {
-	task, err := i.repo.FindByID(ctx, id)
+func (i *interactor) UpdateTask(ctx context.Context, id string, input UpdateTaskInput) (TaskOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskUpdate, id); err != nil {
+		return TaskOutput{}, err
+	}
+	var output TaskOutput
+	err := i.transact(ctx, "UpdateTask", func(ctx context.Context) error {
+		task, err := i.repo.FindByID(ctx, id)
+		if err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("UpdateTask: %w", err)
+		}
+		before := task.Clone()
+		now := time.Now()
+		if err := task.Rename(input.Title, now); err != nil {
+			return err
+		}
+		if err := applySchedule(task, input.Priority, input.DueAt, input.Recurrence, now); err != nil {
+			return err
+		}
+		if err := i.moveTask(ctx, task, input.ParentID, now); err != nil {
+			switch err {
+			case domain.ErrParentNotFound, domain.ErrHierarchyCycle, domain.ErrHierarchyTooDeep:
+				return err
+			default:
+				return fmt.Errorf("UpdateTask: %w", err)
+			}
+		}
+		if err := i.repo.Update(ctx, task); err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("UpdateTask: %w", err)
+		}
+		if err := i.record(ctx, audit.ActionUpdate, before, task); err != nil {
+			return fmt.Errorf("UpdateTask: %w", err)
+		}
+		output = toTaskOutput(task)
+		return nil
+	})
+	return output, err
+}
+
+func (i *interactor) CompleteTask(ctx context.Context, id string, cascade bool) error {
+	if err := i.authorize(ctx, authz.ActionTaskUpdate, id); err != nil {
+		return err
+	}
+	return i.transact(ctx, "CompleteTask", func(ctx context.Context) error {
+		_, err := i.complete(ctx, "CompleteTask", id, cascade)
+		return err
+	})
+}
+
+func (i *interactor) TransitionTask(ctx context.Context, id string, status string) (TaskOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskUpdate, id); err != nil {
+		return TaskOutput{}, err
+	}
+	target, err := domain.ParseStatus(status)
 	if err != nil {
-		if err == domain.ErrTaskNotFound {
+		return TaskOutput{}, err
+	}
+	var output TaskOutput
+	err = i.transact(ctx, "TransitionTask", func(ctx context.Context) error {
+		if target == domain.StatusComplete {
+			task, err := i.complete(ctx, "TransitionTask", id, false)
+			if err != nil {
+				return err
+			}
+			output = toTaskOutput(task)
+			return nil
+		}
+		task, err := i.repo.FindByID(ctx, id)
+		if err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("TransitionTask: %w", err)
+		}
+		if task.Status == target {
+			output = toTaskOutput(task)
+			return nil
+		}
+		before := task.Clone()
+		if err := task.TransitionTo(domain.DefaultWorkflow, target, time.Now()); err != nil {
 			return err
 		}
-		return fmt.Errorf("CompleteTask: %w", err)
+		if err := i.repo.Update(ctx, task); err != nil {
+			return fmt.Errorf("TransitionTask: %w", err)
+		}
+		if err := i.record(ctx, audit.ActionUpdate, before, task); err != nil {
+			return fmt.Errorf("TransitionTask: %w", err)
+		}
+		output = toTaskOutput(task)
+		return nil
+	})
+	return output, err
+}
+
+func (i *interactor) AssignTask(ctx context.Context, id string, userID string) (TaskOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskUpdate, id); err != nil {
+		return TaskOutput{}, err
+	}
+	var output TaskOutput
+	err := i.transact(ctx, "AssignTask", func(ctx context.Context) error {
+		task, err := i.repo.FindByID(ctx, id)
+		if err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("AssignTask: %w", err)
+		}
+		before := task.Clone()
+		if err := task.Assign(userID); err != nil {
+			return err
+		}
+		if err := i.repo.AddAssignee(ctx, id, userID); err != nil {
+			return fmt.Errorf("AssignTask: %w", err)
+		}
+		if err := i.record(ctx, audit.ActionUpdate, before, task); err != nil {
+			return fmt.Errorf("AssignTask: %w", err)
+		}
+		output = toTaskOutput(task)
+		return nil
+	})
+	return output, err
+}
+
+func (i *interactor) UnassignTask(ctx context.Context, id string, userID string) error {
+	if err := i.authorize(ctx, authz.ActionTaskUpdate, id); err != nil {
+		return err
+	}
+	return i.transact(ctx, "UnassignTask", func(ctx context.Context) error {
+		task, err := i.repo.FindByID(ctx, id)
+		if err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("UnassignTask: %w", err)
+		}
+		before := task.Clone()
+		if err := task.Unassign(userID); err != nil {
+			return err
+		}
+		if err := i.repo.RemoveAssignee(ctx, id, userID); err != nil {
+			if err == domain.ErrAssigneeNotFound {
+				return err
+			}
+			return fmt.Errorf("UnassignTask: %w", err)
+		}
+		if err := i.record(ctx, audit.ActionUpdate, before, task); err != nil {
+			return fmt.Errorf("UnassignTask: %w", err)
+		}
+		return nil
+	})
+}
+
+func (i *interactor) DeleteTask(ctx context.Context, id string) error {
+	if err := i.authorize(ctx, authz.ActionTaskDelete, id); err != nil {
+		return err
+	}
+	return i.transact(ctx, "DeleteTask", func(ctx context.Context) error {
+		tasks, err := i.repo.FindTree(ctx, id)
+		if err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("DeleteTask: %w", err)
+		}
+		if err := i.repo.Delete(ctx, id, time.Now()); err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("DeleteTask: %w", err)
+		}
+		// The subtasks go with their parent, so each gets a record of its own.
+		for _, task := range tasks {
+			if err := i.record(ctx, audit.ActionDelete, task, nil); err != nil {
+				return fmt.Errorf("DeleteTask: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (i *interactor) GetTrash(ctx context.Context) ([]TaskOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskRead, ""); err != nil {
+		return nil, err
 	}
-	task.Complete(time.Now())
-	if err := i.repo.Update(ctx, task); err != nil {
-		return fmt.Errorf("CompleteTask: %w", err)
+	tasks, err := i.repo.FindDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetTrash: %w", err)
+	}
+	return toTaskOutputs(tasks), nil
+}
+
+func (i *interactor) RestoreTask(ctx context.Context, id string) (TaskOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskDelete, id); err != nil {
+		return TaskOutput{}, err
+	}
+	var output TaskOutput
+	err := i.transact(ctx, "RestoreTask", func(ctx context.Context) error {
+		if err := i.repo.Restore(ctx, id); err != nil {
+			switch err {
+			case domain.ErrTaskNotFound, domain.ErrParentDeleted:
+				return err
+			default:
+				return fmt.Errorf("RestoreTask: %w", err)
+			}
+		}
+		tasks, err := i.repo.FindTree(ctx, id)
+		if err != nil {
+			return fmt.Errorf("RestoreTask: %w", err)
+		}
+		for _, task := range tasks {
+			if err := i.record(ctx, audit.ActionRestore, nil, task); err != nil {
+				return fmt.Errorf("RestoreTask: %w", err)
+			}
+		}
+		output = toTaskOutput(tasks[0])
+		return nil
+	})
+	return output, err
+}
+
+func (i *interactor) AddDependency(ctx context.Context, taskID string, blockerID string) error {
+	if err := i.authorize(ctx, authz.ActionTaskUpdate, taskID); err != nil {
+		return err
+	}
+	return i.transact(ctx, "AddDependency", func(ctx context.Context) error {
+		if err := i.deps.Lock(ctx); err != nil {
+			return fmt.Errorf("AddDependency: %w", err)
+		}
+		if _, err := i.repo.FindByID(ctx, taskID); err != nil {
+			if err == domain.ErrTaskNotFound {
+				return err
+			}
+			return fmt.Errorf("AddDependency: %w", err)
+		}
+		if _, err := i.repo.FindByID(ctx, blockerID); err != nil {
+			if err == domain.ErrTaskNotFound {
+				return domain.ErrBlockerNotFound
+			}
+			return fmt.Errorf("AddDependency: %w", err)
+		}
+		edges, err := i.deps.FindGraph(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("AddDependency: %w", err)
+		}
+		dependency := domain.Dependency{TaskID: taskID, BlockerID: blockerID}
+		if slices.Contains(edges, dependency) {
+			return nil
+		}
+		if err := domain.NewDependencyGraph(edges).Add(dependency); err != nil {
+			return err
+		}
+		if err := i.deps.Add(ctx, dependency); err != nil {
+			return fmt.Errorf("AddDependency: %w", err)
+		}
+		if err := i.recordDependency(ctx, dependency, true); err != nil {
+			return fmt.Errorf("AddDependency: %w", err)
+		}
+		return nil
+	})
+}
+
+func (i *interactor) RemoveDependency(ctx context.Context, taskID string, blockerID string) error {
+	if err := i.authorize(ctx, authz.ActionTaskUpdate, taskID); err != nil {
+		return err
+	}
+	return i.transact(ctx, "RemoveDependency", func(ctx context.Context) error {
+		if err := i.deps.Lock(ctx); err != nil {
+			return fmt.Errorf("RemoveDependency: %w", err)
+		}
+		dependency := domain.Dependency{TaskID: taskID, BlockerID: blockerID}
+		if err := i.deps.Remove(ctx, dependency); err != nil {
+			if err == domain.ErrDependencyNotFound {
+				return err
+			}
+			return fmt.Errorf("RemoveDependency: %w", err)
+		}
+		if err := i.recordDependency(ctx, dependency, false); err != nil {
+			return fmt.Errorf("RemoveDependency: %w", err)
+		}
+		return nil
+	})
+}
+
+func (i *interactor) GetDependencyGraph(ctx context.Context, id string) (DependencyGraphOutput, error) {
+	if err := i.authorize(ctx, authz.ActionTaskRead, id); err != nil {
+		return DependencyGraphOutput{}, err
+	}
+	edges, err := i.deps.FindGraph(ctx, id)
+	if err != nil {
+		return DependencyGraphOutput{}, fmt.Errorf("GetDependencyGraph: %w", err)
+	}
+	graph := domain.NewDependencyGraph(edges)
+	tasks, err := i.repo.FindAll(ctx, domain.ListFilter{IDs: graph.TaskIDs(id)})
+	if err != nil {
+		return DependencyGraphOutput{}, fmt.Errorf("GetDependencyGraph: %w", err)
+	}
+	live := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		live[task.ID] = true
+	}
+	if !live[id] {
+		return DependencyGraphOutput{}, domain.ErrTaskNotFound
+	}
+
+	output := DependencyGraphOutput{
+		Tasks: toTaskOutputs(tasks),
+		Edges: make([]DependencyOutput, 0, len(edges)),
+	}
+	// Edges of deleted tasks stay in the graph so that cycles are still
+	// detected once they are restored, but are not shown.
+	for _, edge := range graph.Edges() {
+		if live[edge.TaskID] && live[edge.BlockerID] {
+			output.Edges = append(output.Edges, DependencyOutput{TaskID: edge.TaskID, BlockerID: edge.BlockerID})
+		}
+	}
+	return output, nil
+}
+
+// complete completes the task id, and its open subtasks when cascade is set,
+// then spawns follow-ups for recurring tasks among them. Domain errors are
+// returned as is; other errors are wrapped with op.
+func (i *interactor) complete(ctx context.Context, op string, id string, cascade bool) (*domain.Task, error) {
+	tasks, err := i.repo.FindTree(ctx, id)
+	if err != nil {
+		if err == domain.ErrTaskNotFound {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	tree, err := domain.BuildTree(id, tasks)
+	if err != nil {
+		return nil, err
+	}
+	before := make(map[string]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		before[task.ID] = task.Clone()
+	}
+	now := time.Now()
+	changed, err := tree.Complete(cascade, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return tree.Task, nil
+	}
+	ids := make([]string, 0, len(changed))
+	for _, task := range changed {
+		ids = append(ids, task.ID)
+	}
+	openBlockers, err := i.deps.FindOpenBlockers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := domain.CheckBlockers(changed, openBlockers); err != nil {
+		return nil, err
+	}
+	for _, task := range changed {
+		if err := i.repo.Update(ctx, task); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := i.record(ctx, audit.ActionComplete, before[task.ID], task); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := i.spawnNextOccurrence(ctx, task, now); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return tree.Task, nil
+}
+
+// moveTask places task below parentID, or at the top level when parentID is
+// empty. Only a changed parent is validated, so unrelated edits of a task in
+// an over-deep tree keep working.
+func (i *interactor) moveTask(ctx context.Context, task *domain.Task, parentID string, now time.Time) error {
+	current := ""
+	if task.ParentID != nil {
+		current = *task.ParentID
+	}
+	if parentID == current {
+		return nil
+	}
+	if parentID == "" {
+		return task.SetParent(nil, 0, now)
+	}
+	lineage, err := i.lineage(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	subtree, err := i.repo.FindTree(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	tree, err := domain.BuildTree(task.ID, subtree)
+	if err != nil {
+		return err
+	}
+	return task.SetParent(lineage, tree.Height(), now)
+}
+
+// lineage loads the task parentID followed by its ancestors, nearest first.
+// It stops once the chain is longer than domain.MaxDepth, which SetParent
+// rejects anyway.
+func (i *interactor) lineage(ctx context.Context, parentID string) ([]*domain.Task, error) {
+	var lineage []*domain.Task
+	for id := parentID; len(lineage) <= domain.MaxDepth; {
+		task, err := i.repo.FindByID(ctx, id)
+		if err != nil {
+			if err == domain.ErrTaskNotFound && len(lineage) == 0 {
+				return nil, domain.ErrParentNotFound
+			}
+			return nil, err
+		}
+		lineage = append(lineage, task)
+		if task.ParentID == nil {
+			break
+		}
+		id = *task.ParentID
+	}
+	return lineage, nil
+}
+
+// spawnNextOccurrence creates the follow-up of a completed recurring task,
+// unless the series already went past it: a task that was reopened and
+// completed again has spawned its follow-up before.
+func (i *interactor) spawnNextOccurrence(ctx context.Context, task *domain.Task, now time.Time) error {
+	next := task.NextOccurrence(uuid.New().String(), now)
+	if next == nil {
+		return nil
+	}
+	later, err := i.repo.FindAll(ctx, domain.ListFilter{SeriesID: *next.SeriesID, MinOccurrence: task.Occurrence + 1})
+	if err != nil {
+		return fmt.Errorf("find later occurrences: %w", err)
+	}
+	if len(later) > 0 {
+		return nil
+	}
+	if err := i.repo.Create(ctx, next); err != nil {
+		return fmt.Errorf("create next occurrence: %w", err)
+	}
+	return i.record(ctx, audit.ActionCreate, nil, next)
+}
+
+// transact runs fn in a transaction. Errors from fn are returned as is; a
+// failure to begin or commit the transaction is wrapped with op.
+func (i *interactor) transact(ctx context.Context, op string, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := i.tx.Within(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// record appends an audit record of action on a task on behalf of the caller.
+// Updates that change none of the audited fields are not recorded.
+func (i *interactor) record(ctx context.Context, action audit.Action, before, after *domain.Task) error {
+	principal, _ := authz.PrincipalFromContext(ctx)
+	requestID, _ := audit.RequestIDFromContext(ctx)
+	record := audit.NewTaskRecord(uuid.New().String(), action, principal.ID, requestID, before, after, time.Now())
+	if action == audit.ActionUpdate && len(record.Changes) == 0 {
+		return nil
+	}
+	if err := i.audits.Append(ctx, record); err != nil {
+		return fmt.Errorf("record %s of task %q: %w", action, record.TaskID, err)
 	}
 	return nil
 }
+
+// recordDependency appends an audit record of adding d, or removing it, on
+// behalf of the caller.
+func (i *interactor) recordDependency(ctx context.Context, d domain.Dependency, added bool) error {
+	principal, _ := authz.PrincipalFromContext(ctx)
+	requestID, _ := audit.RequestIDFromContext(ctx)
+	workspaceID, _ := workspace.IDFromContext(ctx)
+	record := audit.NewDependencyRecord(uuid.New().String(), workspaceID, principal.ID, requestID, d, added, time.Now())
+	if err := i.audits.Append(ctx, record); err != nil {
+		return fmt.Errorf("record dependency of task %q: %w", d.TaskID, err)
+	}
+	return nil
+}
+
+func (i *interactor) authorize(ctx context.Context, action authz.Action, id string) error {
+	return authz.Check(ctx, i.authorizer, action, authz.Resource{Type: resourceType, ID: id})
+}
+
+func applySchedule(task *domain.Task, priority string, dueAt *time.Time, rule string, now time.Time) error {
+	if priority == "" {
+		priority = string(domain.PriorityMedium)
+	}
+	if err := task.SetPriority(domain.Priority(priority), now); err != nil {
+		return err
+	}
+	if err := task.SetDueAt(dueAt, now); err != nil {
+		return err
+	}
+	var recurrence *domain.Recurrence
+	if rule != "" {
+		var err error
+		if recurrence, err = domain.ParseRecurrence(rule); err != nil {
+			return err
+		}
+	}
+	return task.SetRecurrence(recurrence, now)
+}
+
+func toListFilter(filter TaskFilter, now time.Time) (domain.ListFilter, error) {
+	listFilter := domain.ListFilter{ProjectID: filter.ProjectID, Assignee: filter.Assignee}
+	if filter.Priority != "" {
+		priority, err := domain.ParsePriority(filter.Priority)
+		if err != nil {
+			return domain.ListFilter{}, err
+		}
+		listFilter.Priority = priority
+	}
+	if filter.Sort != "" {
+		sortBy, err := domain.ParseSortOrder(filter.Sort)
+		if err != nil {
+			return domain.ListFilter{}, err
+		}
+		listFilter.SortBy = sortBy
+	}
+	if filter.Overdue {
+		listFilter.OverdueAt = now
+	}
+	if len(filter.Labels) > 0 {
+		listFilter.Labels = slices.Compact(slices.Sorted(slices.Values(filter.Labels)))
+	}
+	if filter.LabelMatch != "" {
+		match, err := domain.ParseLabelMatch(filter.LabelMatch)
+		if err != nil {
+			return domain.ListFilter{}, err
+		}
+		listFilter.LabelMatch = match
+	}
+	return listFilter, nil
+}
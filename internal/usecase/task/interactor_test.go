@@ -3,15 +3,24 @@ package task_test
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/ko44d/go-clean-hexapp/internal/domain/audit"
+	auditmocks "github.com/ko44d/go-clean-hexapp/internal/domain/audit/mocks"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/project"
+	projectmocks "github.com/ko44d/go-clean-hexapp/internal/domain/project/mocks"
 	"github.com/ko44d/go-clean-hexapp/internal/domain/task/mocks"
+	authzmocks "github.com/ko44d/go-clean-hexapp/internal/usecase/authz/mocks"
+	txmocks "github.com/ko44d/go-clean-hexapp/internal/usecase/transaction/mocks"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
 	domain "github.com/ko44d/go-clean-hexapp/internal/domain/task"
+	"github.com/ko44d/go-clean-hexapp/internal/domain/workspace"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/authz"
 	"github.com/ko44d/go-clean-hexapp/internal/usecase/task"
 )
 
@@ -22,17 +31,47 @@ func TestTaskInteractor(t *testing.T) {
 
 var _ = Describe("Task Interactor", func() {
 	var (
-		ctrl       *gomock.Controller
-		mockRepo   *mocks.MockRepository
-		interactor task.Interactor
-		ctx        context.Context
+		ctrl           *gomock.Controller
+		mockRepo       *mocks.MockRepository
+		mockProjects   *projectmocks.MockRepository
+		mockDeps       *mocks.MockDependencyRepository
+		mockAudits     *auditmocks.MockRepository
+		mockTx         *txmocks.MockTransactor
+		mockAuthorizer *authzmocks.MockAuthorizer
+		interactor     task.Interactor
+		ctx            context.Context
+		principal      authz.Principal
+		// records collects the audit records appended during a test.
+		records   []*audit.Record
+		appendErr error
 	)
 
+	allow := func(action authz.Action) {
+		mockAuthorizer.EXPECT().Can(gomock.Any(), principal, action, gomock.Any()).Return(true, nil).AnyTimes()
+	}
+
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		mockRepo = mocks.NewMockRepository(ctrl)
-		interactor = task.New(mockRepo)
-		ctx = context.Background()
+		mockProjects = projectmocks.NewMockRepository(ctrl)
+		mockDeps = mocks.NewMockDependencyRepository(ctrl)
+		mockAudits = auditmocks.NewMockRepository(ctrl)
+		mockTx = txmocks.NewMockTransactor(ctrl)
+		mockAuthorizer = authzmocks.NewMockAuthorizer(ctrl)
+		interactor = task.New(mockRepo, mockProjects, mockDeps, mockAudits, mockTx, mockAuthorizer)
+		records, appendErr = nil, nil
+		mockTx.EXPECT().Within(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		).AnyTimes()
+		mockAudits.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, record *audit.Record) error {
+				records = append(records, record)
+				return appendErr
+			},
+		).AnyTimes()
+		principal = authz.Principal{ID: "user-1", Roles: []string{"editor"}}
+		ctx = authz.WithPrincipal(context.Background(), principal)
+		ctx = workspace.WithID(ctx, "workspace-1")
 	})
 
 	AfterEach(func() {
@@ -40,6 +79,10 @@ var _ = Describe("Task Interactor", func() {
 	})
 
 	Describe("GetTasks", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskRead)
+		})
+
 		Context("when repository returns tasks successfully", func() {
 			It("should return all tasks", func() {
 				repositoryTasks := []*domain.Task{
@@ -59,9 +102,9 @@ var _ = Describe("Task Interactor", func() {
 					},
 				}
 
-				mockRepo.EXPECT().FindAll(ctx).Return(repositoryTasks, nil)
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{}).Return(repositoryTasks, nil)
 
-				tasks, err := interactor.GetTasks(ctx)
+				tasks, err := interactor.GetTasks(ctx, task.TaskFilter{})
 
 				Expect(err).To(BeNil())
 				Expect(tasks).To(HaveLen(2))
@@ -77,20 +120,95 @@ var _ = Describe("Task Interactor", func() {
 		Context("when repository returns an error", func() {
 			It("should return the error", func() {
 				expectedError := errors.New("database error")
-				mockRepo.EXPECT().FindAll(ctx).Return(nil, expectedError)
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{}).Return(nil, expectedError)
 
-				tasks, err := interactor.GetTasks(ctx)
+				tasks, err := interactor.GetTasks(ctx, task.TaskFilter{})
 
 				Expect(err).To(MatchError(expectedError))
 				Expect(tasks).To(BeNil())
 			})
 		})
 
+		Context("when filtering by project", func() {
+			It("should list the project's tasks", func() {
+				mockProjects.EXPECT().FindByID(ctx, "project-1").Return(&project.Project{ID: "project-1"}, nil)
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{ProjectID: "project-1"}).Return([]*domain.Task{}, nil)
+
+				tasks, err := interactor.GetTasks(ctx, task.TaskFilter{ProjectID: "project-1"})
+
+				Expect(err).To(BeNil())
+				Expect(tasks).To(BeEmpty())
+			})
+
+			It("should return project not found error", func() {
+				mockProjects.EXPECT().FindByID(ctx, "project-1").Return(nil, project.ErrProjectNotFound)
+
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{ProjectID: "project-1"})
+
+				Expect(err).To(Equal(task.ErrProjectNotFound))
+			})
+		})
+
+		Context("when filtering by priority and overdue", func() {
+			It("should pass the filter to the repository", func() {
+				before := time.Now()
+				mockRepo.EXPECT().FindAll(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, filter domain.ListFilter) ([]*domain.Task, error) {
+						Expect(filter.Priority).To(Equal(domain.PriorityHigh))
+						Expect(filter.SortBy).To(Equal(domain.SortByDueAt))
+						Expect(filter.OverdueAt).To(BeTemporally(">=", before))
+						return []*domain.Task{}, nil
+					},
+				)
+
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{Priority: "high", Overdue: true, Sort: "due_at"})
+
+				Expect(err).To(BeNil())
+			})
+
+			It("should pass each label name once", func() {
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{
+					Labels:     []string{"backend", "bug"},
+					LabelMatch: domain.LabelMatchAll,
+				}).Return([]*domain.Task{}, nil)
+
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{Labels: []string{"bug", "backend", "bug"}, LabelMatch: "all"})
+
+				Expect(err).To(BeNil())
+			})
+
+			It("should reject unknown label matches", func() {
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{Labels: []string{"bug"}, LabelMatch: "some"})
+
+				Expect(err).To(MatchError(task.ErrInvalidLabelMatch))
+			})
+
+			It("should resolve assignee=me to the caller", func() {
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{Assignee: "user-1"}).Return([]*domain.Task{}, nil)
+
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{Assignee: task.AssigneeMe})
+
+				Expect(err).To(BeNil())
+			})
+
+			It("should reject unknown priorities", func() {
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{Priority: "critical"})
+
+				Expect(err).To(MatchError(task.ErrInvalidPriority))
+			})
+
+			It("should reject unknown sort orders", func() {
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{Sort: "title"})
+
+				Expect(err).To(MatchError(task.ErrInvalidSort))
+			})
+		})
+
 		Context("when repository returns empty list", func() {
 			It("should return empty list", func() {
-				mockRepo.EXPECT().FindAll(ctx).Return([]*domain.Task{}, nil)
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{}).Return([]*domain.Task{}, nil)
 
-				tasks, err := interactor.GetTasks(ctx)
+				tasks, err := interactor.GetTasks(ctx, task.TaskFilter{})
 
 				Expect(err).To(BeNil())
 				Expect(tasks).To(BeEmpty())
@@ -98,7 +216,57 @@ var _ = Describe("Task Interactor", func() {
 		})
 	})
 
+	Describe("GetTask", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskRead)
+		})
+
+		Context("when task exists", func() {
+			It("should return the task", func() {
+				existingTask := &domain.Task{
+					ID:          "task-1",
+					WorkspaceID: "workspace-1",
+					Title:       "Test Task",
+					Status:      domain.StatusTodo,
+				}
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+
+				output, err := interactor.GetTask(ctx, "task-1")
+
+				Expect(err).To(BeNil())
+				Expect(output.ID).To(Equal("task-1"))
+				Expect(output.WorkspaceID).To(Equal("workspace-1"))
+				Expect(output.Title).To(Equal("Test Task"))
+			})
+		})
+
+		Context("when task does not exist in the workspace", func() {
+			It("should return task not found error", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(nil, domain.ErrTaskNotFound)
+
+				_, err := interactor.GetTask(ctx, "task-1")
+
+				Expect(err).To(Equal(domain.ErrTaskNotFound))
+			})
+		})
+
+		Context("when FindByID returns an error", func() {
+			It("should return the error", func() {
+				expectedError := errors.New("database error")
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(nil, expectedError)
+
+				_, err := interactor.GetTask(ctx, "task-1")
+
+				Expect(err).To(MatchError(expectedError))
+			})
+		})
+	})
+
 	Describe("AddTask", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskCreate)
+		})
+
 		Context("when title is valid", func() {
 			It("should create a new task successfully", func() {
 				title := "New Task"
@@ -107,19 +275,150 @@ var _ = Describe("Task Interactor", func() {
 						Expect(task.Title).To(Equal(title))
 						Expect(task.Status).To(Equal(domain.StatusTodo))
 						Expect(task.ID).NotTo(BeEmpty())
+						Expect(task.WorkspaceID).To(Equal("workspace-1"))
+						return nil
+					},
+				)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: title})
+
+				Expect(err).To(BeNil())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].Action).To(Equal(audit.ActionCreate))
+				Expect(records[0].Changes["title"]).To(Equal(audit.Change{After: title}))
+			})
+		})
+
+		Context("when priority and due date are given", func() {
+			It("should schedule the new task", func() {
+				dueAt := time.Now().Add(24 * time.Hour)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.Priority).To(Equal(domain.PriorityHigh))
+						Expect(task.DueAt).To(HaveValue(Equal(dueAt)))
+						return nil
+					},
+				)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task", Priority: "high", DueAt: &dueAt})
+
+				Expect(err).To(BeNil())
+			})
+
+			It("should default the priority to medium", func() {
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.Priority).To(Equal(domain.PriorityMedium))
+						return nil
+					},
+				)
+
+				Expect(interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task"})).To(Succeed())
+			})
+
+			It("should reject a recurrence without a due date", func() {
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task", Recurrence: "FREQ=DAILY"})
+
+				Expect(err).To(MatchError(task.ErrRecurrenceWithoutDueDate))
+			})
+
+			It("should reject an invalid recurrence", func() {
+				dueAt := time.Now().Add(time.Hour)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task", DueAt: &dueAt, Recurrence: "FREQ=YEARLY"})
+
+				Expect(err).To(MatchError(task.ErrInvalidRecurrence))
+			})
+
+			It("should reject a due date in the past", func() {
+				dueAt := time.Now().Add(-time.Hour)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task", DueAt: &dueAt})
+
+				Expect(err).To(MatchError(task.ErrDueBeforeCreation))
+			})
+		})
+
+		Context("when a parent is given", func() {
+			It("should create the task as a subtask", func() {
+				mockRepo.EXPECT().FindByID(ctx, "parent-1").Return(&domain.Task{ID: "parent-1"}, nil)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.ParentID).To(HaveValue(Equal("parent-1")))
 						return nil
 					},
 				)
 
-				err := interactor.AddTask(ctx, title)
+				Expect(interactor.AddTask(ctx, task.AddTaskInput{Title: "Subtask", ParentID: "parent-1"})).To(Succeed())
+			})
+
+			It("should reject a missing parent", func() {
+				mockRepo.EXPECT().FindByID(ctx, "parent-1").Return(nil, domain.ErrTaskNotFound)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "Subtask", ParentID: "parent-1"})
+
+				Expect(err).To(Equal(task.ErrParentNotFound))
+			})
+
+			It("should reject nesting beyond the depth limit", func() {
+				id := func(i int) string { return "task-" + strconv.Itoa(i) }
+				for i := 0; i <= domain.MaxDepth; i++ {
+					parentID := id(i + 1)
+					mockRepo.EXPECT().FindByID(ctx, id(i)).Return(&domain.Task{ID: id(i), ParentID: &parentID}, nil)
+				}
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "Subtask", ParentID: id(0)})
+
+				Expect(err).To(MatchError(task.ErrHierarchyTooDeep))
+			})
+		})
+
+		Context("when a project is given", func() {
+			It("should file the task under the project", func() {
+				mockProjects.EXPECT().FindByID(ctx, "project-1").Return(&project.Project{ID: "project-1"}, nil)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.ProjectID).NotTo(BeNil())
+						Expect(*task.ProjectID).To(Equal("project-1"))
+						return nil
+					},
+				)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task", ProjectID: "project-1"})
 
 				Expect(err).To(BeNil())
 			})
+
+			It("should refuse to add tasks to an archived project", func() {
+				mockProjects.EXPECT().FindByID(ctx, "project-1").Return(&project.Project{ID: "project-1", Archived: true}, nil)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task", ProjectID: "project-1"})
+
+				Expect(err).To(Equal(task.ErrProjectArchived))
+			})
+
+			It("should return project not found error", func() {
+				mockProjects.EXPECT().FindByID(ctx, "project-1").Return(nil, project.ErrProjectNotFound)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task", ProjectID: "project-1"})
+
+				Expect(err).To(Equal(task.ErrProjectNotFound))
+			})
+		})
+
+		Context("when no workspace is selected", func() {
+			It("should return workspace required error", func() {
+				ctx = authz.WithPrincipal(context.Background(), principal)
+
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task"})
+
+				Expect(err).To(Equal(task.ErrWorkspaceRequired))
+			})
 		})
 
 		Context("when title is empty", func() {
 			It("should return validation error", func() {
-				err := interactor.AddTask(ctx, "")
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: ""})
 
 				Expect(err).To(Equal(domain.ErrInvalidTitle))
 			})
@@ -127,7 +426,7 @@ var _ = Describe("Task Interactor", func() {
 
 		Context("when title contains only whitespace", func() {
 			It("should return blank title error", func() {
-				err := interactor.AddTask(ctx, "   ")
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "   "})
 
 				Expect(err).To(Equal(domain.ErrTitleBlank))
 			})
@@ -139,7 +438,7 @@ var _ = Describe("Task Interactor", func() {
 				expectedError := errors.New("database error")
 				mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(expectedError)
 
-				err := interactor.AddTask(ctx, title)
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: title})
 
 				Expect(err).To(MatchError(expectedError))
 			})
@@ -147,6 +446,10 @@ var _ = Describe("Task Interactor", func() {
 	})
 
 	Describe("CompleteTask", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+		})
+
 		Context("when task exists", func() {
 			It("should mark task as complete and update it", func() {
 				taskID := "task-1"
@@ -158,7 +461,8 @@ var _ = Describe("Task Interactor", func() {
 					UpdatedAt: time.Now(),
 				}
 
-				mockRepo.EXPECT().FindByID(ctx, taskID).Return(existingTask, nil)
+				mockRepo.EXPECT().FindTree(ctx, taskID).Return([]*domain.Task{existingTask}, nil)
+				mockDeps.EXPECT().FindOpenBlockers(ctx, []string{"task-1"}).Return(nil, nil)
 				mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
 					func(ctx context.Context, task *domain.Task) error {
 						Expect(task.ID).To(Equal(taskID))
@@ -167,30 +471,183 @@ var _ = Describe("Task Interactor", func() {
 					},
 				)
 
-				err := interactor.CompleteTask(ctx, taskID)
+				err := interactor.CompleteTask(ctx, taskID, false)
+
+				Expect(err).To(BeNil())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].Action).To(Equal(audit.ActionComplete))
+				Expect(records[0].Changes).To(Equal(map[string]audit.Change{
+					"status": {Before: "todo", After: "complete"},
+				}))
+			})
+		})
+
+		Context("when task recurs", func() {
+			It("should create the next occurrence", func() {
+				dueAt := time.Now().Add(time.Hour)
+				recurrence, _ := domain.ParseRecurrence("FREQ=DAILY")
+				seriesID := "task-1"
+				existingTask := &domain.Task{
+					ID:         "task-1",
+					Title:      "Daily standup notes",
+					Status:     domain.StatusTodo,
+					DueAt:      &dueAt,
+					Recurrence: recurrence,
+					SeriesID:   &seriesID,
+					Occurrence: 1,
+					CreatedAt:  time.Now(),
+					UpdatedAt:  time.Now(),
+				}
+
+				mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{existingTask}, nil)
+				mockDeps.EXPECT().FindOpenBlockers(ctx, []string{"task-1"}).Return(nil, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{SeriesID: "task-1", MinOccurrence: 2}).Return(nil, nil)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.ID).NotTo(Equal("task-1"))
+						Expect(task.Status).To(Equal(domain.StatusTodo))
+						Expect(task.DueAt).To(HaveValue(Equal(dueAt.AddDate(0, 0, 1))))
+						Expect(task.SeriesID).To(HaveValue(Equal("task-1")))
+						Expect(task.Occurrence).To(Equal(2))
+						return nil
+					},
+				)
+
+				err := interactor.CompleteTask(ctx, "task-1", false)
+
+				Expect(err).To(BeNil())
+			})
+		})
+
+		Context("when a recurring task is completed twice", func() {
+			var existingTask *domain.Task
+
+			BeforeEach(func() {
+				dueAt := time.Now().Add(time.Hour)
+				recurrence, _ := domain.ParseRecurrence("FREQ=DAILY")
+				seriesID := "task-1"
+				existingTask = &domain.Task{
+					ID:         "task-1",
+					Title:      "Daily standup notes",
+					Status:     domain.StatusTodo,
+					DueAt:      &dueAt,
+					Recurrence: recurrence,
+					SeriesID:   &seriesID,
+					Occurrence: 1,
+					CreatedAt:  time.Now(),
+					UpdatedAt:  time.Now(),
+				}
+			})
+
+			It("should create the next occurrence only once", func() {
+				var created []*domain.Task
+				mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{existingTask}, nil).Times(2)
+				mockDeps.EXPECT().FindOpenBlockers(ctx, []string{"task-1"}).Return(nil, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{SeriesID: "task-1", MinOccurrence: 2}).Return(nil, nil)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						created = append(created, task)
+						return nil
+					},
+				)
+
+				Expect(interactor.CompleteTask(ctx, "task-1", false)).To(Succeed())
+				Expect(interactor.CompleteTask(ctx, "task-1", false)).To(Succeed())
+
+				Expect(created).To(HaveLen(1))
+				Expect(records).To(HaveLen(2))
+			})
+
+			It("should not create another occurrence after reopening", func() {
+				seriesID := "task-1"
+				next := &domain.Task{ID: "task-2", Status: domain.StatusTodo, SeriesID: &seriesID, Occurrence: 2}
+				mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{existingTask}, nil)
+				mockDeps.EXPECT().FindOpenBlockers(ctx, []string{"task-1"}).Return(nil, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{SeriesID: "task-1", MinOccurrence: 2}).Return([]*domain.Task{next}, nil)
+
+				Expect(interactor.CompleteTask(ctx, "task-1", false)).To(Succeed())
+			})
+		})
+
+		Context("when task has open subtasks", func() {
+			var parent, done, open, grandchild *domain.Task
+
+			BeforeEach(func() {
+				parentID := "task-1"
+				openID := "task-3"
+				parent = &domain.Task{ID: parentID, Status: domain.StatusTodo}
+				done = &domain.Task{ID: "task-2", ParentID: &parentID, Status: domain.StatusComplete}
+				open = &domain.Task{ID: openID, ParentID: &parentID, Status: domain.StatusInProgress}
+				grandchild = &domain.Task{ID: "task-4", ParentID: &openID, Status: domain.StatusTodo}
+				mockRepo.EXPECT().FindTree(ctx, parentID).Return([]*domain.Task{parent, done, open, grandchild}, nil)
+			})
+
+			It("should refuse to complete the parent", func() {
+				err := interactor.CompleteTask(ctx, "task-1", false)
+
+				Expect(err).To(MatchError(task.ErrOpenSubtasks))
+			})
+
+			It("should complete open subtasks first when cascading", func() {
+				var updated []string
+				mockDeps.EXPECT().FindOpenBlockers(ctx, []string{"task-4", "task-3", "task-1"}).Return(nil, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.Status).To(Equal(domain.StatusComplete))
+						updated = append(updated, task.ID)
+						return nil
+					},
+				).Times(3)
+
+				err := interactor.CompleteTask(ctx, "task-1", true)
 
 				Expect(err).To(BeNil())
+				Expect(updated).To(Equal([]string{"task-4", "task-3", "task-1"}))
+			})
+		})
+
+		Context("when task waits on an open blocker", func() {
+			It("should refuse to complete it", func() {
+				mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{{ID: "task-1", Status: domain.StatusTodo}}, nil)
+				mockDeps.EXPECT().FindOpenBlockers(ctx, []string{"task-1"}).Return([]domain.Dependency{{TaskID: "task-1", BlockerID: "task-9"}}, nil)
+
+				err := interactor.CompleteTask(ctx, "task-1", false)
+
+				Expect(err).To(MatchError(task.ErrOpenBlockers))
 			})
 		})
 
 		Context("when task does not exist", func() {
 			It("should return task not found error", func() {
 				taskID := "non-existent-id"
-				mockRepo.EXPECT().FindByID(ctx, taskID).Return(nil, domain.ErrTaskNotFound)
+				mockRepo.EXPECT().FindTree(ctx, taskID).Return(nil, domain.ErrTaskNotFound)
 
-				err := interactor.CompleteTask(ctx, taskID)
+				err := interactor.CompleteTask(ctx, taskID, false)
 
 				Expect(err).To(Equal(domain.ErrTaskNotFound))
 			})
 		})
 
-		Context("when FindByID returns an error", func() {
+		Context("when task is cancelled", func() {
+			It("should return invalid transition error", func() {
+				mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{{ID: "task-1", Status: domain.StatusCancelled}}, nil)
+
+				err := interactor.CompleteTask(ctx, "task-1", false)
+
+				Expect(err).To(MatchError(task.ErrInvalidTransition))
+			})
+		})
+
+		Context("when FindTree returns an error", func() {
 			It("should return the error", func() {
 				taskID := "task-1"
 				expectedError := errors.New("database error")
-				mockRepo.EXPECT().FindByID(ctx, taskID).Return(nil, expectedError)
+				mockRepo.EXPECT().FindTree(ctx, taskID).Return(nil, expectedError)
 
-				err := interactor.CompleteTask(ctx, taskID)
+				err := interactor.CompleteTask(ctx, taskID, false)
 
 				Expect(err).To(MatchError(expectedError))
 			})
@@ -208,13 +665,551 @@ var _ = Describe("Task Interactor", func() {
 				}
 				expectedError := errors.New("update failed")
 
-				mockRepo.EXPECT().FindByID(ctx, taskID).Return(existingTask, nil)
+				mockRepo.EXPECT().FindTree(ctx, taskID).Return([]*domain.Task{existingTask}, nil)
+				mockDeps.EXPECT().FindOpenBlockers(ctx, []string{"task-1"}).Return(nil, nil)
 				mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(expectedError)
 
-				err := interactor.CompleteTask(ctx, taskID)
+				err := interactor.CompleteTask(ctx, taskID, false)
+
+				Expect(err).To(MatchError(expectedError))
+			})
+		})
+	})
+
+	Describe("Authorization", func() {
+		Context("when the authorizer denies the action", func() {
+			BeforeEach(func() {
+				mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			})
+
+			It("should not read tasks", func() {
+				tasks, err := interactor.GetTasks(ctx, task.TaskFilter{})
+
+				Expect(err).To(Equal(task.ErrForbidden))
+				Expect(tasks).To(BeNil())
+			})
+
+			It("should not create a task", func() {
+				err := interactor.AddTask(ctx, task.AddTaskInput{Title: "New Task"})
+
+				Expect(err).To(Equal(task.ErrForbidden))
+			})
+
+			It("should not complete a task", func() {
+				err := interactor.CompleteTask(ctx, "task-1", false)
+
+				Expect(err).To(Equal(task.ErrForbidden))
+			})
+		})
+
+		Context("when completing a task", func() {
+			It("should pass the principal and task resource to the authorizer", func() {
+				mockAuthorizer.EXPECT().
+					Can(gomock.Any(), principal, authz.ActionTaskUpdate, authz.Resource{Type: "task", ID: "task-1"}).
+					Return(false, nil)
+
+				err := interactor.CompleteTask(ctx, "task-1", false)
+
+				Expect(err).To(Equal(task.ErrForbidden))
+			})
+		})
+
+		Context("when the authorizer fails", func() {
+			It("should return the wrapped error", func() {
+				expectedError := errors.New("policy unavailable")
+				mockAuthorizer.EXPECT().Can(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, expectedError)
+
+				_, err := interactor.GetTasks(ctx, task.TaskFilter{})
 
 				Expect(err).To(MatchError(expectedError))
+				Expect(err).NotTo(MatchError(task.ErrForbidden))
+			})
+		})
+	})
+
+	Describe("GetSubtasks", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskRead)
+		})
+
+		It("should return the nested subtasks of the task", func() {
+			rootID, childID := "task-1", "task-2"
+			mockRepo.EXPECT().FindTree(ctx, rootID).Return([]*domain.Task{
+				{ID: rootID, Subtasks: domain.Progress{Total: 1}},
+				{ID: childID, ParentID: &rootID},
+				{ID: "task-3", ParentID: &childID},
+			}, nil)
+
+			subtasks, err := interactor.GetSubtasks(ctx, rootID)
+
+			Expect(err).To(BeNil())
+			Expect(subtasks).To(HaveLen(1))
+			Expect(subtasks[0].ID).To(Equal(childID))
+			Expect(subtasks[0].ParentID).To(Equal(rootID))
+			Expect(subtasks[0].Subtasks).To(HaveLen(1))
+			Expect(subtasks[0].Subtasks[0].ID).To(Equal("task-3"))
+		})
+
+		It("should return task not found error", func() {
+			mockRepo.EXPECT().FindTree(ctx, "task-1").Return(nil, domain.ErrTaskNotFound)
+
+			_, err := interactor.GetSubtasks(ctx, "task-1")
+
+			Expect(err).To(Equal(task.ErrTaskNotFound))
+		})
+	})
+
+	Describe("TransitionTask", func() {
+		var existingTask *domain.Task
+
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+			existingTask = &domain.Task{
+				ID:        "task-1",
+				Title:     "Test Task",
+				Status:    domain.StatusTodo,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}
+		})
+
+		Context("when the transition is allowed", func() {
+			It("should persist and return the new status", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.Status).To(Equal(domain.StatusInProgress))
+						return nil
+					},
+				)
+
+				output, err := interactor.TransitionTask(ctx, "task-1", "in_progress")
+
+				Expect(err).To(BeNil())
+				Expect(output.Status).To(Equal("in_progress"))
+			})
+		})
+
+		Context("when the transition is not allowed", func() {
+			It("should return invalid transition error without saving", func() {
+				existingTask.Status = domain.StatusComplete
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+
+				_, err := interactor.TransitionTask(ctx, "task-1", "blocked")
+
+				Expect(err).To(MatchError(task.ErrInvalidTransition))
+			})
+		})
+
+		Context("when the task already has the status", func() {
+			It("should return it without saving or auditing", func() {
+				existingTask.Status = domain.StatusInProgress
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+
+				output, err := interactor.TransitionTask(ctx, "task-1", "in_progress")
+
+				Expect(err).To(BeNil())
+				Expect(output.Status).To(Equal("in_progress"))
+				Expect(records).To(BeEmpty())
+			})
+
+			It("should not complete a complete task again", func() {
+				existingTask.Status = domain.StatusComplete
+				mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{existingTask}, nil)
+
+				output, err := interactor.TransitionTask(ctx, "task-1", "complete")
+
+				Expect(err).To(BeNil())
+				Expect(output.Status).To(Equal("complete"))
+				Expect(records).To(BeEmpty())
+			})
+		})
+
+		Context("when the status is unknown", func() {
+			It("should return invalid status error", func() {
+				_, err := interactor.TransitionTask(ctx, "task-1", "done")
+
+				Expect(err).To(MatchError(task.ErrInvalidStatus))
+			})
+		})
+
+		Context("when task does not exist", func() {
+			It("should return task not found error", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(nil, domain.ErrTaskNotFound)
+
+				_, err := interactor.TransitionTask(ctx, "task-1", "in_progress")
+
+				Expect(err).To(Equal(domain.ErrTaskNotFound))
+			})
+		})
+	})
+
+	Describe("UpdateTask", func() {
+		var existingTask *domain.Task
+
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+			createdAt := time.Now().Add(-time.Hour)
+			existingTask = &domain.Task{
+				ID:        "task-1",
+				Title:     "Test Task",
+				Status:    domain.StatusTodo,
+				Priority:  domain.PriorityMedium,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			}
+		})
+
+		Context("when input is valid", func() {
+			It("should replace title, priority and due date", func() {
+				dueAt := time.Now().Add(time.Hour)
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, task *domain.Task) error {
+						Expect(task.Title).To(Equal("Renamed"))
+						Expect(task.Priority).To(Equal(domain.PriorityUrgent))
+						Expect(task.DueAt).To(HaveValue(Equal(dueAt)))
+						return nil
+					},
+				)
+
+				output, err := interactor.UpdateTask(ctx, "task-1", task.UpdateTaskInput{Title: "Renamed", Priority: "urgent", DueAt: &dueAt})
+
+				Expect(err).To(BeNil())
+				Expect(output.Priority).To(Equal("urgent"))
+				Expect(output.DueAt).To(HaveValue(Equal(dueAt)))
+			})
+
+			It("should record the changed fields", func() {
+				ctx = audit.WithRequestID(ctx, "request-1")
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+				_, err := interactor.UpdateTask(ctx, "task-1", task.UpdateTaskInput{Title: "Renamed", Priority: "medium"})
+
+				Expect(err).To(BeNil())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].TaskID).To(Equal("task-1"))
+				Expect(records[0].Actor).To(Equal("user-1"))
+				Expect(records[0].Action).To(Equal(audit.ActionUpdate))
+				Expect(records[0].RequestID).To(Equal("request-1"))
+				Expect(records[0].Changes).To(Equal(map[string]audit.Change{
+					"title": {Before: "Test Task", After: "Renamed"},
+				}))
+			})
+
+			It("should not record updates that change nothing", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+				_, err := interactor.UpdateTask(ctx, "task-1", task.UpdateTaskInput{Title: "Test Task"})
+
+				Expect(err).To(BeNil())
+				Expect(records).To(BeEmpty())
+			})
+
+			It("should fail the update when the record cannot be written", func() {
+				appendErr = errors.New("insert failed")
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+				mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+				_, err := interactor.UpdateTask(ctx, "task-1", task.UpdateTaskInput{Title: "Renamed"})
+
+				Expect(err).To(MatchError(appendErr))
+				Expect(err).To(MatchError(ContainSubstring("UpdateTask")))
+			})
+		})
+
+		Context("when the due date is before creation", func() {
+			It("should return validation error without saving", func() {
+				dueAt := existingTask.CreatedAt.Add(-time.Minute)
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil)
+
+				_, err := interactor.UpdateTask(ctx, "task-1", task.UpdateTaskInput{Title: "Renamed", DueAt: &dueAt})
+
+				Expect(err).To(MatchError(task.ErrDueBeforeCreation))
+			})
+		})
+
+		Context("when the new parent is one of its subtasks", func() {
+			It("should reject the cycle without saving", func() {
+				taskID := "task-1"
+				child := &domain.Task{ID: "task-2", ParentID: &taskID}
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(existingTask, nil).Times(2)
+				mockRepo.EXPECT().FindByID(ctx, "task-2").Return(child, nil)
+				mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{existingTask, child}, nil)
+
+				_, err := interactor.UpdateTask(ctx, "task-1", task.UpdateTaskInput{Title: "Renamed", ParentID: "task-2"})
+
+				Expect(err).To(MatchError(task.ErrHierarchyCycle))
 			})
 		})
+
+		Context("when task does not exist", func() {
+			It("should return task not found error", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(nil, domain.ErrTaskNotFound)
+
+				_, err := interactor.UpdateTask(ctx, "task-1", task.UpdateTaskInput{Title: "Renamed"})
+
+				Expect(err).To(Equal(domain.ErrTaskNotFound))
+			})
+		})
+	})
+
+	Describe("AddDependency", func() {
+		var lockErr error
+
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+			lockErr = nil
+			mockDeps.EXPECT().Lock(gomock.Any()).DoAndReturn(func(context.Context) error { return lockErr })
+		})
+
+		Context("when both tasks exist", func() {
+			It("should store the dependency", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1"}, nil)
+				mockRepo.EXPECT().FindByID(ctx, "task-2").Return(&domain.Task{ID: "task-2"}, nil)
+				mockDeps.EXPECT().FindGraph(ctx, "task-1").Return(nil, nil)
+				mockDeps.EXPECT().Add(ctx, domain.Dependency{TaskID: "task-1", BlockerID: "task-2"}).Return(nil)
+
+				err := interactor.AddDependency(ctx, "task-1", "task-2")
+
+				Expect(err).To(BeNil())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].TaskID).To(Equal("task-1"))
+				Expect(records[0].Action).To(Equal(audit.ActionUpdate))
+				Expect(records[0].Changes).To(Equal(map[string]audit.Change{
+					"blocked_by": {After: "task-2"},
+				}))
+			})
+
+			It("should neither store nor audit an existing dependency again", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1"}, nil)
+				mockRepo.EXPECT().FindByID(ctx, "task-2").Return(&domain.Task{ID: "task-2"}, nil)
+				mockDeps.EXPECT().FindGraph(ctx, "task-1").Return([]domain.Dependency{{TaskID: "task-1", BlockerID: "task-2"}}, nil)
+
+				err := interactor.AddDependency(ctx, "task-1", "task-2")
+
+				Expect(err).To(BeNil())
+				Expect(records).To(BeEmpty())
+			})
+		})
+
+		Context("when the graph cannot be locked", func() {
+			It("should fail before checking for cycles", func() {
+				lockErr = errors.New("connection lost")
+
+				err := interactor.AddDependency(ctx, "task-1", "task-2")
+
+				Expect(err).To(MatchError(lockErr))
+			})
+		})
+
+		Context("when the blocker already waits on the task", func() {
+			It("should reject the cycle without saving", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1"}, nil)
+				mockRepo.EXPECT().FindByID(ctx, "task-3").Return(&domain.Task{ID: "task-3"}, nil)
+				mockDeps.EXPECT().FindGraph(ctx, "task-1").Return([]domain.Dependency{
+					{TaskID: "task-2", BlockerID: "task-1"},
+					{TaskID: "task-3", BlockerID: "task-2"},
+				}, nil)
+
+				err := interactor.AddDependency(ctx, "task-1", "task-3")
+
+				Expect(err).To(MatchError(task.ErrDependencyCycle))
+			})
+		})
+
+		Context("when the blocker does not exist", func() {
+			It("should return blocker not found error", func() {
+				mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1"}, nil)
+				mockRepo.EXPECT().FindByID(ctx, "task-2").Return(nil, domain.ErrTaskNotFound)
+
+				err := interactor.AddDependency(ctx, "task-1", "task-2")
+
+				Expect(err).To(Equal(task.ErrBlockerNotFound))
+			})
+		})
+	})
+
+	Describe("RemoveDependency", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+			mockDeps.EXPECT().Lock(gomock.Any()).Return(nil)
+		})
+
+		It("should remove and audit the dependency", func() {
+			mockDeps.EXPECT().Remove(ctx, domain.Dependency{TaskID: "task-1", BlockerID: "task-2"}).Return(nil)
+
+			err := interactor.RemoveDependency(ctx, "task-1", "task-2")
+
+			Expect(err).To(BeNil())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Changes).To(Equal(map[string]audit.Change{
+				"blocked_by": {Before: "task-2"},
+			}))
+		})
+
+		It("should return dependency not found error", func() {
+			mockDeps.EXPECT().Remove(ctx, domain.Dependency{TaskID: "task-1", BlockerID: "task-2"}).Return(domain.ErrDependencyNotFound)
+
+			err := interactor.RemoveDependency(ctx, "task-1", "task-2")
+
+			Expect(err).To(Equal(task.ErrDependencyNotFound))
+		})
+	})
+
+	Describe("GetDependencyGraph", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskRead)
+		})
+
+		It("should return the connected tasks and edges", func() {
+			edges := []domain.Dependency{
+				{TaskID: "task-1", BlockerID: "task-2"},
+				{TaskID: "task-3", BlockerID: "task-1"},
+			}
+			mockDeps.EXPECT().FindGraph(ctx, "task-1").Return(edges, nil)
+			mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{IDs: []string{"task-1", "task-2", "task-3"}}).Return([]*domain.Task{
+				{ID: "task-1"}, {ID: "task-2"}, {ID: "task-3"},
+			}, nil)
+
+			graph, err := interactor.GetDependencyGraph(ctx, "task-1")
+
+			Expect(err).To(BeNil())
+			Expect(graph.Tasks).To(HaveLen(3))
+			Expect(graph.Edges).To(Equal([]task.DependencyOutput{
+				{TaskID: "task-1", BlockerID: "task-2"},
+				{TaskID: "task-3", BlockerID: "task-1"},
+			}))
+		})
+
+		It("should return task not found error", func() {
+			mockDeps.EXPECT().FindGraph(ctx, "task-1").Return(nil, nil)
+			mockRepo.EXPECT().FindAll(ctx, domain.ListFilter{IDs: []string{"task-1"}}).Return(nil, nil)
+
+			_, err := interactor.GetDependencyGraph(ctx, "task-1")
+
+			Expect(err).To(Equal(task.ErrTaskNotFound))
+		})
+	})
+
+	Describe("AssignTask", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+		})
+
+		It("should add the assignee", func() {
+			mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1", Status: domain.StatusTodo}, nil)
+			mockRepo.EXPECT().AddAssignee(ctx, "task-1", "user-2").Return(nil)
+
+			output, err := interactor.AssignTask(ctx, "task-1", "user-2")
+
+			Expect(err).To(BeNil())
+			Expect(output.Assignees).To(Equal([]string{"user-2"}))
+		})
+
+		It("should refuse completed tasks without saving", func() {
+			mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1", Status: domain.StatusComplete}, nil)
+
+			_, err := interactor.AssignTask(ctx, "task-1", "user-2")
+
+			Expect(err).To(MatchError(task.ErrTaskFinished))
+		})
+	})
+
+	Describe("UnassignTask", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskUpdate)
+		})
+
+		It("should remove the assignee", func() {
+			mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1", Status: domain.StatusTodo, Assignees: []string{"user-2"}}, nil)
+			mockRepo.EXPECT().RemoveAssignee(ctx, "task-1", "user-2").Return(nil)
+
+			Expect(interactor.UnassignTask(ctx, "task-1", "user-2")).To(Succeed())
+		})
+
+		It("should report users that are not assigned", func() {
+			mockRepo.EXPECT().FindByID(ctx, "task-1").Return(&domain.Task{ID: "task-1", Status: domain.StatusTodo}, nil)
+
+			err := interactor.UnassignTask(ctx, "task-1", "user-2")
+
+			Expect(err).To(MatchError(task.ErrAssigneeNotFound))
+		})
+	})
+
+	Describe("DeleteTask", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskDelete)
+		})
+
+		It("should delete the task and record each deleted task", func() {
+			parentID := "task-1"
+			mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{
+				{ID: "task-1", Title: "Parent", Status: domain.StatusTodo},
+				{ID: "task-2", ParentID: &parentID, Title: "Child", Status: domain.StatusTodo},
+			}, nil)
+			mockRepo.EXPECT().Delete(ctx, "task-1", gomock.Any()).Return(nil)
+
+			Expect(interactor.DeleteTask(ctx, "task-1")).To(Succeed())
+
+			Expect(records).To(HaveLen(2))
+			Expect(records[1].TaskID).To(Equal("task-2"))
+			Expect(records[1].Action).To(Equal(audit.ActionDelete))
+			Expect(records[1].Changes["parent_id"]).To(Equal(audit.Change{Before: "task-1"}))
+		})
+
+		It("should return task not found error", func() {
+			mockRepo.EXPECT().FindTree(ctx, "missing").Return(nil, domain.ErrTaskNotFound)
+
+			err := interactor.DeleteTask(ctx, "missing")
+
+			Expect(err).To(Equal(task.ErrTaskNotFound))
+			Expect(records).To(BeEmpty())
+		})
+	})
+
+	Describe("RestoreTask", func() {
+		BeforeEach(func() {
+			allow(authz.ActionTaskDelete)
+		})
+
+		It("should restore the task and record each restored task", func() {
+			mockRepo.EXPECT().Restore(ctx, "task-1").Return(nil)
+			mockRepo.EXPECT().FindTree(ctx, "task-1").Return([]*domain.Task{
+				{ID: "task-1", Title: "Parent", Status: domain.StatusTodo},
+			}, nil)
+
+			output, err := interactor.RestoreTask(ctx, "task-1")
+
+			Expect(err).To(BeNil())
+			Expect(output.ID).To(Equal("task-1"))
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].Action).To(Equal(audit.ActionRestore))
+		})
+
+		It("should refuse while the parent is deleted", func() {
+			mockRepo.EXPECT().Restore(ctx, "task-2").Return(domain.ErrParentDeleted)
+
+			_, err := interactor.RestoreTask(ctx, "task-2")
+
+			Expect(err).To(Equal(task.ErrParentDeleted))
+			Expect(records).To(BeEmpty())
+		})
+	})
+
+	Describe("GetTrash", func() {
+		It("should list the deleted tasks", func() {
+			allow(authz.ActionTaskRead)
+			deletedAt := time.Now()
+			mockRepo.EXPECT().FindDeleted(ctx).Return([]*domain.Task{{ID: "task-1", DeletedAt: &deletedAt}}, nil)
+
+			outputs, err := interactor.GetTrash(ctx)
+
+			Expect(err).To(BeNil())
+			Expect(outputs).To(HaveLen(1))
+			Expect(outputs[0].DeletedAt).To(HaveValue(Equal(deletedAt)))
+		})
 	})
 })
@@ -41,45 +41,221 @@ func (m *MockInteractor) EXPECT() *MockInteractorMockRecorder {
 	return m.recorder
 }
 
+// AddDependency mocks base method.
+func (m *MockInteractor) AddDependency(ctx context.Context, taskID, blockerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", ctx, taskID, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockInteractorMockRecorder) AddDependency(ctx, taskID, blockerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockInteractor)(nil).AddDependency), ctx, taskID, blockerID)
+}
+
 // AddTask mocks base method.
-func (m *MockInteractor) AddTask(ctx context.Context, title string) error {
+func (m *MockInteractor) AddTask(ctx context.Context, input task.AddTaskInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddTask", ctx, title)
+	ret := m.ctrl.Call(m, "AddTask", ctx, input)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddTask indicates an expected call of AddTask.
-func (mr *MockInteractorMockRecorder) AddTask(ctx, title any) *gomock.Call {
+func (mr *MockInteractorMockRecorder) AddTask(ctx, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockInteractor)(nil).AddTask), ctx, title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTask", reflect.TypeOf((*MockInteractor)(nil).AddTask), ctx, input)
+}
+
+// AssignTask mocks base method.
+func (m *MockInteractor) AssignTask(ctx context.Context, id, userID string) (task.TaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignTask", ctx, id, userID)
+	ret0, _ := ret[0].(task.TaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignTask indicates an expected call of AssignTask.
+func (mr *MockInteractorMockRecorder) AssignTask(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTask", reflect.TypeOf((*MockInteractor)(nil).AssignTask), ctx, id, userID)
 }
 
 // CompleteTask mocks base method.
-func (m *MockInteractor) CompleteTask(ctx context.Context, id string) error {
+func (m *MockInteractor) CompleteTask(ctx context.Context, id string, cascade bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CompleteTask", ctx, id)
+	ret := m.ctrl.Call(m, "CompleteTask", ctx, id, cascade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CompleteTask indicates an expected call of CompleteTask.
-func (mr *MockInteractorMockRecorder) CompleteTask(ctx, id any) *gomock.Call {
+func (mr *MockInteractorMockRecorder) CompleteTask(ctx, id, cascade any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTask", reflect.TypeOf((*MockInteractor)(nil).CompleteTask), ctx, id, cascade)
+}
+
+// DeleteTask mocks base method.
+func (m *MockInteractor) DeleteTask(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockInteractorMockRecorder) DeleteTask(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTask", reflect.TypeOf((*MockInteractor)(nil).CompleteTask), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockInteractor)(nil).DeleteTask), ctx, id)
+}
+
+// GetDependencyGraph mocks base method.
+func (m *MockInteractor) GetDependencyGraph(ctx context.Context, id string) (task.DependencyGraphOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencyGraph", ctx, id)
+	ret0, _ := ret[0].(task.DependencyGraphOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependencyGraph indicates an expected call of GetDependencyGraph.
+func (mr *MockInteractorMockRecorder) GetDependencyGraph(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencyGraph", reflect.TypeOf((*MockInteractor)(nil).GetDependencyGraph), ctx, id)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockInteractor) GetSubtasks(ctx context.Context, id string) ([]task.TaskTreeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", ctx, id)
+	ret0, _ := ret[0].([]task.TaskTreeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockInteractorMockRecorder) GetSubtasks(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockInteractor)(nil).GetSubtasks), ctx, id)
+}
+
+// GetTask mocks base method.
+func (m *MockInteractor) GetTask(ctx context.Context, id string) (task.TaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret0, _ := ret[0].(task.TaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockInteractorMockRecorder) GetTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockInteractor)(nil).GetTask), ctx, id)
 }
 
 // GetTasks mocks base method.
-func (m *MockInteractor) GetTasks(ctx context.Context) ([]task.TaskOutput, error) {
+func (m *MockInteractor) GetTasks(ctx context.Context, filter task.TaskFilter) ([]task.TaskOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", ctx)
+	ret := m.ctrl.Call(m, "GetTasks", ctx, filter)
 	ret0, _ := ret[0].([]task.TaskOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTasks indicates an expected call of GetTasks.
-func (mr *MockInteractorMockRecorder) GetTasks(ctx any) *gomock.Call {
+func (mr *MockInteractorMockRecorder) GetTasks(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockInteractor)(nil).GetTasks), ctx, filter)
+}
+
+// GetTrash mocks base method.
+func (m *MockInteractor) GetTrash(ctx context.Context) ([]task.TaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx)
+	ret0, _ := ret[0].([]task.TaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockInteractorMockRecorder) GetTrash(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockInteractor)(nil).GetTrash), ctx)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockInteractor) RemoveDependency(ctx context.Context, taskID, blockerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", ctx, taskID, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockInteractorMockRecorder) RemoveDependency(ctx, taskID, blockerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockInteractor)(nil).RemoveDependency), ctx, taskID, blockerID)
+}
+
+// RestoreTask mocks base method.
+func (m *MockInteractor) RestoreTask(ctx context.Context, id string) (task.TaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, id)
+	ret0, _ := ret[0].(task.TaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockInteractorMockRecorder) RestoreTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockInteractor)(nil).RestoreTask), ctx, id)
+}
+
+// TransitionTask mocks base method.
+func (m *MockInteractor) TransitionTask(ctx context.Context, id, status string) (task.TaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionTask", ctx, id, status)
+	ret0, _ := ret[0].(task.TaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionTask indicates an expected call of TransitionTask.
+func (mr *MockInteractorMockRecorder) TransitionTask(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionTask", reflect.TypeOf((*MockInteractor)(nil).TransitionTask), ctx, id, status)
+}
+
+// UnassignTask mocks base method.
+func (m *MockInteractor) UnassignTask(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignTask", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignTask indicates an expected call of UnassignTask.
+func (mr *MockInteractorMockRecorder) UnassignTask(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignTask", reflect.TypeOf((*MockInteractor)(nil).UnassignTask), ctx, id, userID)
+}
+
+// UpdateTask mocks base method.
+func (m *MockInteractor) UpdateTask(ctx context.Context, id string, input task.UpdateTaskInput) (task.TaskOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, id, input)
+	ret0, _ := ret[0].(task.TaskOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockInteractorMockRecorder) UpdateTask(ctx, id, input any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockInteractor)(nil).GetTasks), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockInteractor)(nil).UpdateTask), ctx, id, input)
 }
@@ -7,11 +7,47 @@ import (
 )
 
 type TaskOutput struct {
-	ID        string
-	Title     string
-	Status    string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           string
+	WorkspaceID  string
+	ProjectID    string
+	ParentID     string
+	Title        string
+	Status       string
+	Priority     string
+	DueAt        *time.Time
+	Recurrence   string
+	SeriesID     string
+	Assignees    []string
+	Progress     ProgressOutput
+	Labels       []string
+	CommentCount int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time
+}
+
+// ProgressOutput counts the direct, non-cancelled subtasks of a task.
+type ProgressOutput struct {
+	Total     int
+	Completed int
+}
+
+type DependencyOutput struct {
+	TaskID    string
+	BlockerID string
+}
+
+// DependencyGraphOutput holds a task, every task it transitively waits on or
+// blocks, and the edges between them.
+type DependencyGraphOutput struct {
+	Tasks []TaskOutput
+	Edges []DependencyOutput
+}
+
+// TaskTreeOutput is a task with its subtasks, nested to any depth.
+type TaskTreeOutput struct {
+	TaskOutput
+	Subtasks []TaskTreeOutput
 }
 
 func toTaskOutputs(tasks []*domain.Task) []TaskOutput {
@@ -27,11 +63,46 @@ func toTaskOutput(task *domain.Task) TaskOutput {
 		return TaskOutput{}
 	}
 
-	return TaskOutput{
-		ID:        task.ID,
-		Title:     task.Title,
-		Status:    string(task.Status),
-		CreatedAt: task.CreatedAt,
-		UpdatedAt: task.UpdatedAt,
+	output := TaskOutput{
+		ID:          task.ID,
+		WorkspaceID: task.WorkspaceID,
+		Title:       task.Title,
+		Status:      string(task.Status),
+		Priority:    string(task.Priority),
+		DueAt:       task.DueAt,
+		Progress: ProgressOutput{
+			Total:     task.Subtasks.Total,
+			Completed: task.Subtasks.Completed,
+		},
+		Assignees:    task.Assignees,
+		Labels:       task.Labels,
+		CommentCount: task.CommentCount,
+		CreatedAt:    task.CreatedAt,
+		UpdatedAt:    task.UpdatedAt,
+		DeletedAt:    task.DeletedAt,
 	}
+	if task.ProjectID != nil {
+		output.ProjectID = *task.ProjectID
+	}
+	if task.ParentID != nil {
+		output.ParentID = *task.ParentID
+	}
+	if task.Recurrence != nil {
+		output.Recurrence = task.Recurrence.String()
+	}
+	if task.SeriesID != nil {
+		output.SeriesID = *task.SeriesID
+	}
+	return output
+}
+
+func toTaskTreeOutputs(trees []*domain.Tree) []TaskTreeOutput {
+	outputs := make([]TaskTreeOutput, 0, len(trees))
+	for _, tree := range trees {
+		outputs = append(outputs, TaskTreeOutput{
+			TaskOutput: toTaskOutput(tree.Task),
+			Subtasks:   toTaskTreeOutputs(tree.Subtasks),
+		})
+	}
+	return outputs
 }
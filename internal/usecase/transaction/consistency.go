@@ -0,0 +1,18 @@
+package transaction
+
+import "context"
+
+type readYourWritesKey struct{}
+
+// WithReadYourWrites asks repositories that serve reads from a replica to
+// read from the primary instead, so the reads see every write committed
+// before them. Reads inside a transaction always go to the primary.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+// ReadYourWrites reports whether ctx was marked by WithReadYourWrites.
+func ReadYourWrites(ctx context.Context) bool {
+	marked, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return marked
+}
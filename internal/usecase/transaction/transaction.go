@@ -0,0 +1,13 @@
+//go:generate mockgen -source=transaction.go -destination=mocks/mock_transactor.go -package=mocks
+
+package transaction
+
+import "context"
+
+// Transactor groups repository calls into a single unit of work. Repositories
+// called with the ctx handed to fn join the transaction, which commits when fn
+// returns nil and rolls back otherwise. Nested calls join the outer
+// transaction.
+type Transactor interface {
+	Within(ctx context.Context, fn func(ctx context.Context) error) error
+}
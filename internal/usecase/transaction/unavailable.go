@@ -0,0 +1,19 @@
+package transaction
+
+import "time"
+
+// UnavailableError reports that storage cannot serve requests for now, after
+// transient failures or while a circuit breaker keeps calls away from it.
+// Callers may try again after RetryAfter.
+type UnavailableError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *UnavailableError) Error() string {
+	return "storage unavailable: " + e.Err.Error()
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
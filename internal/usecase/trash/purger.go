@@ -0,0 +1,50 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Purger periodically empties the trash of tasks deleted longer than the
+// retention period ago. Purging is idempotent, so replicas may run it
+// concurrently.
+type Purger struct {
+	store     Store
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewPurger(store Store, retention time.Duration) *Purger {
+	return &Purger{store: store, retention: retention, now: time.Now}
+}
+
+// Run calls Tick every interval until ctx is cancelled.
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Tick(ctx, p.now()); err != nil {
+				log.Printf("trash: %v", err)
+			}
+		}
+	}
+}
+
+// Tick purges the tasks whose retention has run out at now.
+func (p *Purger) Tick(ctx context.Context, now time.Time) error {
+	purged, err := p.store.Purge(ctx, now.Add(-p.retention))
+	if err != nil {
+		return fmt.Errorf("purge deleted tasks: %w", err)
+	}
+	if purged > 0 {
+		log.Printf("trash: purged %d tasks deleted more than %s ago", purged, p.retention)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package trash_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/trash"
+	"github.com/ko44d/go-clean-hexapp/internal/usecase/trash/mocks"
+)
+
+func TestTrashPurger(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Trash Purger Suite")
+}
+
+var _ = Describe("Purger", func() {
+	var (
+		ctrl      *gomock.Controller
+		mockStore *mocks.MockStore
+		purger    *trash.Purger
+		ctx       context.Context
+		now       time.Time
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockStore = mocks.NewMockStore(ctrl)
+		purger = trash.NewPurger(mockStore, 30*24*time.Hour)
+		ctx = context.Background()
+		now = time.Date(2025, 10, 31, 12, 0, 0, 0, time.UTC)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("purges the tasks deleted before the retention period", func() {
+		mockStore.EXPECT().Purge(ctx, time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)).Return(int64(3), nil)
+
+		Expect(purger.Tick(ctx, now)).To(Succeed())
+	})
+
+	It("returns store failures", func() {
+		storeErr := errors.New("connection refused")
+		mockStore.EXPECT().Purge(ctx, gomock.Any()).Return(int64(0), storeErr)
+
+		err := purger.Tick(ctx, now)
+
+		Expect(err).To(MatchError(storeErr))
+	})
+})
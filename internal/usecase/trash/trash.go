@@ -0,0 +1,15 @@
+//go:generate mockgen -source=trash.go -destination=mocks/mock_trash.go -package=mocks
+package trash
+
+import (
+	"context"
+	"time"
+)
+
+// Store permanently removes deleted tasks. Purging runs across tenants, so
+// implementations must not rely on a workspace in ctx.
+type Store interface {
+	// Purge hard-deletes the tasks deleted before deletedBefore and reports
+	// how many were removed.
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
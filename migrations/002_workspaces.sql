@@ -0,0 +1,16 @@
+-- Tasks created before workspaces existed are assigned to the nil workspace.
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS workspace_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE tasks ALTER COLUMN workspace_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON tasks(workspace_id);
+
+-- The application sets app.workspace_id with SET LOCAL semantics at the start
+-- of every transaction. Superusers bypass RLS, so the service must connect as
+-- a regular role for these policies to apply.
+ALTER TABLE tasks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tasks FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY tasks_workspace_isolation ON tasks
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
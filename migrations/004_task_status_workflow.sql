@@ -0,0 +1,4 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_status_check
+    CHECK (status IN ('todo', 'in_progress', 'blocked', 'complete', 'cancelled'));
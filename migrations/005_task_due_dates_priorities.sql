@@ -0,0 +1,12 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS due_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS priority TEXT NOT NULL DEFAULT 'medium'
+        CHECK (priority IN ('low', 'medium', 'high', 'urgent'));
+
+-- Backs overdue listings and "due within N hours" lookups, which only ever
+-- consider open tasks with a due date.
+CREATE INDEX IF NOT EXISTS idx_tasks_open_due_at
+    ON tasks(workspace_id, due_at)
+    WHERE due_at IS NOT NULL AND status IN ('todo', 'in_progress', 'blocked');
+
+CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(workspace_id, priority);
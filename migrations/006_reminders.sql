@@ -0,0 +1,30 @@
+-- One row per delivered reminder. The due date is part of the key so that a
+-- rescheduled task is reminded again.
+CREATE TABLE IF NOT EXISTS reminders_sent (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    workspace_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('due_soon', 'overdue')),
+    due_at TIMESTAMPTZ NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (task_id, kind, due_at)
+    );
+
+ALTER TABLE reminders_sent ENABLE ROW LEVEL SECURITY;
+ALTER TABLE reminders_sent FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY reminders_sent_workspace_isolation ON reminders_sent
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
+
+-- The scheduler has to find work across all workspaces, which row level
+-- security hides from the application role. This function only exposes
+-- workspace ids; the tasks themselves are still read per workspace.
+CREATE OR REPLACE FUNCTION reminder_workspaces(due_before TIMESTAMPTZ)
+    RETURNS SETOF UUID
+    LANGUAGE sql STABLE SECURITY DEFINER
+    SET search_path = public
+AS $$
+    SELECT DISTINCT workspace_id FROM tasks
+    WHERE due_at IS NOT NULL AND due_at < due_before
+      AND status IN ('todo', 'in_progress', 'blocked')
+$$;
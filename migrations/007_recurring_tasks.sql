@@ -0,0 +1,9 @@
+-- recurrence holds an RRULE subset such as FREQ=WEEKLY;BYDAY=MO. Occurrences
+-- of one series share series_id, the id of the first occurrence; it is not a
+-- foreign key so that deleting an old occurrence keeps the series together.
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS recurrence TEXT,
+    ADD COLUMN IF NOT EXISTS series_id UUID,
+    ADD COLUMN IF NOT EXISTS occurrence INTEGER NOT NULL DEFAULT 0 CHECK (occurrence >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_series_id ON tasks(workspace_id, series_id) WHERE series_id IS NOT NULL;
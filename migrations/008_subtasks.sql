@@ -0,0 +1,7 @@
+-- Subtasks reference their parent in the same workspace. Depth limits and
+-- cycle checks live in the domain; the CHECK only rejects direct self loops.
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES tasks(id) ON DELETE CASCADE,
+    ADD CONSTRAINT tasks_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id) WHERE parent_id IS NOT NULL;
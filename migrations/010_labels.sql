@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS labels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 50),
+    color TEXT NOT NULL CHECK (color ~ '^#[0-9a-f]{6}$'),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (workspace_id, name)
+    );
+
+ALTER TABLE labels ENABLE ROW LEVEL SECURITY;
+ALTER TABLE labels FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY labels_workspace_isolation ON labels
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
+
+-- The primary key serves lookups by task; the label_id index serves the
+-- label filters of GET /tasks and deleting a label.
+CREATE TABLE IF NOT EXISTS task_labels (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    workspace_id UUID NOT NULL,
+    PRIMARY KEY (task_id, label_id)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels(label_id, task_id);
+
+ALTER TABLE task_labels ENABLE ROW LEVEL SECURITY;
+ALTER TABLE task_labels FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY task_labels_workspace_isolation ON task_labels
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
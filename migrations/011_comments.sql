@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author_id TEXT NOT NULL,
+    body TEXT NOT NULL CHECK (length(body) BETWEEN 1 AND 10000),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    edited_at TIMESTAMP
+    );
+
+-- Serves keyset pagination of a task's thread and the comment counts of
+-- task listings.
+CREATE INDEX IF NOT EXISTS idx_comments_task_id_created_at ON comments(task_id, created_at, id);
+
+ALTER TABLE comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comments FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY comments_workspace_isolation ON comments
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
@@ -0,0 +1,19 @@
+-- user_id is the caller ID forwarded by the gateway (X-User-ID); users are
+-- not stored by this service.
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL CHECK (length(user_id) > 0),
+    workspace_id UUID NOT NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (task_id, user_id)
+    );
+
+-- Serves GET /tasks?assignee=me.
+CREATE INDEX IF NOT EXISTS idx_task_assignees_user_id ON task_assignees(user_id, task_id);
+
+ALTER TABLE task_assignees ENABLE ROW LEVEL SECURITY;
+ALTER TABLE task_assignees FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY task_assignees_workspace_isolation ON task_assignees
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
@@ -0,0 +1,46 @@
+-- Append-only history of task changes. task_id deliberately has no foreign
+-- key so that records outlive the tasks they describe.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY,
+    workspace_id UUID NOT NULL,
+    task_id UUID NOT NULL,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'complete', 'delete')),
+    changes JSONB NOT NULL,
+    request_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+    );
+
+-- Serves GET /tasks/:id/history and GET /audit?since=.
+CREATE INDEX IF NOT EXISTS idx_audit_log_task_id ON audit_log(task_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(workspace_id, created_at, id);
+
+ALTER TABLE audit_log ENABLE ROW LEVEL SECURITY;
+ALTER TABLE audit_log FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY audit_log_workspace_read ON audit_log FOR SELECT
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
+
+CREATE POLICY audit_log_workspace_append ON audit_log FOR INSERT
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
+
+-- Records are immutable. Without UPDATE or DELETE policies row level security
+-- already hides every row from such statements; the triggers make attempts
+-- fail loudly, also for roles that bypass RLS or truncate the table.
+CREATE OR REPLACE FUNCTION audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_immutable ON audit_log;
+CREATE TRIGGER audit_log_immutable
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_immutable();
+
+REVOKE UPDATE, DELETE, TRUNCATE ON audit_log FROM PUBLIC;
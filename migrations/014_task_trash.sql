@@ -0,0 +1,36 @@
+-- Deleted tasks stay in the trash until they are restored or purged. A task
+-- and the subtasks deleted along with it share the same deleted_at.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Serves GET /trash and the purger.
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(workspace_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'complete', 'delete', 'restore'));
+
+-- Deleted tasks are never reminded about.
+CREATE OR REPLACE FUNCTION reminder_workspaces(due_before TIMESTAMPTZ)
+    RETURNS SETOF UUID
+    LANGUAGE sql STABLE SECURITY DEFINER
+    SET search_path = public
+AS $$
+    SELECT DISTINCT workspace_id FROM tasks
+    WHERE due_at IS NOT NULL AND due_at < due_before
+      AND status IN ('todo', 'in_progress', 'blocked')
+      AND deleted_at IS NULL
+$$;
+
+-- The purger empties the trash of every workspace, which row level security
+-- hides from the application role. Rows attached to the tasks go with them
+-- through ON DELETE CASCADE.
+CREATE OR REPLACE FUNCTION purge_deleted_tasks(deleted_before TIMESTAMP)
+    RETURNS BIGINT
+    LANGUAGE sql VOLATILE SECURITY DEFINER
+    SET search_path = public
+AS $$
+    WITH purged AS (
+        DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < deleted_before RETURNING 1
+    )
+    SELECT count(*) FROM purged
+$$;
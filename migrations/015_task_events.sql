@@ -0,0 +1,62 @@
+-- Event store backing TASK_STORE=events. Each task has a stream of events
+-- numbered from 1; the primary key turns two writers appending the same
+-- version into a unique violation, which the repository reports as a
+-- version conflict.
+CREATE TABLE IF NOT EXISTS task_events (
+    task_id UUID NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    workspace_id UUID NOT NULL,
+    type TEXT NOT NULL CHECK (type IN (
+        'TaskCreated', 'TaskRenamed', 'TaskCompleted', 'TaskStatusChanged', 'TaskRescheduled',
+        'TaskMoved', 'TaskAssigned', 'TaskUnassigned', 'TaskDeleted', 'TaskRestored'
+    )),
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (task_id, version)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_task_events_workspace_id ON task_events(workspace_id, task_id, version);
+
+-- The latest snapshot of each stream; replay starts after its version.
+CREATE TABLE IF NOT EXISTS task_snapshots (
+    task_id UUID PRIMARY KEY,
+    workspace_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    state JSONB NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_task_snapshots_workspace_id ON task_snapshots(workspace_id);
+
+ALTER TABLE task_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE task_events FORCE ROW LEVEL SECURITY;
+ALTER TABLE task_snapshots ENABLE ROW LEVEL SECURITY;
+ALTER TABLE task_snapshots FORCE ROW LEVEL SECURITY;
+
+CREATE POLICY task_events_workspace_read ON task_events FOR SELECT
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
+
+CREATE POLICY task_events_workspace_append ON task_events FOR INSERT
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
+
+CREATE POLICY task_snapshots_workspace_isolation ON task_snapshots
+    USING (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid)
+    WITH CHECK (workspace_id = NULLIF(current_setting('app.workspace_id', true), '')::uuid);
+
+-- Events are immutable, like audit_log.
+CREATE OR REPLACE FUNCTION task_events_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'task_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS task_events_immutable ON task_events;
+CREATE TRIGGER task_events_immutable
+    BEFORE UPDATE OR DELETE ON task_events
+    FOR EACH ROW EXECUTE FUNCTION task_events_immutable();
+
+DROP TRIGGER IF EXISTS task_events_no_truncate ON task_events;
+CREATE TRIGGER task_events_no_truncate
+    BEFORE TRUNCATE ON task_events
+    FOR EACH STATEMENT EXECUTE FUNCTION task_events_immutable();
+
+REVOKE UPDATE, DELETE, TRUNCATE ON task_events FROM PUBLIC;
@@ -0,0 +1,45 @@
+-- Tells replicas caching task reads (TASK_CACHE_NOTIFY) which workspace
+-- changed. Notifications are sent on commit, and Postgres folds identical
+-- ones within a transaction, so a bulk change costs one per workspace.
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('task_changes', OLD.workspace_id::text);
+    ELSE
+        PERFORM pg_notify('task_changes', NEW.workspace_id::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_notify_change ON tasks;
+CREATE TRIGGER tasks_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
+
+DROP TRIGGER IF EXISTS task_assignees_notify_change ON task_assignees;
+CREATE TRIGGER task_assignees_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON task_assignees
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
+
+-- Labels and comment counts are part of the cached task lists.
+DROP TRIGGER IF EXISTS labels_notify_change ON labels;
+CREATE TRIGGER labels_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON labels
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
+
+DROP TRIGGER IF EXISTS task_labels_notify_change ON task_labels;
+CREATE TRIGGER task_labels_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON task_labels
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
+
+DROP TRIGGER IF EXISTS comments_notify_change ON comments;
+CREATE TRIGGER comments_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON comments
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
+
+-- TASK_STORE=events only ever appends.
+DROP TRIGGER IF EXISTS task_events_notify_change ON task_events;
+CREATE TRIGGER task_events_notify_change
+    AFTER INSERT ON task_events
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
@@ -0,0 +1,44 @@
+-- Token buckets of the Postgres rate limit store (RATE_LIMIT_STORE=postgres),
+-- shared by every replica. Buckets belong to clients rather than workspaces,
+-- so the table has no row level security.
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
+
+-- Refills the bucket for the time passed since its last request and takes a
+-- token if there is one, in one round trip. The row lock serialises the
+-- requests of a client across replicas; the database clock keeps replicas
+-- with skewed clocks from refilling buckets early. The time is read once the
+-- row is locked, so a caller that waited never moves updated_at backwards.
+CREATE OR REPLACE FUNCTION take_rate_limit_token(bucket_key TEXT, burst INTEGER, period_seconds DOUBLE PRECISION)
+RETURNS TABLE (allowed BOOLEAN, tokens DOUBLE PRECISION) AS $$
+DECLARE
+    taken_at TIMESTAMPTZ;
+    last_tokens DOUBLE PRECISION;
+    last_updated_at TIMESTAMPTZ;
+    available DOUBLE PRECISION;
+BEGIN
+    INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+    VALUES (bucket_key, burst, clock_timestamp())
+    ON CONFLICT (key) DO NOTHING;
+
+    SELECT b.tokens, b.updated_at
+    INTO last_tokens, last_updated_at
+    FROM rate_limit_buckets b
+    WHERE b.key = bucket_key
+    FOR UPDATE;
+
+    taken_at := clock_timestamp();
+    available := LEAST(burst, last_tokens + GREATEST(EXTRACT(EPOCH FROM taken_at - last_updated_at), 0) * burst / period_seconds);
+
+    allowed := available >= 1;
+    tokens := CASE WHEN allowed THEN available - 1 ELSE available END;
+    UPDATE rate_limit_buckets b SET tokens = take_rate_limit_token.tokens, updated_at = taken_at
+    WHERE b.key = bucket_key;
+    RETURN NEXT;
+END;
+$$ LANGUAGE plpgsql;
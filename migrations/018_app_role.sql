@@ -0,0 +1,30 @@
+-- The application connects as its own role rather than as the superuser the
+-- Postgres container creates: superusers and roles with BYPASSRLS skip row
+-- level security, so the workspace policies would never apply to them. The
+-- name and password come from APP_DB_USER and APP_DB_PASSWORD, which
+-- docker-compose.yml sets from POSTGRES_USER and POSTGRES_PASSWORD in .env.
+-- The tables stay owned by the superuser running the migrations, and the
+-- SECURITY DEFINER functions keep running as it.
+\getenv app_user APP_DB_USER
+\getenv app_password APP_DB_PASSWORD
+
+SELECT format('CREATE ROLE %I LOGIN NOSUPERUSER NOBYPASSRLS NOCREATEDB NOCREATEROLE PASSWORD %L',
+              :'app_user', :'app_password')
+WHERE NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = :'app_user')
+\gexec
+
+SELECT format('ALTER ROLE %I NOSUPERUSER NOBYPASSRLS', :'app_user')
+\gexec
+
+SELECT format('GRANT CONNECT ON DATABASE %I TO %I', current_database(), :'app_user')
+\gexec
+SELECT format('GRANT USAGE ON SCHEMA public TO %I', :'app_user')
+\gexec
+SELECT format('GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO %I', :'app_user')
+\gexec
+SELECT format('GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO %I', :'app_user')
+\gexec
+
+-- The audit log and the event store are append-only (013, 015).
+SELECT format('REVOKE UPDATE, DELETE ON audit_log, task_events FROM %I', :'app_user')
+\gexec